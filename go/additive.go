@@ -7,21 +7,58 @@ import "math"
 type Additive struct {
 	Mean   float64
 	StdDev float64
+	open   bool // draw u1 with UniformOpen (see NewAdditiveOpen)
 }
 
 // NewAdditive creates a new additive (normal) distribution.
-// Panics if stdDev <= 0.
+// Panics if mean is not finite or stdDev is not positive and finite.
 func NewAdditive(mean, stdDev float64) *Additive {
-	if stdDev <= 0 {
-		panic("stdDev must be positive")
+	a, err := TryNewAdditive(mean, stdDev)
+	if err != nil {
+		panic(err.Error())
 	}
-	return &Additive{Mean: mean, StdDev: stdDev}
+	return a
+}
+
+// TryNewAdditive is NewAdditive returning a *ParameterError instead of
+// panicking.
+func TryNewAdditive(mean, stdDev float64) (*Additive, error) {
+	if !isFinite(mean) {
+		return nil, newParameterError("Additive", "mean", "mean must be finite")
+	}
+	if !(stdDev > 0) || math.IsInf(stdDev, 1) {
+		return nil, newParameterError("Additive", "stdDev", "stdDev must be positive and finite")
+	}
+	return &Additive{Mean: mean, StdDev: stdDev}, nil
+}
+
+// NewAdditiveOpen is NewAdditive drawing the Box-Muller radius uniform with
+// UniformOpen instead of clamping 0 to the smallest subnormal. It produces
+// the same values as NewAdditive except on the (probability 2^-53) draws
+// where the clamp would have fired.
+func NewAdditiveOpen(mean, stdDev float64) *Additive {
+	a := NewAdditive(mean, stdDev)
+	a.open = true
+	return a
 }
 
 // Sample generates a single sample from the additive distribution.
 func (a *Additive) Sample(rng *Rng) float64 {
+	return a.Mean + standardNormal(rng, a.open)*a.StdDev
+}
+
+// antitheticPair reflects the standard normal draw: mean ± z·stdDev. Using
+// 1-u in Box-Muller would not help, because cos(2π(1-u)) = cos(2πu).
+func (a *Additive) antitheticPair(rng *Rng) (float64, float64) {
+	z := standardNormal(rng, a.open)
+	return a.Mean + z*a.StdDev, a.Mean - z*a.StdDev
+}
+
+// standardNormal draws a standard normal value from two uniforms; open selects
+// UniformOpen for the radius uniform.
+func standardNormal(rng *Rng, open bool) float64 {
 	// Box-Muller transform
-	u1 := rng.UniformFloat64()
+	u1 := rng.uniformFor(open)
 	u2 := rng.UniformFloat64()
 
 	// Avoid log(0) - use smallest positive subnormal for cross-language consistency
@@ -33,16 +70,69 @@ func (a *Additive) Sample(rng *Rng) float64 {
 	theta := 2.0 * math.Pi * u2
 
 	// Use the first of the two Box-Muller outputs
-	z := r * math.Cos(theta)
-
-	return a.Mean + z*a.StdDev
+	return r * math.Cos(theta)
 }
 
 // Samples generates multiple samples from the additive distribution.
 func (a *Additive) Samples(rng *Rng, count int) []float64 {
-	result := make([]float64, count)
-	for i := 0; i < count; i++ {
-		result[i] = a.Sample(rng)
+	return sampleN(a, rng, count)
+}
+
+// SamplesInto fills dst with samples from the additive distribution without allocating.
+func (a *Additive) SamplesInto(rng *Rng, dst []float64) {
+	fillSamples(a, rng, dst)
+}
+
+// DrawsPerSample returns 2, the two uniforms of one Box–Muller draw, or
+// VariableDraws for NewAdditiveOpen, which rejects a zero first uniform.
+func (a *Additive) DrawsPerSample() int {
+	if a.open {
+		return VariableDraws
+	}
+	return 2
+}
+
+// Pdf returns the probability density at x.
+func (a *Additive) Pdf(x float64) float64 {
+	z := (x - a.Mean) / a.StdDev
+	return math.Exp(-z*z/2) / (a.StdDev * math.Sqrt(2*math.Pi))
+}
+
+// Cdf returns P(X <= x).
+func (a *Additive) Cdf(x float64) float64 {
+	return 0.5 * math.Erfc(-(x-a.Mean)/(a.StdDev*math.Sqrt2))
+}
+
+// Quantile returns the inverse CDF. Quantile(0) is -Inf, Quantile(1) is
+// +Inf, and p outside [0, 1] gives NaN.
+func (a *Additive) Quantile(p float64) float64 {
+	if invalidProbability(p) {
+		return math.NaN()
 	}
-	return result
+	return a.Mean + a.StdDev*gaussQuantile(p)
+}
+
+// TrueCenter returns the population Center, which equals the mean by symmetry.
+func (a *Additive) TrueCenter() float64 {
+	return a.Mean
+}
+
+// TrueSpread returns the population Spread: √2·Φ⁻¹(3/4)·stdDev ≈ 0.9539·stdDev.
+func (a *Additive) TrueSpread() float64 {
+	return spreadOfNormal * a.StdDev
+}
+
+// TrueMean returns the population mean, the mean parameter.
+func (a *Additive) TrueMean() float64 {
+	return a.Mean
+}
+
+// TrueVariance returns the population variance stdDev².
+func (a *Additive) TrueVariance() float64 {
+	return a.StdDev * a.StdDev
+}
+
+// TrueStdDev returns the population standard deviation, √TrueVariance.
+func (a *Additive) TrueStdDev() float64 {
+	return math.Sqrt(a.TrueVariance())
 }
@@ -0,0 +1,122 @@
+package pragmastat
+
+import "math"
+
+// Affine is the distribution of offset + scale·X for X drawn from an inner
+// distribution, e.g. Exp noise shifted to start at 100 ms. A negative scale
+// reflects the inner distribution.
+type Affine struct {
+	Inner  Distribution
+	Scale  float64
+	Offset float64
+}
+
+// NewAffine creates an affine transform of inner.
+// Panics if inner is nil or scale is zero or not finite.
+func NewAffine(inner Distribution, scale, offset float64) *Affine {
+	a, err := TryNewAffine(inner, scale, offset)
+	if err != nil {
+		panic(err.Error())
+	}
+	return a
+}
+
+// TryNewAffine is NewAffine returning a *ParameterError instead of panicking.
+func TryNewAffine(inner Distribution, scale, offset float64) (*Affine, error) {
+	if inner == nil {
+		return nil, newParameterError("Affine", "inner", "inner cannot be nil")
+	}
+	if scale == 0 || math.IsNaN(scale) || math.IsInf(scale, 0) {
+		return nil, newParameterError("Affine", "scale", "scale must be finite and non-zero")
+	}
+	if math.IsNaN(offset) || math.IsInf(offset, 0) {
+		return nil, newParameterError("Affine", "offset", "offset must be finite")
+	}
+	return &Affine{Inner: inner, Scale: scale, Offset: offset}, nil
+}
+
+// Sample returns offset + scale·inner.Sample(rng), consuming exactly the
+// random numbers of one inner draw.
+func (a *Affine) Sample(rng *Rng) float64 {
+	return a.transform(a.Inner.Sample(rng))
+}
+
+// Samples generates multiple samples from the transformed distribution.
+func (a *Affine) Samples(rng *Rng, count int) []float64 {
+	return sampleN(a, rng, count)
+}
+
+// SamplesInto fills dst with samples from the transformed distribution without allocating.
+func (a *Affine) SamplesInto(rng *Rng, dst []float64) {
+	fillSamples(a, rng, dst)
+}
+
+// DrawsPerSample returns the inner distribution's count.
+func (a *Affine) DrawsPerSample() int {
+	return DrawsPerSample(a.Inner)
+}
+
+func (a *Affine) transform(x float64) float64 {
+	return a.Offset + a.Scale*x
+}
+
+// Pdf returns inner.Pdf((x - offset)/scale)/|scale|, or NaN if the inner
+// distribution is not a FullDistribution.
+func (a *Affine) Pdf(x float64) float64 {
+	inner, ok := a.Inner.(FullDistribution)
+	if !ok {
+		return math.NaN()
+	}
+	return inner.Pdf((x-a.Offset)/a.Scale) / math.Abs(a.Scale)
+}
+
+// Cdf returns P(X <= x), or NaN if the inner distribution is not a
+// FullDistribution. A negative scale reflects the inner CDF:
+// 1 - inner.Cdf((x - offset)/scale), which assumes the inner distribution has
+// no atoms.
+func (a *Affine) Cdf(x float64) float64 {
+	inner, ok := a.Inner.(FullDistribution)
+	if !ok {
+		return math.NaN()
+	}
+	z := (x - a.Offset) / a.Scale
+	if a.Scale < 0 {
+		return 1 - inner.Cdf(z)
+	}
+	return inner.Cdf(z)
+}
+
+// Quantile returns offset + scale·inner.Quantile(p), using 1 - p for a
+// negative scale, or NaN if the inner distribution is not a
+// FullDistribution.
+func (a *Affine) Quantile(p float64) float64 {
+	inner, ok := a.Inner.(FullDistribution)
+	if !ok || invalidProbability(p) {
+		return math.NaN()
+	}
+	if a.Scale < 0 {
+		p = 1 - p
+	}
+	return a.transform(inner.Quantile(p))
+}
+
+// TrueCenter returns offset + scale·(inner TrueCenter), since Center is
+// equivariant under shifts, scaling, and reflection. Returns NaN if the
+// inner distribution does not provide TrueCenter.
+func (a *Affine) TrueCenter() float64 {
+	inner, ok := a.Inner.(interface{ TrueCenter() float64 })
+	if !ok {
+		return math.NaN()
+	}
+	return a.transform(inner.TrueCenter())
+}
+
+// TrueSpread returns |scale|·(inner TrueSpread). Returns NaN if the inner
+// distribution does not provide TrueSpread.
+func (a *Affine) TrueSpread() float64 {
+	inner, ok := a.Inner.(interface{ TrueSpread() float64 })
+	if !ok {
+		return math.NaN()
+	}
+	return math.Abs(a.Scale) * inner.TrueSpread()
+}
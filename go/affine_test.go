@@ -0,0 +1,107 @@
+package pragmastat
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// TestAffineEstimatorInvariance draws the same stream through an inner
+// distribution and its affine transform, so Center and Spread of the large
+// samples must transform exactly as the invariance properties predict.
+func TestAffineEstimatorInvariance(t *testing.T) {
+	const n = 2000
+	inner := NewExp(1)
+	for _, c := range []struct{ scale, offset float64 }{{1, 100}, {3, -2}, {-0.5, 7}} {
+		a := NewAffine(inner, c.scale, c.offset)
+		x := inner.Samples(NewRngFromString("affine-invariance"), n)
+		y := a.Samples(NewRngFromString("affine-invariance"), n)
+		for i := range x {
+			if y[i] != c.offset+c.scale*x[i] {
+				t.Fatalf("scale %v: draw %d = %v, want %v", c.scale, i, y[i], c.offset+c.scale*x[i])
+			}
+		}
+
+		centerX, _ := Center(x, false)
+		centerY, _ := Center(y, false)
+		if want := c.offset + c.scale*centerX; !floatEquals(centerY, want, invarianceTolerance) {
+			t.Errorf("scale %v: Center = %v, want %v", c.scale, centerY, want)
+		}
+		spreadX, _ := Spread(x, false)
+		spreadY, _ := Spread(y, false)
+		if want := math.Abs(c.scale) * spreadX; !floatEquals(spreadY, want, invarianceTolerance) {
+			t.Errorf("scale %v: Spread = %v, want %v", c.scale, spreadY, want)
+		}
+
+		if want := c.offset + c.scale*inner.TrueCenter(); !floatEquals(a.TrueCenter(), want, 1e-12) {
+			t.Errorf("scale %v: TrueCenter = %v, want %v", c.scale, a.TrueCenter(), want)
+		}
+		if want := math.Abs(c.scale) * inner.TrueSpread(); !floatEquals(a.TrueSpread(), want, 1e-12) {
+			t.Errorf("scale %v: TrueSpread = %v, want %v", c.scale, a.TrueSpread(), want)
+		}
+	}
+}
+
+func TestAffineFunctions(t *testing.T) {
+	inner := NewGamma(2.5, 0.5)
+	for _, scale := range []float64{2, -2} {
+		a := NewAffine(inner, scale, 10)
+		for i := 1; i < 100; i++ {
+			p := float64(i) / 100
+			x := a.Quantile(p)
+			if got := a.Cdf(x); !floatEquals(got, p, 1e-12) {
+				t.Errorf("scale %v: Cdf(Quantile(%v)) = %v", scale, p, got)
+			}
+			h := 1e-5 * math.Max(1, math.Abs(x))
+			numeric := (a.Cdf(x+h) - a.Cdf(x-h)) / (2 * h)
+			if pdf := a.Pdf(x); math.Abs(pdf-numeric) > 1e-6 {
+				t.Errorf("scale %v: Pdf(%v) = %v, finite difference %v", scale, x, pdf, numeric)
+			}
+		}
+	}
+
+	// Exp starting at 100 ms; reflected, it ends at 100 ms.
+	shifted, reflected := NewAffine(NewExp(1), 1, 100), NewAffine(NewExp(1), -1, 100)
+	if shifted.Cdf(99) != 0 || shifted.Quantile(0) != 100 || shifted.Quantile(1) != math.Inf(1) {
+		t.Errorf("shifted: Cdf(99) = %v, Quantile(0) = %v, Quantile(1) = %v",
+			shifted.Cdf(99), shifted.Quantile(0), shifted.Quantile(1))
+	}
+	if reflected.Cdf(101) != 1 || reflected.Quantile(1) != 100 || reflected.Quantile(0) != math.Inf(-1) {
+		t.Errorf("reflected: Cdf(101) = %v, Quantile(0) = %v, Quantile(1) = %v",
+			reflected.Cdf(101), reflected.Quantile(0), reflected.Quantile(1))
+	}
+
+	// Composes with Mixture both ways.
+	m, err := NewMixture([]Distribution{NewUniform(0, 1), NewUniform(1, 2)}, []float64{1, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := NewAffine(m, 2, 1).Cdf(3); !floatEquals(got, 0.5, 1e-15) {
+		t.Errorf("Affine(Mixture).Cdf(3) = %v, want 0.5", got)
+	}
+
+	discrete := NewAffine(NewBinomial(5, 0.5), 2, 0)
+	if !math.IsNaN(discrete.Cdf(1)) || !math.IsNaN(discrete.Quantile(0.5)) || !math.IsNaN(discrete.TrueCenter()) {
+		t.Error("affine transform of a Binomial should return NaN for Cdf, Quantile, and TrueCenter")
+	}
+}
+
+func TestAffineParameterErrors(t *testing.T) {
+	for _, c := range []struct {
+		inner         Distribution
+		scale, offset float64
+		parameter     string
+	}{
+		{nil, 1, 0, "inner"},
+		{NewExp(1), 0, 0, "scale"},
+		{NewExp(1), math.NaN(), 0, "scale"},
+		{NewExp(1), math.Inf(1), 0, "scale"},
+		{NewExp(1), 1, math.Inf(-1), "offset"},
+	} {
+		_, err := TryNewAffine(c.inner, c.scale, c.offset)
+		var pe *ParameterError
+		if !errors.As(err, &pe) || pe.Distribution != "Affine" || pe.Parameter != c.parameter {
+			t.Errorf("TryNewAffine(%v, %v, %v) = %v, want Affine.%s error", c.inner, c.scale, c.offset, err, c.parameter)
+		}
+	}
+}
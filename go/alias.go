@@ -0,0 +1,92 @@
+package pragmastat
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// AliasTable draws category indices with probabilities proportional to a set
+// of weights in O(1) per draw after O(n) setup (Vose's alias method).
+// It is read-only after construction and safe for concurrent Draw calls with
+// distinct Rng instances.
+type AliasTable struct {
+	prob  []float64
+	alias []int
+}
+
+// NewAliasTable builds an alias table for the given weights. Weights must be
+// finite and non-negative with a positive sum; categories with zero weight
+// are never drawn. The construction is deterministic, so a given seed always
+// yields the same sequence of draws.
+func NewAliasTable(weights []float64) (*AliasTable, error) {
+	n := len(weights)
+	if n == 0 {
+		return nil, errors.New("weights cannot be empty")
+	}
+	total := 0.0
+	for i, w := range weights {
+		if math.IsNaN(w) || math.IsInf(w, 0) || w < 0 {
+			return nil, fmt.Errorf("weights[%d] must be finite and non-negative, got %v", i, w)
+		}
+		total += w
+	}
+	if total <= 0 || math.IsInf(total, 0) {
+		return nil, errors.New("total weight must be positive and finite")
+	}
+
+	prob := make([]float64, n)
+	alias := make([]int, n)
+	scaled := make([]float64, n)
+	small := make([]int, 0, n)
+	large := make([]int, 0, n)
+	for i, w := range weights {
+		scaled[i] = w / total * float64(n)
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+	for len(small) > 0 && len(large) > 0 {
+		s := small[len(small)-1]
+		small = small[:len(small)-1]
+		l := large[len(large)-1]
+		large = large[:len(large)-1]
+
+		prob[s] = scaled[s]
+		alias[s] = l
+		scaled[l] = (scaled[l] + scaled[s]) - 1
+		if scaled[l] < 1 {
+			small = append(small, l)
+		} else {
+			large = append(large, l)
+		}
+	}
+	// Leftovers are 1 up to rounding error.
+	for _, i := range large {
+		prob[i] = 1
+		alias[i] = i
+	}
+	for _, i := range small {
+		prob[i] = 1
+		alias[i] = i
+	}
+	return &AliasTable{prob: prob, alias: alias}, nil
+}
+
+// Len returns the number of categories.
+func (t *AliasTable) Len() int {
+	return len(t.prob)
+}
+
+// Draw returns a category index in [0, Len()) with probability proportional
+// to its weight. Each draw consumes exactly two values from rng: a uniform
+// column index and a uniform float64.
+func (t *AliasTable) Draw(rng *Rng) int {
+	i := rng.UniformIntN(0, len(t.prob))
+	if rng.UniformFloat64() < t.prob[i] {
+		return i
+	}
+	return t.alias[i]
+}
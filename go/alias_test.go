@@ -0,0 +1,121 @@
+package pragmastat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAliasTableFrequencies(t *testing.T) {
+	weights := []float64{1, 2, 0, 3, 4, 0.5}
+	table, err := NewAliasTable(weights)
+	if err != nil {
+		t.Fatalf("NewAliasTable: %v", err)
+	}
+	const draws = 1_000_000
+	counts := make([]int, len(weights))
+	rng := NewRngFromSeed(1729)
+	for i := 0; i < draws; i++ {
+		counts[table.Draw(rng)]++
+	}
+
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+	for i, w := range weights {
+		p := w / total
+		got := float64(counts[i]) / draws
+		sigma := math.Sqrt(p * (1 - p) / draws)
+		if math.Abs(got-p) > 5*sigma+1e-12 {
+			t.Errorf("category %d: frequency %v, want %v ± %v", i, got, p, 5*sigma)
+		}
+	}
+	if counts[2] != 0 {
+		t.Errorf("zero-weight category drawn %d times", counts[2])
+	}
+}
+
+func TestAliasTableSingleCategory(t *testing.T) {
+	table, err := NewAliasTable([]float64{7})
+	if err != nil {
+		t.Fatalf("NewAliasTable: %v", err)
+	}
+	rng := NewRngFromSeed(1729)
+	for i := 0; i < 100; i++ {
+		if got := table.Draw(rng); got != 0 {
+			t.Fatalf("Draw = %d, want 0", got)
+		}
+	}
+}
+
+func TestAliasTableDeterminism(t *testing.T) {
+	weights := []float64{3, 1, 4, 1, 5, 9, 2, 6}
+	a, _ := NewAliasTable(weights)
+	b, _ := NewAliasTable(weights)
+	rngA := NewRngFromString("alias")
+	rngB := NewRngFromString("alias")
+	for i := 0; i < 1000; i++ {
+		if x, y := a.Draw(rngA), b.Draw(rngB); x != y {
+			t.Fatalf("draw %d: %d vs %d for the same seed", i, x, y)
+		}
+	}
+}
+
+func TestAliasTableErrors(t *testing.T) {
+	for name, weights := range map[string][]float64{
+		"empty":    nil,
+		"negative": {1, -1, 2},
+		"nan":      {1, math.NaN()},
+		"inf":      {1, math.Inf(1)},
+		"zero sum": {0, 0, 0},
+		"overflow": {math.MaxFloat64, math.MaxFloat64},
+	} {
+		if _, err := NewAliasTable(weights); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+// linearScanDraw is the O(n) baseline that AliasTable replaces.
+func linearScanDraw(rng *Rng, weights []float64, total float64) int {
+	u := rng.UniformFloat64() * total
+	acc := 0.0
+	for i, w := range weights {
+		acc += w
+		if u < acc {
+			return i
+		}
+	}
+	return len(weights) - 1
+}
+
+func aliasBenchWeights() []float64 {
+	rng := NewRngFromSeed(1729)
+	weights := make([]float64, 10_000)
+	for i := range weights {
+		weights[i] = rng.UniformFloat64()
+	}
+	return weights
+}
+
+func BenchmarkAliasTableDraw(b *testing.B) {
+	table, _ := NewAliasTable(aliasBenchWeights())
+	rng := NewRngFromSeed(1729)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		table.Draw(rng)
+	}
+}
+
+func BenchmarkLinearScanDraw(b *testing.B) {
+	weights := aliasBenchWeights()
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+	rng := NewRngFromSeed(1729)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		linearScanDraw(rng, weights, total)
+	}
+}
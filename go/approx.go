@@ -0,0 +1,18 @@
+package pragmastat
+
+import "math"
+
+// ApproxEqual reports whether a and b are equal within an absolute tolerance:
+// |a-b| < epsilon. Identical values (including equal infinities) always
+// compare equal, and NaN is considered equal to NaN, so expected values that
+// are themselves NaN or ±Inf can be checked directly. Infinities of opposite
+// sign, or an infinity and a finite value, are never equal.
+func ApproxEqual(a, b, epsilon float64) bool {
+	if a == b {
+		return true
+	}
+	if math.IsNaN(a) && math.IsNaN(b) {
+		return true
+	}
+	return math.Abs(a-b) < epsilon
+}
@@ -0,0 +1,39 @@
+package pragmastat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestApproxEqual(t *testing.T) {
+	inf := math.Inf(1)
+	nan := math.NaN()
+	cases := []struct {
+		a, b, epsilon float64
+		want          bool
+	}{
+		{1, 1, 0, true},
+		{1, 1 + 1e-12, 1e-9, true},
+		{1, 1 + 1e-6, 1e-9, false},
+		{-3.5, -3.5000000001, 1e-9, true},
+		{0, -0.0, 0, true},
+		{1, 1.1, 0.1, false}, // strict inequality: |a-b| must be < epsilon
+		{inf, inf, 1e-9, true},
+		{-inf, -inf, 1e-9, true},
+		{inf, -inf, 1e-9, false},
+		{inf, math.MaxFloat64, 1e-9, false},
+		{inf, 1, inf, false},
+		{nan, nan, 1e-9, true},
+		{nan, 1, 1e-9, false},
+		{1, nan, inf, false},
+		{nan, inf, 1e-9, false},
+	}
+	for _, c := range cases {
+		if got := ApproxEqual(c.a, c.b, c.epsilon); got != c.want {
+			t.Errorf("ApproxEqual(%v, %v, %v) = %v, want %v", c.a, c.b, c.epsilon, got, c.want)
+		}
+		if got := ApproxEqual(c.b, c.a, c.epsilon); got != c.want {
+			t.Errorf("ApproxEqual(%v, %v, %v) = %v, want %v (symmetry)", c.b, c.a, c.epsilon, got, c.want)
+		}
+	}
+}
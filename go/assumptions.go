@@ -63,6 +63,77 @@ func NewDomainError(subject Subject) *AssumptionError {
 	return &AssumptionError{Violation: Violation{ID: Domain, Subject: subject}}
 }
 
+// CheckTwoSample validates the inputs of a two-sample estimator and returns the
+// highest-priority assumption violation, or nil if all checks pass.
+//
+// Checks run in canonical priority order with positional subjects:
+// validity(x), validity(y), then (when requirePositivity is true)
+// positivity(x), positivity(y), then (when requireSparity is true)
+// sparity(x), sparity(y). The sparity checks compute Spread of each sample.
+func CheckTwoSample(x, y []float64, requirePositivity, requireSparity bool) error {
+	if err := checkTwoSample(x, y, requirePositivity); err != nil {
+		return err
+	}
+	if requireSparity {
+		if _, _, err := checkSparityPair(x, y, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkTwoSample runs the validity and positivity checks of CheckTwoSample for
+// any element type, shared by the float64 and float32 estimators. Estimators
+// that require sparity call checkSparityPair afterwards and reuse the spreads.
+func checkTwoSample[T Number](x, y []T, requirePositivity bool) error {
+	if err := checkValidity(x, SubjectX); err != nil {
+		return err
+	}
+	if err := checkValidity(y, SubjectY); err != nil {
+		return err
+	}
+	if requirePositivity {
+		if err := checkPositivity(x, SubjectX); err != nil {
+			return err
+		}
+		if err := checkPositivity(y, SubjectY); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkPositivity returns a positivity error if any value is not strictly positive.
+func checkPositivity[T Number](x []T, subject Subject) error {
+	for _, v := range x {
+		if v <= 0 {
+			return NewPositivityError(subject)
+		}
+	}
+	return nil
+}
+
+// checkSparityPair computes the spreads of x and y for the two-sample
+// estimators that require both samples to be non tie-dominant. A sparity
+// error is reported for x before y.
+func checkSparityPair[T Number](x, y []T, assumeSorted bool) (spreadX, spreadY float64, err error) {
+	spreadX, err = spreadImpl(x, assumeSorted)
+	if err != nil {
+		return 0, 0, err
+	}
+	if spreadX <= 0 {
+		return 0, 0, NewSparityError(SubjectX)
+	}
+	spreadY, err = spreadImpl(y, assumeSorted)
+	if err != nil {
+		return 0, 0, err
+	}
+	if spreadY <= 0 {
+		return 0, 0, NewSparityError(SubjectY)
+	}
+	return spreadX, spreadY, nil
+}
+
 // Log log-transforms a slice. Returns error if any value is non-positive.
 func Log[T Number](values []T, subject Subject) ([]float64, error) {
 	result := make([]float64, len(values))
@@ -0,0 +1,213 @@
+package pragmastat
+
+import (
+	"math"
+	"testing"
+)
+
+// assertViolation fails unless err is an *AssumptionError with the given id and subject.
+func assertViolation(t *testing.T, err error, id AssumptionID, subject Subject) {
+	t.Helper()
+	ae, ok := err.(*AssumptionError)
+	if !ok {
+		t.Fatalf("expected *AssumptionError %s(%s), got %T: %v", id, subject, err, err)
+	}
+	if ae.Violation.ID != id || ae.Violation.Subject != subject {
+		t.Errorf("expected %s(%s), got %s", id, subject, ae.Violation)
+	}
+}
+
+func TestCheckTwoSample(t *testing.T) {
+	valid := []float64{1, 2, 3}
+	negative := []float64{-1, 2, 3}
+	nan := []float64{1, math.NaN(), 3}
+	constant := []float64{5, 5, 5}
+
+	cases := []struct {
+		name              string
+		x, y              []float64
+		requirePositivity bool
+		requireSparity    bool
+		id                AssumptionID
+		subject           Subject
+	}{
+		{"empty x", nil, valid, false, false, Validity, SubjectX},
+		{"empty y", valid, []float64{}, false, false, Validity, SubjectY},
+		{"nan x", nan, valid, true, true, Validity, SubjectX},
+		{"nan y", valid, nan, true, true, Validity, SubjectY},
+		{"validity y before positivity x", negative, nan, true, false, Validity, SubjectY},
+		{"positivity x", negative, valid, true, false, Positivity, SubjectX},
+		{"positivity y", valid, []float64{1, 0, 3}, true, false, Positivity, SubjectY},
+		{"positivity y before sparity x", constant, []float64{1, 0, 3}, true, true, Positivity, SubjectY},
+		{"sparity x", constant, valid, false, true, Sparity, SubjectX},
+		{"sparity y", valid, constant, false, true, Sparity, SubjectY},
+		{"sparity x before y", []float64{1}, constant, false, true, Sparity, SubjectX},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assertViolation(t, CheckTwoSample(c.x, c.y, c.requirePositivity, c.requireSparity), c.id, c.subject)
+		})
+	}
+
+	if err := CheckTwoSample(valid, negative, false, false); err != nil {
+		t.Errorf("positivity must not be checked when not required, got %v", err)
+	}
+	if err := CheckTwoSample(constant, constant, false, false); err != nil {
+		t.Errorf("sparity must not be checked when not required, got %v", err)
+	}
+	if err := CheckTwoSample(valid, valid, true, true); err != nil {
+		t.Errorf("expected no error for valid input, got %v", err)
+	}
+}
+
+func TestTwoSampleEstimatorViolations(t *testing.T) {
+	valid := []float64{1, 2, 3}
+	constant := []float64{5, 5, 5}
+
+	_, err := Shift(valid, nil, false)
+	assertViolation(t, err, Validity, SubjectY)
+
+	_, err = Ratio([]float64{0, 1, 2}, valid, false)
+	assertViolation(t, err, Positivity, SubjectX)
+
+	_, err = Ratio(valid, []float64{1, -2, 3}, false)
+	assertViolation(t, err, Positivity, SubjectY)
+
+	_, err = avgSpread(constant, valid, false)
+	assertViolation(t, err, Sparity, SubjectX)
+
+	_, err = Disparity(valid, constant, false)
+	assertViolation(t, err, Sparity, SubjectY)
+
+	_, err = Disparity([]float64{math.Inf(1)}, constant, false)
+	assertViolation(t, err, Validity, SubjectX)
+}
+
+func TestRatioViolations(t *testing.T) {
+	valid := []float64{1, 2, 3}
+
+	_, err := Ratio(nil, valid, false)
+	assertViolation(t, err, Validity, SubjectX)
+
+	_, err = Ratio(valid, []float64{}, false)
+	assertViolation(t, err, Validity, SubjectY)
+
+	_, err = Ratio(valid, []float64{2, 0, 1}, false)
+	assertViolation(t, err, Positivity, SubjectY)
+
+	// The internal quantile kernel reports the same typed errors.
+	_, err = ratioQuantilesImpl(valid, []float64{}, []float64{0.5}, false)
+	assertViolation(t, err, Validity, SubjectY)
+	_, err = ratioQuantilesImpl(valid, []float64{-1}, []float64{0.5}, false)
+	assertViolation(t, err, Positivity, SubjectY)
+
+	// The Sample path surfaces positivity(y) for the second argument.
+	x, err := NewSample(valid)
+	if err != nil {
+		t.Fatalf("NewSample: %v", err)
+	}
+	y, err := NewSample([]float64{1, -1, 2})
+	if err != nil {
+		t.Fatalf("NewSample: %v", err)
+	}
+	_, err = x.Ratio(y)
+	assertViolation(t, err, Positivity, SubjectY)
+}
+
+func TestRelSpreadZeroCenter(t *testing.T) {
+	_, err := RelSpread([]float64{-1, 1}, false)
+	assertViolation(t, err, Domain, SubjectX)
+
+	_, err = RelSpread([]float64{-3, -1, 0, 1, 3}, false)
+	assertViolation(t, err, Domain, SubjectX)
+
+	// Domain takes priority over sparity.
+	_, err = RelSpread([]float64{0, 0, 0}, false)
+	assertViolation(t, err, Domain, SubjectX)
+
+	got, err := RelSpread([]float64{-2, -4, -6}, false)
+	if err != nil {
+		t.Fatalf("RelSpread: %v", err)
+	}
+	if !floatEquals(got, 0.5, 1e-9) {
+		t.Errorf("RelSpread({-2,-4,-6}) = %v, want 0.5", got)
+	}
+}
+
+func TestRelSpreadTieDominant(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		x    []float64
+	}{
+		{"constant positive", []float64{5, 5, 5}},
+		{"constant negative", []float64{-2, -2}},
+		{"single value", []float64{7}},
+		{"tie-dominant", []float64{4, 4, 4, 4, 9}},
+	} {
+		for _, denom := range []RelSpreadDenominator{RelSpreadCenter, RelSpreadMedian} {
+			got, err := RelSpreadWith(c.x, denom, false)
+			if err != nil || got != 0 {
+				t.Errorf("%s, %v: got (%v, %v), want (0, nil)", c.name, denom, got, err)
+			}
+		}
+	}
+
+	_, err := RelSpread([]float64{0, 0, 0}, false)
+	assertViolation(t, err, Domain, SubjectX)
+	_, err = RelSpreadWith([]float64{0, 0}, RelSpreadMedian, false)
+	assertViolation(t, err, Domain, SubjectX)
+
+	got, err := RelSpread([]float64{1, 2, 3, 4, 5}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !floatEquals(got, 2.0/3, 1e-9) {
+		t.Errorf("RelSpread({1..5}) = %v, want 2/3", got)
+	}
+}
+
+func TestRelSpreadWith(t *testing.T) {
+	// Right-skewed: the Walsh-average median lies above the sample median.
+	x := []float64{1, 2, 3, 4, 5, 8, 13, 40}
+	center, _ := Center(x, false)
+	median, _ := Median(x)
+	spread, _ := Spread(x, false)
+	if center == median {
+		t.Fatalf("test data must have Center != Median, both are %v", center)
+	}
+
+	byCenter, err := RelSpreadWith(x, RelSpreadCenter, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plain, _ := RelSpread(x, false); byCenter != plain {
+		t.Errorf("RelSpreadCenter = %v, RelSpread = %v", byCenter, plain)
+	}
+	byMedian, err := RelSpreadWith(x, RelSpreadMedian, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !floatEquals(byMedian, spread/median, 1e-12) {
+		t.Errorf("RelSpreadMedian = %v, want %v", byMedian, spread/median)
+	}
+	if sorted, _ := RelSpreadWith(sortedOne(x, false), RelSpreadMedian, true); sorted != byMedian {
+		t.Errorf("assumeSorted: %v, want %v", sorted, byMedian)
+	}
+
+	// A zero median is a domain error even when Center is non-zero.
+	zeroMedian := []float64{-1, 0, 0, 5, 9}
+	if _, err := RelSpreadWith(zeroMedian, RelSpreadCenter, false); err != nil {
+		t.Errorf("Center denominator: %v", err)
+	}
+	_, err = RelSpreadWith(zeroMedian, RelSpreadMedian, false)
+	assertViolation(t, err, Domain, SubjectX)
+
+	_, err = RelSpreadWith(nil, RelSpreadMedian, false)
+	assertViolation(t, err, Validity, SubjectX)
+	if _, err := RelSpreadWith(x, RelSpreadDenominator(7), false); err == nil {
+		t.Error("expected an error for an unknown denominator")
+	}
+	if RelSpreadMedian.String() != "median" || RelSpreadCenter.String() != "center" {
+		t.Error("unexpected denominator names")
+	}
+}
@@ -0,0 +1,112 @@
+package pragmastat
+
+import "testing"
+
+func TestAvgSpreadUniformWeightsMatchUnweighted(t *testing.T) {
+	rng := NewRngFromString("avg-spread-weights")
+	for _, sizes := range [][2]int{{2, 3}, {5, 5}, {7, 12}, {20, 9}} {
+		x := NewAdditive(0, 1).Samples(rng, sizes[0])
+		y := NewAdditive(3, 2).Samples(rng, sizes[1])
+		want, err := avgSpread(x, y, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sx, _ := NewSample(x)
+		sy, _ := NewSample(y)
+		got, err := AvgSpread(sx, sy)
+		if err != nil || got.Value != want {
+			t.Errorf("sizes %v: unweighted AvgSpread = %v, %v, want %v", sizes, got.Value, err, want)
+		}
+
+		wx, _ := NewWeightedSample(x, uniformWeights(len(x), 0.3), nil)
+		wy, _ := NewWeightedSample(y, uniformWeights(len(y), 0.3), nil)
+		got, err = AvgSpread(wx, wy)
+		if err != nil || !floatEquals(got.Value, want, 1e-9) {
+			t.Errorf("sizes %v: uniformly weighted AvgSpread = %v, %v, want %v", sizes, got.Value, err, want)
+		}
+	}
+}
+
+func TestAvgSpreadWeighted(t *testing.T) {
+	// Pairs of a: |0-1| weight 1, |1-3| weight 2, |0-3| weight 2. Half of the
+	// total weight 5 is first reached at difference 2, so spread(a) = 2, and
+	// WeightedSize(a) = 4² / (1+1+4) = 8/3.
+	a, err := NewWeightedSample([]float64{0, 1, 3}, []float64{1, 1, 2}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := NewSample([]float64{10, 14})
+
+	got, err := AvgSpread(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := (8.0/3*2 + 2*4) / (8.0/3 + 2)
+	if !floatEquals(got.Value, want, 1e-12) || got.Unit != NumberUnit {
+		t.Errorf("AvgSpread = %v %v, want %v", got.Value, got.Unit, want)
+	}
+
+	// A zero-weight value is ignored.
+	c, _ := NewWeightedSample([]float64{0, 1, 3, 100}, []float64{1, 1, 2, 0}, nil)
+	if got2, _ := AvgSpread(c, b); !floatEquals(got2.Value, want, 1e-12) {
+		t.Errorf("AvgSpread with zero-weight outlier = %v, want %v", got2.Value, want)
+	}
+}
+
+func TestAvgSpreadFinerUnit(t *testing.T) {
+	registry := NewUnitRegistry()
+	second, _ := NewUnit(registry, "s", "Time", "s", "Second", 1000)
+	milli, _ := NewUnit(registry, "ms", "Time", "ms", "Millisecond", 1)
+	a, _ := NewSampleWithUnit([]float64{1, 2, 4}, second)
+	b, _ := NewWeightedSample([]float64{0, 1000, 3000}, []float64{1, 1, 2}, milli)
+
+	// a in ms is {1000, 2000, 4000} with Spread 2000; b has weighted Spread 2000
+	// (as in TestAvgSpreadWeighted, scaled by 1000).
+	got, err := AvgSpread(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Unit != milli || !floatEquals(got.Value, 2000, 1e-9) {
+		t.Errorf("AvgSpread = %v %v, want 2000 ms", got.Value, got.Unit)
+	}
+}
+
+func TestAvgSpreadErrors(t *testing.T) {
+	varied, _ := NewSample([]float64{1, 2, 3})
+	constant, _ := NewSample([]float64{5, 5, 5})
+	heavy, _ := NewWeightedSample([]float64{1, 2, 3}, []float64{1, 0, 0}, nil)
+
+	_, err := AvgSpread(constant, varied)
+	assertViolation(t, err, Sparity, SubjectX)
+	_, err = AvgSpread(varied, constant)
+	assertViolation(t, err, Sparity, SubjectY)
+	_, err = AvgSpread(varied, heavy)
+	assertViolation(t, err, Sparity, SubjectY)
+
+	if _, err := AvgSpread(nil, varied); err == nil {
+		t.Error("expected an error for a nil sample")
+	}
+}
+
+func uniformWeights(n int, w float64) []float64 {
+	weights := make([]float64, n)
+	for i := range weights {
+		weights[i] = w
+	}
+	return weights
+}
+
+func BenchmarkAvgSpreadWeighted(b *testing.B) {
+	rng := NewRngFromString("avg-spread-bench")
+	const n = 3000
+	x, _ := NewWeightedSample(NewAdditive(0, 1).Samples(rng, n), NewUniform(0, 1).Samples(rng, n), nil)
+	y, _ := NewWeightedSample(NewAdditive(3, 2).Samples(rng, n), NewUniform(0, 1).Samples(rng, n), nil)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := AvgSpread(x, y); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
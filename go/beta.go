@@ -0,0 +1,166 @@
+package pragmastat
+
+import "math"
+
+// Beta represents a beta distribution on [0, 1] with shape parameters Alpha
+// and Beta. The mean is alpha/(alpha+beta).
+type Beta struct {
+	Alpha float64
+	Beta  float64
+}
+
+// NewBeta creates a new beta distribution.
+// Panics if alpha <= 0 or beta <= 0.
+func NewBeta(alpha, beta float64) *Beta {
+	b, err := TryNewBeta(alpha, beta)
+	if err != nil {
+		panic(err.Error())
+	}
+	return b
+}
+
+// TryNewBeta is NewBeta returning a *ParameterError instead of panicking.
+func TryNewBeta(alpha, beta float64) (*Beta, error) {
+	if !(alpha > 0) || math.IsInf(alpha, 1) {
+		return nil, newParameterError("Beta", "alpha", "alpha must be positive and finite")
+	}
+	if !(beta > 0) || math.IsInf(beta, 1) {
+		return nil, newParameterError("Beta", "beta", "beta must be positive and finite")
+	}
+	return &Beta{Alpha: alpha, Beta: beta}, nil
+}
+
+// Sample generates a single sample from the beta distribution.
+//
+// When both parameters are below 1 it uses Jöhnk's method: each attempt
+// draws two uniforms u, v and accepts when u^(1/alpha) + v^(1/beta) <= 1,
+// working in log space so tiny parameters do not underflow. Otherwise it
+// draws X ~ Gamma(alpha, 1) and then Y ~ Gamma(beta, 1) (see Gamma.Sample
+// for their consumption) and returns X/(X+Y).
+func (b *Beta) Sample(rng *Rng) float64 {
+	if b.Alpha < 1 && b.Beta < 1 {
+		return b.johnk(rng)
+	}
+	x := standardGamma(rng, b.Alpha)
+	y := standardGamma(rng, b.Beta)
+	return x / (x + y)
+}
+
+func (b *Beta) johnk(rng *Rng) float64 {
+	for {
+		logX := math.Log(rng.UniformFloat64()) / b.Alpha
+		logY := math.Log(rng.UniformFloat64()) / b.Beta
+		logSum := logX + math.Log1p(math.Exp(logY-logX))
+		if logX < logY {
+			logSum = logY + math.Log1p(math.Exp(logX-logY))
+		}
+		if logSum <= 0 && !math.IsInf(logSum, -1) {
+			return math.Exp(logX - logSum)
+		}
+	}
+}
+
+// Samples generates multiple samples from the beta distribution.
+func (b *Beta) Samples(rng *Rng, count int) []float64 {
+	return sampleN(b, rng, count)
+}
+
+// SamplesInto fills dst with samples from the beta distribution without allocating.
+func (b *Beta) SamplesInto(rng *Rng, dst []float64) {
+	fillSamples(b, rng, dst)
+}
+
+// DrawsPerSample returns VariableDraws: both the Gamma ratio and Jöhnk's
+// method use rejection.
+func (b *Beta) DrawsPerSample() int {
+	return VariableDraws
+}
+
+// Pdf returns the probability density at x; it is 0 outside [0, 1] and
+// +Inf at an endpoint whose parameter is below 1.
+func (b *Beta) Pdf(x float64) float64 {
+	switch {
+	case x < 0 || x > 1:
+		return 0
+	case x == 0:
+		return betaEndpointDensity(b.Alpha, b.Beta)
+	case x == 1:
+		return betaEndpointDensity(b.Beta, b.Alpha)
+	}
+	lbeta := lgamma(b.Alpha) + lgamma(b.Beta) - lgamma(b.Alpha+b.Beta)
+	return math.Exp((b.Alpha-1)*math.Log(x) + (b.Beta-1)*math.Log1p(-x) - lbeta)
+}
+
+// betaEndpointDensity is the density at the endpoint governed by near
+// (x = 0 for alpha, x = 1 for beta); far is the other parameter.
+func betaEndpointDensity(near, far float64) float64 {
+	switch {
+	case near < 1:
+		return math.Inf(1)
+	case near == 1:
+		return far // 1/B(1, far)
+	}
+	return 0
+}
+
+// Cdf returns P(X <= x), the regularized incomplete beta I_x(alpha, beta).
+func (b *Beta) Cdf(x float64) float64 {
+	return regIncBeta(b.Alpha, b.Beta, x)
+}
+
+// Quantile returns the inverse CDF by bracketed Newton iteration. Upper
+// quantiles are found as 1 - Q'(1-p) with Q' the quantile of Beta(beta,
+// alpha), so both tails keep their relative precision. Quantile(0) is 0,
+// Quantile(1) is 1, and p outside [0, 1] gives NaN.
+func (b *Beta) Quantile(p float64) float64 {
+	switch {
+	case invalidProbability(p):
+		return math.NaN()
+	case p == 0:
+		return 0
+	case p == 1:
+		return 1
+	case p > 0.5:
+		return 1 - (&Beta{Alpha: b.Beta, Beta: b.Alpha}).lowerQuantile(1-p)
+	}
+	return b.lowerQuantile(p)
+}
+
+// lowerQuantile inverts the CDF for p <= 1/2, halving from 1 to bracket the
+// root within a factor of 2 first.
+func (b *Beta) lowerQuantile(p float64) float64 {
+	lo, hi := 0.5, 1.0
+	for lo > 0 && b.Cdf(lo) > p {
+		lo, hi = lo/2, lo
+	}
+	return invertCdfNewton(b.Cdf, b.Pdf, p, lo, hi)
+}
+
+// TrueCenter returns the population Center, computed numerically from Cdf
+// and Quantile (there is no closed form).
+func (b *Beta) TrueCenter() float64 {
+	return numericTrueCenter(b)
+}
+
+// TrueSpread returns the population Spread, computed numerically from Cdf
+// and Quantile (there is no closed form).
+func (b *Beta) TrueSpread() float64 {
+	return numericTrueSpread(b)
+}
+
+// TrueMean returns the population mean alpha/(alpha+beta).
+func (b *Beta) TrueMean() float64 {
+	return b.Alpha / (b.Alpha + b.Beta)
+}
+
+// TrueVariance returns the population variance
+// alpha·beta/((alpha+beta)²·(alpha+beta+1)).
+func (b *Beta) TrueVariance() float64 {
+	s := b.Alpha + b.Beta
+	return b.Alpha * b.Beta / (s * s * (s + 1))
+}
+
+// TrueStdDev returns the population standard deviation, √TrueVariance.
+func (b *Beta) TrueStdDev() float64 {
+	return math.Sqrt(b.TrueVariance())
+}
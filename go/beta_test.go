@@ -0,0 +1,104 @@
+package pragmastat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBetaSymmetry(t *testing.T) {
+	for _, a := range []float64{0.3, 1, 4.5} {
+		b := NewBeta(a, a)
+		for _, x := range []float64{0.01, 0.2, 0.5, 0.73} {
+			if !floatEquals(b.Cdf(x), 1-b.Cdf(1-x), 1e-13) || !floatEquals(b.Pdf(x), b.Pdf(1-x), 1e-12) {
+				t.Errorf("Beta(%v, %v) is not symmetric at %v", a, a, x)
+			}
+		}
+		for _, p := range []float64{0.05, 0.3} {
+			if !floatEquals(b.Quantile(p), 1-b.Quantile(1-p), 1e-12) {
+				t.Errorf("Beta(%v, %v).Quantile(%v) = %v, 1-Quantile(1-p) = %v", a, a, p, b.Quantile(p), 1-b.Quantile(1-p))
+			}
+		}
+		if q := b.Quantile(0.5); !floatEquals(q, 0.5, 1e-12) {
+			t.Errorf("Beta(%v, %v) median = %v", a, a, q)
+		}
+	}
+}
+
+func TestBetaMean(t *testing.T) {
+	const n = 1000000
+	// (0.4, 0.6) takes Jöhnk's path, the others the Gamma ratio.
+	for _, c := range []struct{ alpha, beta float64 }{{0.4, 0.6}, {2, 3}, {0.5, 7}} {
+		x := NewBeta(c.alpha, c.beta).Samples(NewRngFromString("beta-mean"), n)
+		var sum float64
+		for _, v := range x {
+			if v < 0 || v > 1 {
+				t.Fatalf("Beta(%v, %v) sample %v outside [0, 1]", c.alpha, c.beta, v)
+			}
+			sum += v
+		}
+		s := c.alpha + c.beta
+		want := c.alpha / s
+		sd := math.Sqrt(c.alpha * c.beta / (s * s * (s + 1)))
+		if mean := sum / n; math.Abs(mean-want) > 5*sd/math.Sqrt(n) {
+			t.Errorf("Beta(%v, %v): mean = %v, want %v", c.alpha, c.beta, mean, want)
+		}
+	}
+}
+
+func TestBetaExtremeParameters(t *testing.T) {
+	// Tiny parameters push nearly all mass onto the endpoints.
+	tiny := NewBeta(1e-3, 1e-3)
+	if !math.IsInf(tiny.Pdf(0), 1) || !math.IsInf(tiny.Pdf(1), 1) {
+		t.Errorf("Beta(1e-3, 1e-3) endpoint densities = %v, %v", tiny.Pdf(0), tiny.Pdf(1))
+	}
+	// P(0.01 < X < 0.99) is about 2·alpha·ln 99 ≈ 0.5%.
+	middle := 0
+	for i, v := range tiny.Samples(NewRngFromString("beta-tiny"), 1000) {
+		if v < 0 || v > 1 || math.IsNaN(v) {
+			t.Fatalf("sample %d = %v", i, v)
+		}
+		if v > 0.01 && v < 0.99 {
+			middle++
+		}
+	}
+	if middle > 20 {
+		t.Errorf("%d of 1000 samples are away from the endpoints", middle)
+	}
+	if q := tiny.Quantile(0.25); q <= 0 || !floatEquals(tiny.Cdf(q), 0.25, 1e-12) {
+		t.Errorf("Beta(1e-3, 1e-3).Quantile(0.25) = %v with Cdf %v", q, tiny.Cdf(q))
+	}
+
+	// Huge parameters concentrate around the mean.
+	huge := NewBeta(1000, 1000)
+	for _, v := range huge.Samples(NewRngFromString("beta-huge"), 1000) {
+		if math.Abs(v-0.5) > 0.06 {
+			t.Errorf("Beta(1000, 1000) sample %v far from 1/2", v)
+		}
+	}
+
+	// Unit parameters give the uniform density, and alpha = 1 a finite
+	// density 1/B(1, beta) = beta at 0.
+	if d := NewBeta(1, 1).Pdf(0.3); !floatEquals(d, 1, 1e-14) {
+		t.Errorf("Beta(1, 1).Pdf(0.3) = %v", d)
+	}
+	if d := NewBeta(1, 3).Pdf(0); d != 3 {
+		t.Errorf("Beta(1, 3).Pdf(0) = %v, want 3", d)
+	}
+}
+
+// TestBetaDeterminism pins the first draws for a fixed seed on both
+// sampling paths; a change breaks cross-language reproducibility.
+func TestBetaDeterminism(t *testing.T) {
+	cases := map[[2]float64][]float64{
+		{0.4, 0.6}: {0.10055285916744161, 0.14665259294912641, 0.18285390434791246, 0.8224734201686413},
+		{2, 3}:     {0.43039378334175216, 0.4901832921252618, 0.6222113465058352, 0.5625465740544235},
+	}
+	for params, want := range cases {
+		got := NewBeta(params[0], params[1]).Samples(NewRngFromString("beta-vectors"), len(want))
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("Beta(%v, %v), draw %d: got %v, want %v", params[0], params[1], i, got[i], want[i])
+			}
+		}
+	}
+}
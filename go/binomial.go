@@ -0,0 +1,201 @@
+package pragmastat
+
+import "math"
+
+// Bernoulli represents a Bernoulli distribution: 1 with probability P and 0
+// otherwise. Samples are returned as float64 so it fits the Distribution
+// interface.
+type Bernoulli struct {
+	P float64
+}
+
+// NewBernoulli creates a new Bernoulli distribution.
+// Panics if p is outside [0, 1].
+func NewBernoulli(p float64) *Bernoulli {
+	b, err := TryNewBernoulli(p)
+	if err != nil {
+		panic(err.Error())
+	}
+	return b
+}
+
+// TryNewBernoulli is NewBernoulli returning a *ParameterError instead of
+// panicking.
+func TryNewBernoulli(p float64) (*Bernoulli, error) {
+	if invalidProbability(p) {
+		return nil, newParameterError("Bernoulli", "p", "p must be in [0, 1]")
+	}
+	return &Bernoulli{P: p}, nil
+}
+
+// Sample returns 1 or 0, consuming one uniform draw like Rng.Bernoulli.
+func (b *Bernoulli) Sample(rng *Rng) float64 {
+	if rng.Bernoulli(b.P) {
+		return 1
+	}
+	return 0
+}
+
+// Samples generates multiple samples from the Bernoulli distribution.
+func (b *Bernoulli) Samples(rng *Rng, count int) []float64 {
+	return sampleN(b, rng, count)
+}
+
+// SamplesInto fills dst with samples from the Bernoulli distribution without allocating.
+func (b *Bernoulli) SamplesInto(rng *Rng, dst []float64) {
+	fillSamples(b, rng, dst)
+}
+
+// DrawsPerSample returns 1.
+func (b *Bernoulli) DrawsPerSample() int {
+	return 1
+}
+
+// Pmf returns P(X = k).
+func (b *Bernoulli) Pmf(k int) float64 {
+	switch k {
+	case 0:
+		return 1 - b.P
+	case 1:
+		return b.P
+	}
+	return 0
+}
+
+// Cdf returns P(X <= x).
+func (b *Bernoulli) Cdf(x float64) float64 {
+	switch {
+	case x < 0:
+		return 0
+	case x < 1:
+		return 1 - b.P
+	}
+	return 1
+}
+
+// TrueMean returns the population mean p.
+func (b *Bernoulli) TrueMean() float64 {
+	return b.P
+}
+
+// TrueVariance returns the population variance p(1-p).
+func (b *Bernoulli) TrueVariance() float64 {
+	return b.P * (1 - b.P)
+}
+
+// TrueStdDev returns the population standard deviation, √TrueVariance.
+func (b *Bernoulli) TrueStdDev() float64 {
+	return math.Sqrt(b.TrueVariance())
+}
+
+// Binomial represents the number of successes in N independent trials with
+// success probability P. Samples are counts returned as float64.
+type Binomial struct {
+	N int
+	P float64
+}
+
+// NewBinomial creates a new binomial distribution.
+// Panics if n < 0 or p is outside [0, 1].
+func NewBinomial(n int, p float64) *Binomial {
+	b, err := TryNewBinomial(n, p)
+	if err != nil {
+		panic(err.Error())
+	}
+	return b
+}
+
+// TryNewBinomial is NewBinomial returning a *ParameterError instead of
+// panicking.
+func TryNewBinomial(n int, p float64) (*Binomial, error) {
+	if n < 0 {
+		return nil, newParameterError("Binomial", "n", "n must be non-negative")
+	}
+	if invalidProbability(p) {
+		return nil, newParameterError("Binomial", "p", "p must be in [0, 1]")
+	}
+	return &Binomial{N: n, P: p}, nil
+}
+
+// Sample draws a count with Rng.Binomial, so the sequences are identical.
+// Degenerate parameters (n = 0, p = 0 or p = 1) consume no draws. For
+// n·min(p, 1-p) < 10 the CDF is inverted with exactly one uniform; otherwise
+// BTRS rejection consumes two uniforms per attempt (about 1.2 attempts on
+// average).
+func (b *Binomial) Sample(rng *Rng) float64 {
+	return float64(rng.Binomial(b.N, b.P))
+}
+
+// Samples generates multiple samples from the binomial distribution.
+func (b *Binomial) Samples(rng *Rng, count int) []float64 {
+	return sampleN(b, rng, count)
+}
+
+// SamplesInto fills dst with samples from the binomial distribution without allocating.
+func (b *Binomial) SamplesInto(rng *Rng, dst []float64) {
+	fillSamples(b, rng, dst)
+}
+
+// DrawsPerSample returns 0 for a degenerate Binomial (N = 0 or P in {0, 1}),
+// 1 when Rng.Binomial uses inversion (N·min(P, 1-P) < 10), and
+// VariableDraws for BTRS rejection.
+func (b *Binomial) DrawsPerSample() int {
+	p := math.Min(b.P, 1-b.P)
+	switch {
+	case b.N == 0 || p == 0:
+		return 0
+	case float64(b.N)*p < 10:
+		return 1
+	}
+	return VariableDraws
+}
+
+// Pmf returns P(X = k).
+func (b *Binomial) Pmf(k int) float64 {
+	switch {
+	case k < 0 || k > b.N:
+		return 0
+	case b.P == 0:
+		return boolToFloat(k == 0)
+	case b.P == 1:
+		return boolToFloat(k == b.N)
+	}
+	n, kf := float64(b.N), float64(k)
+	logC := lgamma(n+1) - lgamma(kf+1) - lgamma(n-kf+1)
+	return math.Exp(logC + kf*math.Log(b.P) + (n-kf)*math.Log1p(-b.P))
+}
+
+// Cdf returns P(X <= x) = I_{1-p}(n-k, k+1) with k = ⌊x⌋, the regularized
+// incomplete beta form of the binomial tail.
+func (b *Binomial) Cdf(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	if x >= float64(b.N) {
+		return 1
+	}
+	k := math.Floor(x)
+	return regIncBeta(float64(b.N)-k, k+1, 1-b.P)
+}
+
+// TrueMean returns the population mean n·p.
+func (b *Binomial) TrueMean() float64 {
+	return float64(b.N) * b.P
+}
+
+// TrueVariance returns the population variance n·p(1-p).
+func (b *Binomial) TrueVariance() float64 {
+	return float64(b.N) * b.P * (1 - b.P)
+}
+
+// TrueStdDev returns the population standard deviation, √TrueVariance.
+func (b *Binomial) TrueStdDev() float64 {
+	return math.Sqrt(b.TrueVariance())
+}
+
+func boolToFloat(v bool) float64 {
+	if v {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,100 @@
+package pragmastat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBinomialPmfCdf(t *testing.T) {
+	for _, c := range []struct {
+		n int
+		p float64
+	}{{0, 0.3}, {1, 0.3}, {20, 0.3}, {50, 0.85}, {10, 0}, {10, 1}} {
+		b := NewBinomial(c.n, c.p)
+		var cumulative float64
+		for k := 0; k <= c.n; k++ {
+			pmf := b.Pmf(k)
+			if c.p > 0 && c.p < 1 {
+				if want := binomialPmf(c.n, c.p)(k); !floatEquals(pmf, want, 1e-12) {
+					t.Errorf("Binomial(%d, %v).Pmf(%d) = %v, want %v", c.n, c.p, k, pmf, want)
+				}
+			}
+			cumulative += pmf
+			if got := b.Cdf(float64(k) + 0.5); !floatEquals(got, cumulative, 1e-12) {
+				t.Errorf("Binomial(%d, %v).Cdf(%v) = %v, summed pmf %v", c.n, c.p, float64(k)+0.5, got, cumulative)
+			}
+		}
+		if b.Cdf(-0.5) != 0 || b.Pmf(-1) != 0 || b.Pmf(c.n+1) != 0 {
+			t.Errorf("Binomial(%d, %v) has mass outside 0..n", c.n, c.p)
+		}
+	}
+
+	be := NewBernoulli(0.3)
+	if be.Pmf(0) != 0.7 || be.Pmf(1) != 0.3 || be.Pmf(2) != 0 {
+		t.Errorf("Bernoulli(0.3) pmf = %v, %v, %v", be.Pmf(0), be.Pmf(1), be.Pmf(2))
+	}
+	if be.Cdf(-1) != 0 || be.Cdf(0.5) != 0.7 || be.Cdf(1) != 1 {
+		t.Errorf("Bernoulli(0.3) cdf = %v, %v, %v", be.Cdf(-1), be.Cdf(0.5), be.Cdf(1))
+	}
+}
+
+func TestBinomialFrequencies(t *testing.T) {
+	const n = 200_000
+	rng := NewRngFromString("binomial-frequencies")
+	for _, c := range []struct {
+		n int
+		p float64
+	}{{20, 0.3}, {60, 0.4}} {
+		b := NewBinomial(c.n, c.p)
+		chi2, df := chiSquareDiscrete(func() int { return int(b.Sample(rng)) }, b.Pmf, c.n, n)
+		if limit := float64(df) + 5*math.Sqrt(2*float64(df)); chi2 > limit {
+			t.Errorf("Binomial(%d, %v): chi-square = %.1f with %d df (limit %.1f)", c.n, c.p, chi2, df, limit)
+		}
+	}
+
+	var hits float64
+	be := NewBernoulli(0.3)
+	for i := 0; i < n; i++ {
+		hits += be.Sample(rng)
+	}
+	if got := hits / n; math.Abs(got-0.3) > 5*math.Sqrt(0.21/n) {
+		t.Errorf("Bernoulli(0.3) frequency = %v", got)
+	}
+}
+
+// TestBinomialDeterminism pins the draws for a fixed seed and checks that the
+// types consume the stream exactly like the Rng methods.
+func TestBinomialDeterminism(t *testing.T) {
+	want := []float64{1, 6, 7, 6, 6}
+	got := NewBinomial(20, 0.3).Samples(NewRngFromString("binomial-vectors"), len(want))
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("draw %d: got %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	typed, direct := NewRngFromString("binomial-stream"), NewRngFromString("binomial-stream")
+	b, be := NewBinomial(500, 0.2), NewBernoulli(0.6)
+	for i := 0; i < 100; i++ {
+		if b.Sample(typed) != float64(direct.Binomial(500, 0.2)) {
+			t.Fatalf("Binomial draw %d differs from Rng.Binomial", i)
+		}
+		if (be.Sample(typed) == 1) != direct.Bernoulli(0.6) {
+			t.Fatalf("Bernoulli draw %d differs from Rng.Bernoulli", i)
+		}
+	}
+}
+
+func TestBinomialParameterErrors(t *testing.T) {
+	if _, err := TryNewBinomial(-1, 0.5); err == nil {
+		t.Error("expected an error for n < 0")
+	}
+	for _, p := range []float64{-0.1, 1.5, math.NaN()} {
+		if _, err := TryNewBinomial(5, p); err == nil {
+			t.Errorf("expected an error for Binomial p = %v", p)
+		}
+		if _, err := TryNewBernoulli(p); err == nil {
+			t.Errorf("expected an error for Bernoulli p = %v", p)
+		}
+	}
+}
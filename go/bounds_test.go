@@ -0,0 +1,40 @@
+package pragmastat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBoundsHelpers(t *testing.T) {
+	inf := math.Inf(1)
+	cases := []struct {
+		name          string
+		b             Bounds
+		width, mid    float64
+		inside, outer []float64
+	}{
+		{"finite", Bounds{Lower: -1, Upper: 3}, 4, 1, []float64{-1, 0, 3}, []float64{-1.5, 3.01}},
+		{"degenerate", Bounds{Lower: 2, Upper: 2}, 0, 2, []float64{2}, []float64{math.Nextafter(2, 3), math.Nextafter(2, 1)}},
+		{"lower-infinite", Bounds{Lower: -inf, Upper: 5}, inf, -inf, []float64{-inf, -1e308, 5}, []float64{6, inf}},
+		{"everything", Bounds{Lower: -inf, Upper: inf}, inf, math.NaN(), []float64{-inf, 0, 1e308, inf}, nil},
+		{"huge", Bounds{Lower: -math.MaxFloat64, Upper: math.MaxFloat64}, inf, 0, []float64{0}, []float64{inf}},
+	}
+	for _, c := range cases {
+		if w := c.b.Width(); w != c.width {
+			t.Errorf("%s: Width = %v, want %v", c.name, w, c.width)
+		}
+		if m := c.b.Midpoint(); m != c.mid && !(math.IsNaN(m) && math.IsNaN(c.mid)) {
+			t.Errorf("%s: Midpoint = %v, want %v", c.name, m, c.mid)
+		}
+		for _, v := range c.inside {
+			if !c.b.Contains(v) {
+				t.Errorf("%s: expected %v to be contained", c.name, v)
+			}
+		}
+		for _, v := range append(c.outer, math.NaN()) {
+			if c.b.Contains(v) {
+				t.Errorf("%s: expected %v not to be contained", c.name, v)
+			}
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package pragmastat
+
+import "context"
+
+// CancelledError is returned by the context-aware (…Ctx) functions when the
+// context is cancelled or its deadline passes before the computation finishes.
+// It wraps ctx.Err(), so errors.Is(err, context.Canceled) and
+// errors.Is(err, context.DeadlineExceeded) work as expected.
+type CancelledError struct {
+	Err error
+}
+
+func (e *CancelledError) Error() string {
+	return "computation cancelled: " + e.Err.Error()
+}
+
+// Unwrap returns the underlying context error.
+func (e *CancelledError) Unwrap() error {
+	return e.Err
+}
+
+// checkContext returns a *CancelledError if ctx is done. The iterative
+// algorithms call it at loop boundaries, so cancellation latency is bounded
+// by one iteration (an O(n) pass); the initial sort is not interruptible.
+func checkContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return &CancelledError{Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package pragmastat
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func assertCancelled(t *testing.T, err error, target error) {
+	t.Helper()
+	var ce *CancelledError
+	if !errors.As(err, &ce) {
+		t.Fatalf("expected *CancelledError, got %T: %v", err, err)
+	}
+	if !errors.Is(err, target) {
+		t.Errorf("expected error wrapping %v, got %v", target, err)
+	}
+}
+
+func TestCtxPreCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	x := uniformVec(NewRngFromSeed(invarianceSeed), 100)
+	y := uniformVec(NewRngFromSeed(invarianceSeed+1), 100)
+
+	_, err := CenterCtx(ctx, x, false)
+	assertCancelled(t, err, context.Canceled)
+
+	_, err = ShiftBoundsCtx(ctx, x, y, 0.05, false)
+	assertCancelled(t, err, context.Canceled)
+
+	// Assumption violations are still reported before cancellation.
+	_, err = CenterCtx(ctx, nil, false)
+	assertViolation(t, err, Validity, SubjectX)
+}
+
+func TestCtxBackgroundMatchesPlain(t *testing.T) {
+	x := uniformVec(NewRngFromSeed(invarianceSeed), 50)
+	y := uniformVec(NewRngFromSeed(invarianceSeed+1), 40)
+
+	c1, err1 := Center(x, false)
+	c2, err2 := CenterCtx(context.Background(), x, false)
+	if err1 != nil || err2 != nil || c1 != c2 {
+		t.Errorf("Center = %v (%v), CenterCtx = %v (%v)", c1, err1, c2, err2)
+	}
+	b1, err1 := ShiftBounds(x, y, 0.05, false)
+	b2, err2 := ShiftBoundsCtx(context.Background(), x, y, 0.05, false)
+	if err1 != nil || err2 != nil || b1 != b2 {
+		t.Errorf("ShiftBounds = %v (%v), ShiftBoundsCtx = %v (%v)", b1, err1, b2, err2)
+	}
+}
+
+func TestCtxDeadlineLatency(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping huge-input cancellation test in short mode")
+	}
+	const n = 4_000_000
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = float64(i)
+	}
+	y := x[:n/2]
+
+	const timeout = 20 * time.Millisecond
+	const maxLatency = 2 * time.Second
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	start := time.Now()
+	_, err := CenterCtx(ctx, x, true)
+	if elapsed := time.Since(start); elapsed > maxLatency {
+		t.Errorf("CenterCtx returned after %v, want < %v", elapsed, maxLatency)
+	}
+	assertCancelled(t, err, context.DeadlineExceeded)
+
+	ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	start = time.Now()
+	_, err = ShiftBoundsCtx(ctx, x, y, 0.05, true)
+	if elapsed := time.Since(start); elapsed > maxLatency {
+		t.Errorf("ShiftBoundsCtx returned after %v, want < %v", elapsed, maxLatency)
+	}
+	assertCancelled(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,103 @@
+package pragmastat
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// categoricalTolerance is how far the probabilities passed to Categorical
+// may sum from 1 without WithRenormalize.
+const categoricalTolerance = 1e-9
+
+// CategoricalOption configures Categorical and CategoricalN.
+type CategoricalOption func(*categoricalConfig)
+
+type categoricalConfig struct {
+	renormalize bool
+}
+
+// WithRenormalize accepts any non-negative vector with a positive sum and
+// divides it by its sum, instead of requiring the values to sum to 1.
+func WithRenormalize() CategoricalOption {
+	return func(c *categoricalConfig) { c.renormalize = true }
+}
+
+// Categorical draws one category index with P(i) = probs[i], scanning the
+// cumulative sums linearly, which is cheapest for a one-shot draw. It
+// consumes one UniformFloat64. For many draws from the same vector use
+// CategoricalN or an AliasTable.
+//
+// Returns an error if probs is empty, contains a negative or non-finite
+// value, sums to zero, or (without WithRenormalize) does not sum to 1
+// within 1e-9.
+func Categorical(rng *Rng, probs []float64, opts ...CategoricalOption) (int, error) {
+	cum, err := categoricalCumulative(probs, opts)
+	if err != nil {
+		return 0, err
+	}
+	return categoricalScan(rng, cum), nil
+}
+
+// categoricalScan draws one index from validated running sums by a linear
+// scan, consuming one UniformFloat64.
+func categoricalScan(rng *Rng, cum []float64) int {
+	u := rng.UniformFloat64() * cum[len(cum)-1]
+	for i, c := range cum {
+		if u < c {
+			return i
+		}
+	}
+	panic("unreachable: u is below the total")
+}
+
+// CategoricalN draws n category indices as Categorical would, binary
+// searching the cumulative sums (O(log k) per draw). It consumes random
+// numbers exactly like n calls to Categorical, so the results are equal for
+// the same seed. Returns an error for n < 0 or invalid probs.
+func CategoricalN(rng *Rng, probs []float64, n int, opts ...CategoricalOption) ([]int, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("n must be non-negative, got %d", n)
+	}
+	cum, err := categoricalCumulative(probs, opts)
+	if err != nil {
+		return nil, err
+	}
+	total := cum[len(cum)-1]
+	result := make([]int, n)
+	for i := range result {
+		u := rng.UniformFloat64() * total
+		result[i] = sort.Search(len(cum), func(j int) bool { return cum[j] > u })
+	}
+	return result, nil
+}
+
+// categoricalCumulative validates probs and returns its running sums. The
+// draw scales u by the last sum rather than dividing, so renormalizing costs
+// nothing and rounding can never push u past the final category.
+func categoricalCumulative(probs []float64, opts []CategoricalOption) ([]float64, error) {
+	var cfg categoricalConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if len(probs) == 0 {
+		return nil, errors.New("probs cannot be empty")
+	}
+	cum := make([]float64, len(probs))
+	total := 0.0
+	for i, p := range probs {
+		if math.IsNaN(p) || math.IsInf(p, 0) || p < 0 {
+			return nil, fmt.Errorf("probs[%d] must be finite and non-negative, got %v", i, p)
+		}
+		total += p
+		cum[i] = total
+	}
+	if total <= 0 || math.IsInf(total, 0) {
+		return nil, errors.New("probs must have a positive, finite sum")
+	}
+	if !cfg.renormalize && math.Abs(total-1) > categoricalTolerance {
+		return nil, fmt.Errorf("probs must sum to 1, got %v (use WithRenormalize to rescale)", total)
+	}
+	return cum, nil
+}
@@ -0,0 +1,91 @@
+package pragmastat
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestCategoricalFrequencies(t *testing.T) {
+	const n = 1_000_000
+	probs := []float64{0.5, 0.2, 0, 0.25, 0.05}
+	rng := NewRngFromSeed(1729)
+	counts := make([]int, len(probs))
+	draws, err := CategoricalN(rng, probs, n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range draws {
+		counts[d]++
+	}
+	for i, p := range probs {
+		freq := float64(counts[i]) / n
+		if tol := 5 * math.Sqrt(p*(1-p)/n); math.Abs(freq-p) > tol {
+			t.Errorf("category %d: frequency %v, want %v ± %v", i, freq, p, tol)
+		}
+	}
+	if counts[2] != 0 {
+		t.Errorf("zero-probability category drawn %d times", counts[2])
+	}
+}
+
+func TestCategoricalMatchesCategoricalN(t *testing.T) {
+	probs := []float64{0.1, 0.3, 0.6}
+	single := NewRngFromString("categorical")
+	want := make([]int, 1000)
+	for i := range want {
+		v, err := Categorical(single, probs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want[i] = v
+	}
+	got, _ := CategoricalN(NewRngFromString("categorical"), probs, len(want))
+	if !reflect.DeepEqual(got, want) {
+		t.Error("CategoricalN differs from repeated Categorical calls")
+	}
+	again, _ := CategoricalN(NewRngFromString("categorical"), probs, len(want))
+	if !reflect.DeepEqual(got, again) {
+		t.Error("CategoricalN is not deterministic")
+	}
+}
+
+func TestCategoricalRenormalize(t *testing.T) {
+	weights := []float64{2, 6, 12}
+	if _, err := Categorical(NewRngFromSeed(1), weights); err == nil {
+		t.Fatal("expected an error for probabilities that do not sum to 1")
+	}
+	got, err := CategoricalN(NewRngFromSeed(1), weights, 100, WithRenormalize())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := CategoricalN(NewRngFromSeed(1), []float64{0.1, 0.3, 0.6}, 100)
+	if !reflect.DeepEqual(got, want) {
+		t.Error("renormalized weights draw differently from the normalized vector")
+	}
+}
+
+func TestCategoricalErrors(t *testing.T) {
+	rng := NewRngFromSeed(1)
+	for name, probs := range map[string][]float64{
+		"empty":    {},
+		"all-zero": {0, 0, 0},
+		"negative": {0.5, -0.1, 0.6},
+		"NaN":      {0.5, math.NaN()},
+		"Inf":      {math.Inf(1), 0},
+	} {
+		if _, err := Categorical(rng, probs, WithRenormalize()); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+		if _, err := CategoricalN(rng, probs, 3, WithRenormalize()); err == nil {
+			t.Errorf("%s: expected an error from CategoricalN", name)
+		}
+	}
+	if _, err := CategoricalN(rng, []float64{1}, -1); err == nil {
+		t.Error("expected an error for negative n")
+	}
+	// Rounding within the tolerance is accepted.
+	if _, err := Categorical(rng, []float64{0.1, 0.2, 0.7000000001}); err != nil {
+		t.Errorf("sum within tolerance: %v", err)
+	}
+}
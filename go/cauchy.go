@@ -0,0 +1,115 @@
+package pragmastat
+
+import "math"
+
+// Cauchy represents a Cauchy (Lorentz) distribution with given location and
+// scale. Its mean and variance are undefined, which makes it a stress test
+// for estimators: the sample mean never settles, while Center converges to
+// the location and Spread to 2·scale (the interquartile range is also
+// 2·scale).
+type Cauchy struct {
+	Location float64
+	Scale    float64
+}
+
+// NewCauchy creates a new Cauchy distribution.
+// Panics if scale <= 0.
+func NewCauchy(location, scale float64) *Cauchy {
+	c, err := TryNewCauchy(location, scale)
+	if err != nil {
+		panic(err.Error())
+	}
+	return c
+}
+
+// TryNewCauchy is NewCauchy returning a *ParameterError instead of panicking.
+func TryNewCauchy(location, scale float64) (*Cauchy, error) {
+	if !(scale > 0) {
+		return nil, newParameterError("Cauchy", "scale", "scale must be positive")
+	}
+	return &Cauchy{Location: location, Scale: scale}, nil
+}
+
+// Sample generates a single sample from the Cauchy distribution.
+func (c *Cauchy) Sample(rng *Rng) float64 {
+	return c.quantile(rng.UniformFloat64())
+}
+
+// Samples generates multiple samples from the Cauchy distribution.
+func (c *Cauchy) Samples(rng *Rng, count int) []float64 {
+	return sampleN(c, rng, count)
+}
+
+// SamplesInto fills dst with samples from the Cauchy distribution without allocating.
+func (c *Cauchy) SamplesInto(rng *Rng, dst []float64) {
+	fillSamples(c, rng, dst)
+}
+
+// DrawsPerSample returns 1.
+func (c *Cauchy) DrawsPerSample() int {
+	return 1
+}
+
+func (c *Cauchy) antitheticPair(rng *Rng) (float64, float64) {
+	u, v := rng.UniformAntithetic()
+	return c.quantile(u), c.quantile(v)
+}
+
+// Pdf returns the probability density at x.
+func (c *Cauchy) Pdf(x float64) float64 {
+	z := (x - c.Location) / c.Scale
+	return 1 / (math.Pi * c.Scale * (1 + z*z))
+}
+
+// Cdf returns P(X <= x).
+func (c *Cauchy) Cdf(x float64) float64 {
+	return 0.5 + math.Atan((x-c.Location)/c.Scale)/math.Pi
+}
+
+// Quantile returns the inverse CDF: location + scale·tan(π(p - 0.5)).
+// Quantile(0) is -Inf, Quantile(1) is +Inf, and p outside [0, 1] gives NaN.
+func (c *Cauchy) Quantile(p float64) float64 {
+	switch {
+	case invalidProbability(p):
+		return math.NaN()
+	case p == 0:
+		return math.Inf(-1)
+	case p == 1:
+		return math.Inf(1)
+	}
+	return c.quantile(p)
+}
+
+// quantile applies the inverse-CDF formula without endpoint handling. Since
+// π/2 is not exactly representable, tan stays finite at p = 0 and p = 1
+// (about ∓1.6e16·scale), so samples are always finite.
+func (c *Cauchy) quantile(p float64) float64 {
+	return c.Location + c.Scale*math.Tan(math.Pi*(p-0.5))
+}
+
+// TrueCenter returns the population Center, which equals the location by
+// symmetry.
+func (c *Cauchy) TrueCenter() float64 {
+	return c.Location
+}
+
+// TrueSpread returns the population Spread. X1-X2 is Cauchy with scale
+// 2·scale, so the median of |X1-X2| is 2·scale.
+func (c *Cauchy) TrueSpread() float64 {
+	return 2 * c.Scale
+}
+
+// TrueMean returns NaN: the Cauchy distribution has no mean.
+func (c *Cauchy) TrueMean() float64 {
+	return math.NaN()
+}
+
+// TrueVariance returns NaN: the Cauchy distribution has no variance.
+func (c *Cauchy) TrueVariance() float64 {
+	return math.NaN()
+}
+
+// TrueStdDev returns the population standard deviation, √TrueVariance.
+func (c *Cauchy) TrueStdDev() float64 {
+	return math.Sqrt(c.TrueVariance())
+}
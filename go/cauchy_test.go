@@ -0,0 +1,80 @@
+package pragmastat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCauchyFunctions(t *testing.T) {
+	c := NewCauchy(3, 2)
+	for _, p := range []float64{1e-9, 0.01, 0.25, 0.5, 0.75, 0.99} {
+		if got := c.Cdf(c.Quantile(p)); !floatEquals(got, p, 1e-12) {
+			t.Errorf("Cdf(Quantile(%v)) = %v", p, got)
+		}
+	}
+	if c.Quantile(0.5) != 3 || c.Quantile(0.75) != 5 || !floatEquals(c.Quantile(0.25), 1, 1e-12) {
+		t.Errorf("quartiles = %v, %v, %v; want 1, 3, 5", c.Quantile(0.25), c.Quantile(0.5), c.Quantile(0.75))
+	}
+	if !math.IsInf(c.Quantile(0), -1) || !math.IsInf(c.Quantile(1), 1) || !math.IsNaN(c.Quantile(1.5)) {
+		t.Error("Quantile endpoints: want -Inf, +Inf, and NaN outside [0, 1]")
+	}
+	if want := 1 / (2 * math.Pi); !floatEquals(c.Pdf(3), want, 1e-15) {
+		t.Errorf("Pdf(location) = %v, want %v", c.Pdf(3), want)
+	}
+	// Pdf is the derivative of Cdf.
+	const h = 1e-6
+	for _, x := range []float64{-10, 0, 3, 4.5, 50} {
+		numeric := (c.Cdf(x+h) - c.Cdf(x-h)) / (2 * h)
+		if !floatEquals(c.Pdf(x), numeric, 1e-8) {
+			t.Errorf("Pdf(%v) = %v, numeric derivative %v", x, c.Pdf(x), numeric)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("NewCauchy(0, 0): expected panic")
+		}
+	}()
+	NewCauchy(0, 0)
+}
+
+// TestCauchyRobustness draws large Cauchy samples under several seeds: Center
+// stays near the location and Spread near 2·scale, while the sample mean,
+// which does not converge for the Cauchy distribution, wanders far away.
+func TestCauchyRobustness(t *testing.T) {
+	const (
+		n        = 10_000
+		location = 5.0
+		scale    = 1.0
+	)
+	c := NewCauchy(location, scale)
+	worstCenter, worstMean := 0.0, 0.0
+	for seed := int64(0); seed < 20; seed++ {
+		x := c.Samples(NewRngFromSeed(seed), n)
+		center, err := Center(x, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		spread, err := Spread(x, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(spread-2*scale) > 0.1 {
+			t.Errorf("seed %d: Spread = %v, want about %v", seed, spread, 2*scale)
+		}
+		sum := 0.0
+		for _, v := range x {
+			sum += v
+		}
+		worstCenter = math.Max(worstCenter, math.Abs(center-location))
+		worstMean = math.Max(worstMean, math.Abs(sum/n-location))
+	}
+	// The standard error of Center is about 1.8·scale/√n ≈ 0.018.
+	if worstCenter > 0.1 {
+		t.Errorf("Center strays %v from the location", worstCenter)
+	}
+	if worstMean < 10*worstCenter {
+		t.Errorf("sample mean strays only %v (Center %v); expected it to wander", worstMean, worstCenter)
+	}
+	t.Logf("max |Center - location| = %.4f, max |mean - location| = %.4f", worstCenter, worstMean)
+}
@@ -1,6 +1,7 @@
 package pragmastat
 
 import (
+	"context"
 	"errors"
 	"math"
 	"sort"
@@ -25,7 +26,7 @@ func deriveSeed[T Number](values []T) int64 {
 // centerImpl computes the median of all pairwise averages efficiently.
 // Time complexity: O(n log n) expected
 // Space complexity: O(n)
-func centerImpl[T Number](values []T, assumeSorted bool) (float64, error) {
+func centerImpl[T Number](ctx context.Context, values []T, assumeSorted bool) (float64, error) {
 	n := len(values)
 	if n == 0 {
 		return 0, errEmptyInput
@@ -89,6 +90,9 @@ func centerImpl[T Number](values []T, assumeSorted bool) (float64, error) {
 		if iter >= maxIterations {
 			return 0, errors.New("convergence failure (pathological input)")
 		}
+		if err := checkContext(ctx); err != nil {
+			return 0, err
+		}
 
 		// === PARTITION STEP ===
 		countBelowPivot := int64(0)
@@ -10,7 +10,7 @@ const relativeEpsilon = 1e-14
 
 // centerQuantileBoundsImpl computes both lower and upper bounds from pairwise averages.
 // Uses binary search with counting function to avoid materializing all N(N+1)/2 pairs.
-func centerQuantileBoundsImpl(sorted []float64, marginLo, marginHi int64) (lo, hi float64) {
+func centerQuantileBoundsImpl[T Number](sorted []T, marginLo, marginHi int64) (lo, hi float64) {
 	n := len(sorted)
 	totalPairs := int64(n) * int64(n+1) / 2
 
@@ -38,16 +38,16 @@ func centerQuantileBoundsImpl(sorted []float64, marginLo, marginHi int64) (lo, h
 
 // centerCountPairsLessOrEqualImpl counts pairwise averages <= target value.
 // Uses O(n) two-pointer algorithm.
-func centerCountPairsLessOrEqualImpl(sorted []float64, target float64) int64 {
+func centerCountPairsLessOrEqualImpl[T Number](sorted []T, target float64) int64 {
 	n := len(sorted)
 	var count int64
 	// j is not reset: as i increases, threshold decreases monotonically
 	j := n - 1
 
 	for i := 0; i < n; i++ {
-		threshold := 2*target - sorted[i]
+		threshold := 2*target - float64(sorted[i])
 
-		for j >= 0 && sorted[j] > threshold {
+		for j >= 0 && float64(sorted[j]) > threshold {
 			j--
 		}
 
@@ -60,24 +60,24 @@ func centerCountPairsLessOrEqualImpl(sorted []float64, target float64) int64 {
 }
 
 // centerFindExactQuantileImpl finds the exact k-th pairwise average using selection algorithm.
-func centerFindExactQuantileImpl(sorted []float64, k int64) float64 {
+func centerFindExactQuantileImpl[T Number](sorted []T, k int64) float64 {
 	n := len(sorted)
 	totalPairs := int64(n) * int64(n+1) / 2
 
 	if n == 1 {
-		return sorted[0]
+		return float64(sorted[0])
 	}
 
 	if k == 1 {
-		return sorted[0]
+		return float64(sorted[0])
 	}
 
 	if k == totalPairs {
-		return sorted[n-1]
+		return float64(sorted[n-1])
 	}
 
-	lo := sorted[0]
-	hi := sorted[n-1]
+	lo := float64(sorted[0])
+	hi := float64(sorted[n-1])
 	const eps = relativeEpsilon
 
 	for hi-lo > eps*math.Max(1.0, math.Max(math.Abs(lo), math.Abs(hi))) {
@@ -99,26 +99,26 @@ func centerFindExactQuantileImpl(sorted []float64, k int64) float64 {
 	var candidates []float64
 
 	for i := 0; i < n; i++ {
-		threshold := 2*target - sorted[i]
+		threshold := 2*target - float64(sorted[i])
 
 		left := i
 		right := n
 
 		for left < right {
 			m := (left + right) / 2
-			if sorted[m] < threshold-eps {
+			if float64(sorted[m]) < threshold-eps {
 				left = m + 1
 			} else {
 				right = m
 			}
 		}
 
-		if left < n && left >= i && math.Abs(sorted[left]-threshold) < eps*math.Max(1.0, math.Abs(threshold)) {
-			candidates = append(candidates, 0.5*sorted[i]+0.5*sorted[left])
+		if left < n && left >= i && math.Abs(float64(sorted[left])-threshold) < eps*math.Max(1.0, math.Abs(threshold)) {
+			candidates = append(candidates, 0.5*float64(sorted[i])+0.5*float64(sorted[left]))
 		}
 
 		if left > i {
-			avgBefore := 0.5*sorted[i] + 0.5*sorted[left-1]
+			avgBefore := 0.5*float64(sorted[i]) + 0.5*float64(sorted[left-1])
 			if avgBefore <= target+eps {
 				candidates = append(candidates, avgBefore)
 			}
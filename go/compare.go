@@ -105,7 +105,7 @@ var compare1Specs = []metricSpec{
 	{
 		metric: MetricCenter,
 		validateAndNormalize: func(threshold *Threshold, x, y *Sample) (Measurement, error) {
-			return validateCenterOrSpread(threshold, x)
+			return validateCenter(threshold, x)
 		},
 		estimate: func(x, y *Sample) (Measurement, error) {
 			return x.Center()
@@ -117,7 +117,7 @@ var compare1Specs = []metricSpec{
 	{
 		metric: MetricSpread,
 		validateAndNormalize: func(threshold *Threshold, x, y *Sample) (Measurement, error) {
-			return validateCenterOrSpread(threshold, x)
+			return validateSpread(threshold, x)
 		},
 		estimate: func(x, y *Sample) (Measurement, error) {
 			return x.Spread()
@@ -172,8 +172,20 @@ var compare2Specs = []metricSpec{
 	},
 }
 
-// validateCenterOrSpread validates and normalizes a Center or Spread threshold.
-func validateCenterOrSpread(threshold *Threshold, x *Sample) (Measurement, error) {
+// validateCenter validates and normalizes a Center threshold. Center is a
+// location, so affine units convert with their offset as well as the factor.
+func validateCenter(threshold *Threshold, x *Sample) (Measurement, error) {
+	if !threshold.Value.Unit.IsCompatible(x.unit) {
+		return Measurement{}, &UnitMismatchError{Unit1: threshold.Value.Unit, Unit2: x.unit}
+	}
+	factor := ConversionFactor(threshold.Value.Unit, x.unit)
+	offset := ConversionOffset(threshold.Value.Unit, x.unit)
+	return NewMeasurement(threshold.Value.Value*factor+offset, x.unit), nil
+}
+
+// validateSpread validates and normalizes a Spread threshold. Spread is a
+// difference of values, so it converts with the factor alone.
+func validateSpread(threshold *Threshold, x *Sample) (Measurement, error) {
 	if !threshold.Value.Unit.IsCompatible(x.unit) {
 		return Measurement{}, &UnitMismatchError{Unit1: threshold.Value.Unit, Unit2: x.unit}
 	}
@@ -181,7 +193,8 @@ func validateCenterOrSpread(threshold *Threshold, x *Sample) (Measurement, error
 	return NewMeasurement(threshold.Value.Value*factor, x.unit), nil
 }
 
-// validateShift validates and normalizes a Shift threshold.
+// validateShift validates and normalizes a Shift threshold. Like Spread, it
+// converts with the factor alone.
 func validateShift(threshold *Threshold, x, y *Sample) (Measurement, error) {
 	if !threshold.Value.Unit.IsCompatible(x.unit) {
 		return Measurement{}, &UnitMismatchError{Unit1: threshold.Value.Unit, Unit2: x.unit}
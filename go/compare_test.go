@@ -38,3 +38,46 @@ func TestCompare2RejectsNilThreshold(t *testing.T) {
 		t.Fatalf("unexpected error message: %v", err)
 	}
 }
+
+func TestCompare1ConvertsAffineCenterThreshold(t *testing.T) {
+	celsius, err := NewAffineUnit(nil, "celsius", "Temperature", "°C", "Celsius", 9, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fahrenheit, err := NewAffineUnit(nil, "fahrenheit", "Temperature", "°F", "Fahrenheit", 5, -160)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x, err := NewSampleWithUnit([]float64{97, 98, 99, 99.5, 100, 100, 100.5, 101, 102, 103}, celsius)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 212 °F is 100 °C as a location but 117.78 °C-degrees as a difference.
+	center, err := validateCenter(&Threshold{Metric: MetricCenter, Value: NewMeasurement(212, fahrenheit)}, x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !floatEquals(center.Value, 100, 1e-12) || center.Unit != celsius {
+		t.Errorf("Center threshold 212 °F = %v %v, want 100 °C", center.Value, center.Unit)
+	}
+	spread, err := validateSpread(&Threshold{Metric: MetricSpread, Value: NewMeasurement(9, fahrenheit)}, x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !floatEquals(spread.Value, 5, 1e-12) {
+		t.Errorf("Spread threshold 9 °F = %v °C, want 5", spread.Value)
+	}
+
+	threshold, err := NewThreshold(MetricCenter, NewMeasurement(212, fahrenheit), 0.05)
+	if err != nil {
+		t.Fatal(err)
+	}
+	projections, err := Compare1(x, []*Threshold{threshold})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := projections[0].Verdict; v != VerdictInconclusive {
+		t.Errorf("Center ≈ 100 °C vs 212 °F gave %v, want inconclusive", v)
+	}
+}
@@ -0,0 +1,334 @@
+package pragmastat
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// =============================================================================
+// Tie-compressed Center/Spread
+//
+// Heavily tied data (integer-valued or quantized measurements) is run-length
+// encoded into sorted distinct values with multiplicities, and the pairwise
+// order statistic is selected over (value, multiplicity) pairs. Every counting
+// pass is O(d) for d distinct values, so the cost after encoding no longer
+// depends on n. Pairwise sums and differences are formed with exactly the same
+// float64 operations as centerImpl/spreadImpl, so the selected order statistics
+// and therefore the results are identical to the uncompressed path.
+// =============================================================================
+
+// runLengths holds the sorted distinct values of a sample with their
+// multiplicities and the prefix sums of the multiplicities.
+type runLengths struct {
+	values []float64
+	counts []int64
+	prefix []int64 // prefix[i] = counts[0] + ... + counts[i-1]
+}
+
+// newRunLengths encodes x. Sorted input is encoded in a single pass; unsorted
+// input is tallied in a hash table and only the distinct values are sorted.
+func newRunLengths(x []float64, assumeSorted bool) *runLengths {
+	var values []float64
+	var counts []int64
+	if assumeSorted {
+		for i, v := range x {
+			if i > 0 && v == values[len(values)-1] {
+				counts[len(counts)-1]++
+				continue
+			}
+			values = append(values, v)
+			counts = append(counts, 1)
+		}
+	} else {
+		tally := newFloatTally()
+		for _, v := range x {
+			tally.add(v)
+		}
+		values, counts = tally.sorted()
+	}
+	prefix := make([]int64, len(counts)+1)
+	for i, c := range counts {
+		prefix[i+1] = prefix[i] + c
+	}
+	return &runLengths{values: values, counts: counts, prefix: prefix}
+}
+
+// floatTally counts occurrences of float64 values in an open-addressing hash
+// table keyed by bit pattern; it is several times faster than a Go map on
+// the hot tallying loop. Negative zero is folded into positive zero so that
+// equal values share one slot.
+type floatTally struct {
+	keys   []uint64
+	counts []int64 // 0 marks an empty slot
+	size   int
+}
+
+func newFloatTally() *floatTally {
+	const initialSlots = 1024
+	return &floatTally{keys: make([]uint64, initialSlots), counts: make([]int64, initialSlots)}
+}
+
+func (t *floatTally) add(v float64) {
+	if v == 0 {
+		v = 0
+	}
+	key := math.Float64bits(v)
+	mask := uint64(len(t.keys) - 1)
+	for i := tallyHash(key) & mask; ; i = (i + 1) & mask {
+		if t.counts[i] == 0 {
+			t.keys[i] = key
+			t.counts[i] = 1
+			t.size++
+			if 2*t.size > len(t.keys) {
+				t.grow()
+			}
+			return
+		}
+		if t.keys[i] == key {
+			t.counts[i]++
+			return
+		}
+	}
+}
+
+func (t *floatTally) grow() {
+	keys, counts := t.keys, t.counts
+	t.keys = make([]uint64, 2*len(keys))
+	t.counts = make([]int64, 2*len(counts))
+	mask := uint64(len(t.keys) - 1)
+	for j, c := range counts {
+		if c == 0 {
+			continue
+		}
+		i := tallyHash(keys[j]) & mask
+		for t.counts[i] != 0 {
+			i = (i + 1) & mask
+		}
+		t.keys[i] = keys[j]
+		t.counts[i] = c
+	}
+}
+
+// sorted returns the distinct values in ascending order with their counts.
+func (t *floatTally) sorted() ([]float64, []int64) {
+	idx := make([]int, 0, t.size)
+	for i, c := range t.counts {
+		if c != 0 {
+			idx = append(idx, i)
+		}
+	}
+	sort.Slice(idx, func(a, b int) bool {
+		return math.Float64frombits(t.keys[idx[a]]) < math.Float64frombits(t.keys[idx[b]])
+	})
+	values := make([]float64, len(idx))
+	counts := make([]int64, len(idx))
+	for i, j := range idx {
+		values[i] = math.Float64frombits(t.keys[j])
+		counts[i] = t.counts[j]
+	}
+	return values, counts
+}
+
+// tallyHash is the SplitMix64 finalizer, which spreads the low-entropy bit
+// patterns of quantized values across the table.
+func tallyHash(key uint64) uint64 {
+	key = (key ^ (key >> 30)) * 0xbf58476d1ce4e5b9
+	key = (key ^ (key >> 27)) * 0x94d049bb133111eb
+	return key ^ (key >> 31)
+}
+
+// total returns the number of encoded observations.
+func (r *runLengths) total() int64 {
+	return r.prefix[len(r.counts)]
+}
+
+// centerCompressedImpl computes Center over run-length encoded data.
+func centerCompressedImpl(r *runLengths) (float64, error) {
+	n := r.total()
+	v := r.values
+	if n == 1 {
+		return v[0], nil
+	}
+	if n == 2 {
+		return 0.5*v[0] + 0.5*v[len(v)-1], nil
+	}
+
+	totalPairs := n * (n + 1) / 2
+	medianRankLow := (totalPairs + 1) / 2
+	medianRankHigh := (totalPairs + 2) / 2
+
+	low, err := selectKthOrdered(v[0]+v[0], v[len(v)-1]+v[len(v)-1], medianRankLow, r.countSums)
+	if err != nil {
+		return 0, err
+	}
+	if medianRankLow == medianRankHigh {
+		return low / 2, nil
+	}
+	high, err := selectKthOrdered(v[0]+v[0], v[len(v)-1]+v[len(v)-1], medianRankHigh, r.countSums)
+	if err != nil {
+		return 0, err
+	}
+	return 0.25*high + 0.25*low, nil
+}
+
+// spreadCompressedImpl computes Spread over run-length encoded data.
+func spreadCompressedImpl(r *runLengths) (float64, error) {
+	n := r.total()
+	v := r.values
+	if len(v) == 1 {
+		return 0.0, nil
+	}
+	if n == 2 {
+		return math.Abs(v[1] - v[0]), nil
+	}
+
+	total := n * (n - 1) / 2
+	kLow := (total + 1) / 2
+	kHigh := (total + 2) / 2
+
+	maxDiff := v[len(v)-1] - v[0]
+	low, err := selectKthOrdered(0, maxDiff, kLow, r.countDiffs)
+	if err != nil {
+		return 0, err
+	}
+	if kLow == kHigh {
+		return low, nil
+	}
+	high, err := selectKthOrdered(0, maxDiff, kHigh, r.countDiffs)
+	if err != nil {
+		return 0, err
+	}
+	return 0.5*low + 0.5*high, nil
+}
+
+// countSums counts pairwise sums v[a]+v[b] (a <= b, weighted by multiplicity,
+// self-pairs included as in centerImpl) that are <= threshold. It also returns
+// the largest sum <= threshold and the smallest sum > threshold (±Inf if none).
+func (r *runLengths) countSums(threshold float64) (int64, float64, float64) {
+	v, c, p := r.values, r.counts, r.prefix
+	d := len(v)
+	var count int64
+	maxBelow := math.Inf(-1)
+	minAbove := math.Inf(1)
+
+	// b is the largest column with v[a]+v[b] <= threshold; it only moves left
+	// as a increases.
+	b := d - 1
+	for a := 0; a < d; a++ {
+		for b >= a && v[a]+v[b] > threshold {
+			b--
+		}
+		if b >= a {
+			count += c[a]*(c[a]+1)/2 + c[a]*(p[b+1]-p[a+1])
+			if s := v[a] + v[b]; s > maxBelow {
+				maxBelow = s
+			}
+		}
+		next := b + 1
+		if next < a {
+			next = a
+		}
+		if next < d {
+			if s := v[a] + v[next]; s < minAbove {
+				minAbove = s
+			}
+		}
+	}
+	return count, maxBelow, minAbove
+}
+
+// countDiffs counts pairwise differences |x[i]-x[j]| (i < j, weighted by
+// multiplicity) that are <= threshold, plus the largest difference <= threshold
+// and the smallest difference > threshold (±Inf if none).
+func (r *runLengths) countDiffs(threshold float64) (int64, float64, float64) {
+	v, c, p := r.values, r.counts, r.prefix
+	d := len(v)
+	var count int64
+	maxBelow := math.Inf(-1)
+	minAbove := math.Inf(1)
+
+	// Ties contribute zero differences.
+	var zeros int64
+	for _, ca := range c {
+		zeros += ca * (ca - 1) / 2
+	}
+	if zeros > 0 {
+		if threshold >= 0 {
+			count += zeros
+			maxBelow = 0
+		} else {
+			minAbove = 0
+		}
+	}
+
+	// b is the largest column with v[b]-v[a] <= threshold; it only moves right
+	// as a increases.
+	b := 0
+	for a := 0; a < d-1; a++ {
+		if b < a {
+			b = a
+		}
+		for b+1 < d && v[b+1]-v[a] <= threshold {
+			b++
+		}
+		if b > a {
+			count += c[a] * (p[b+1] - p[a+1])
+			if diff := v[b] - v[a]; diff > maxBelow {
+				maxBelow = diff
+			}
+		}
+		if b+1 < d {
+			if diff := v[b+1] - v[a]; diff < minAbove {
+				minAbove = diff
+			}
+		}
+	}
+	return count, maxBelow, minAbove
+}
+
+// selectKthOrdered finds the k-th smallest (1-based) element of an implicit
+// multiset given a counting function that reports how many elements are
+// <= threshold along with the nearest elements on either side. The search
+// bisects the order-preserving integer image of float64, so it terminates in
+// at most ~64 steps regardless of the value range, and it always lands on an
+// actual element.
+func selectKthOrdered(searchMin, searchMax float64, k int64, count func(float64) (int64, float64, float64)) (float64, error) {
+	const maxIterations = 128
+	for iter := 0; iter < maxIterations; iter++ {
+		if searchMin == searchMax {
+			return searchMin, nil
+		}
+		lo := orderedBits(searchMin)
+		hi := orderedBits(searchMax)
+		mid := fromOrderedBits(lo + (hi-lo)/2)
+		countLessOrEqual, closestBelow, closestAbove := count(mid)
+		if countLessOrEqual >= k {
+			searchMax = closestBelow
+		} else {
+			searchMin = closestAbove
+		}
+	}
+	if searchMin != searchMax {
+		return 0, errors.New("convergence failure (pathological input)")
+	}
+	return searchMin, nil
+}
+
+// orderedBits maps a float64 to a uint64 whose unsigned order matches the
+// numeric order of the (non-NaN) float64 values.
+func orderedBits(f float64) uint64 {
+	b := math.Float64bits(f)
+	if b>>63 == 1 {
+		return ^b
+	}
+	return b | 1<<63
+}
+
+// fromOrderedBits is the inverse of orderedBits.
+func fromOrderedBits(u uint64) float64 {
+	if u>>63 == 1 {
+		return math.Float64frombits(u &^ (1 << 63))
+	}
+	return math.Float64frombits(^u)
+}
@@ -0,0 +1,102 @@
+package pragmastat
+
+import (
+	"sort"
+	"testing"
+)
+
+// tiedVec draws n values from a small set of quantized levels.
+func tiedVec(rng *Rng, n, levels int, scale float64) []float64 {
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = float64(rng.UniformIntN(-levels/2, levels-levels/2)) * scale
+	}
+	return x
+}
+
+func TestCompressedMatchesUncompressed(t *testing.T) {
+	rng := NewRngFromSeed(invarianceSeed)
+	for iter := 0; iter < 300; iter++ {
+		n := rng.UniformIntN(1, 200)
+		levels := rng.UniformIntN(1, 12)
+		x := tiedVec(rng, n, levels, 0.1)
+
+		wantCenter, err := Center(x, false)
+		if err != nil {
+			t.Fatalf("Center: %v", err)
+		}
+		gotCenter, err := CenterCompressed(x, false)
+		if err != nil {
+			t.Fatalf("CenterCompressed: %v", err)
+		}
+		if gotCenter != wantCenter {
+			t.Fatalf("n=%d levels=%d: CenterCompressed = %v, Center = %v", n, levels, gotCenter, wantCenter)
+		}
+
+		sorted := append([]float64(nil), x...)
+		sort.Float64s(sorted)
+		if got, _ := CenterCompressed(sorted, true); got != wantCenter {
+			t.Fatalf("n=%d levels=%d: CenterCompressed(sorted) = %v, Center = %v", n, levels, got, wantCenter)
+		}
+
+		wantSpread, wantErr := Spread(x, false)
+		gotSpread, gotErr := SpreadCompressed(x, false)
+		if (wantErr == nil) != (gotErr == nil) {
+			t.Fatalf("n=%d levels=%d: Spread err = %v, SpreadCompressed err = %v", n, levels, wantErr, gotErr)
+		}
+		if wantErr == nil && gotSpread != wantSpread {
+			t.Fatalf("n=%d levels=%d: SpreadCompressed = %v, Spread = %v", n, levels, gotSpread, wantSpread)
+		}
+	}
+}
+
+func TestCompressedMatchesUncompressedContinuous(t *testing.T) {
+	// Without ties the encoding is the identity; results must still agree.
+	rng := NewRngFromSeed(invarianceSeed)
+	for n := 1; n <= 40; n++ {
+		x := uniformVec(rng, n)
+		want, _ := Center(x, false)
+		if got, _ := CenterCompressed(x, false); got != want {
+			t.Errorf("n=%d: CenterCompressed = %v, Center = %v", n, got, want)
+		}
+		if n < 2 {
+			continue
+		}
+		want, _ = Spread(x, false)
+		if got, _ := SpreadCompressed(x, false); got != want {
+			t.Errorf("n=%d: SpreadCompressed = %v, Spread = %v", n, got, want)
+		}
+	}
+}
+
+func TestCompressedViolations(t *testing.T) {
+	_, err := CenterCompressed(nil, false)
+	assertViolation(t, err, Validity, SubjectX)
+
+	_, err = SpreadCompressed([]float64{3, 3, 3, 3}, false)
+	assertViolation(t, err, Sparity, SubjectX)
+}
+
+// The benchmarks run Center on 10^7 values with 1000 distinct levels.
+
+const compressedBenchSize = 10_000_000
+
+func BenchmarkCenterTied(b *testing.B) {
+	x := tiedVec(NewRngFromSeed(invarianceSeed), compressedBenchSize, 1000, 1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := Center(x, false); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCenterCompressedTied(b *testing.B) {
+	x := tiedVec(NewRngFromSeed(invarianceSeed), compressedBenchSize, 1000, 1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := CenterCompressed(x, false); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
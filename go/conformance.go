@@ -0,0 +1,98 @@
+package pragmastat
+
+// ConformanceReport holds golden sequences for one seed, for other language
+// implementations to validate their generators against. Every field is
+// produced by a fresh NewRngFromString(seed), so each can be reproduced
+// independently. The JSON field names form a stable schema.
+type ConformanceReport struct {
+	Seed  string `json:"seed"`
+	Count int    `json:"count"`
+	// Uniform holds the first Count UniformFloat64 outputs.
+	Uniform []float64 `json:"uniform"`
+	// UniformInt64 holds the first Count UniformInt64 outputs on
+	// [ConformanceIntMin, ConformanceIntMax).
+	UniformInt64 []int64 `json:"uniform_int64"`
+	// UniformBool holds the first Count UniformBool outputs.
+	UniformBool []bool `json:"uniform_bool"`
+	// Shuffle, Sample, and Resample apply RngShuffle, RngSample (k = 3), and
+	// RngResample (k = 10) to ConformanceInput().
+	Shuffle  []float64 `json:"shuffle"`
+	Sample   []float64 `json:"sample"`
+	Resample []float64 `json:"resample"`
+	// Distributions holds one Sample from each built-in distribution.
+	Distributions ConformanceDistributions `json:"distributions"`
+}
+
+// ConformanceDistributions holds one sample per built-in distribution, each
+// drawn from a fresh generator with the standard parameters noted per field.
+type ConformanceDistributions struct {
+	Uniform   float64 `json:"uniform"`   // Uniform(0, 1)
+	Additive  float64 `json:"additive"`  // Additive(0, 1)
+	Multiplic float64 `json:"multiplic"` // Multiplic(0, 1)
+	Exp       float64 `json:"exp"`       // Exp(1)
+	Power     float64 `json:"power"`     // Power(1, 2)
+	Cauchy    float64 `json:"cauchy"`    // Cauchy(0, 1)
+	StudentT  float64 `json:"student_t"` // StudentT(3, 0, 1)
+	Gamma     float64 `json:"gamma"`     // Gamma(2, 1)
+	Beta      float64 `json:"beta"`      // Beta(2, 3)
+}
+
+// Canonical parameters of ConformanceVectors.
+const (
+	ConformanceIntMin int64 = -1000
+	ConformanceIntMax int64 = 1000
+)
+
+// conformanceInput is the canonical input for the collection vectors. It is
+// an array so that callers only ever see copies.
+var conformanceInput = [...]float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+
+// ConformanceInput returns a fresh copy of the canonical input for the
+// collection vectors: 0, 1, ..., 9.
+func ConformanceInput() []float64 {
+	return append([]float64(nil), conformanceInput[:]...)
+}
+
+// ConformanceVectors computes the golden sequences for seed. Panics if count
+// is negative.
+func ConformanceVectors(seed string, count int) ConformanceReport {
+	if count < 0 {
+		panic("conformance: count must be non-negative")
+	}
+	report := ConformanceReport{
+		Seed:         seed,
+		Count:        count,
+		Uniform:      make([]float64, count),
+		UniformInt64: make([]int64, count),
+		UniformBool:  make([]bool, count),
+	}
+	rng := NewRngFromString(seed)
+	for i := range report.Uniform {
+		report.Uniform[i] = rng.UniformFloat64()
+	}
+	rng = NewRngFromString(seed)
+	for i := range report.UniformInt64 {
+		report.UniformInt64[i] = rng.UniformInt64(ConformanceIntMin, ConformanceIntMax)
+	}
+	rng = NewRngFromString(seed)
+	for i := range report.UniformBool {
+		report.UniformBool[i] = rng.UniformBool()
+	}
+
+	report.Shuffle = RngShuffle(NewRngFromString(seed), ConformanceInput())
+	report.Sample = RngSample(NewRngFromString(seed), ConformanceInput(), 3)
+	report.Resample = RngResample(NewRngFromString(seed), ConformanceInput(), 10)
+
+	report.Distributions = ConformanceDistributions{
+		Uniform:   NewUniform(0, 1).Sample(NewRngFromString(seed)),
+		Additive:  NewAdditive(0, 1).Sample(NewRngFromString(seed)),
+		Multiplic: NewMultiplic(0, 1).Sample(NewRngFromString(seed)),
+		Exp:       NewExp(1).Sample(NewRngFromString(seed)),
+		Power:     NewPower(1, 2).Sample(NewRngFromString(seed)),
+		Cauchy:    NewCauchy(0, 1).Sample(NewRngFromString(seed)),
+		StudentT:  NewStudentT(3, 0, 1).Sample(NewRngFromString(seed)),
+		Gamma:     NewGamma(2, 1).Sample(NewRngFromString(seed)),
+		Beta:      NewBeta(2, 3).Sample(NewRngFromString(seed)),
+	}
+	return report
+}
@@ -0,0 +1,144 @@
+package pragmastat
+
+import "math"
+
+// Contaminated is the contaminated normal distribution: Additive(mean,
+// stdDev) with probability 1 - contamination and Additive(mean,
+// outlierScale·stdDev) otherwise. It models measurements with occasional
+// wild outliers, the standard robustness test bed. The parameters are fixed at
+// construction, since the components are built from them; read them with the
+// accessor methods.
+type Contaminated struct {
+	mean          float64
+	stdDev        float64
+	contamination float64
+	outlierScale  float64
+
+	mixture *Mixture
+	clean   *Additive
+	outlier *Additive
+}
+
+// NewContaminated creates a new contaminated normal distribution.
+// Panics if mean is not finite, stdDev is not positive and finite,
+// contamination is outside [0, 1), or outlierScale is not finite and greater
+// than 1 (with outlierScale·stdDev finite).
+func NewContaminated(mean, stdDev, contamination, outlierScale float64) *Contaminated {
+	c, err := TryNewContaminated(mean, stdDev, contamination, outlierScale)
+	if err != nil {
+		panic(err.Error())
+	}
+	return c
+}
+
+// TryNewContaminated is NewContaminated returning a *ParameterError instead
+// of panicking.
+func TryNewContaminated(mean, stdDev, contamination, outlierScale float64) (*Contaminated, error) {
+	if math.IsNaN(mean) || math.IsInf(mean, 0) {
+		return nil, newParameterError("Contaminated", "mean", "mean must be finite")
+	}
+	if !(stdDev > 0) || math.IsInf(stdDev, 1) {
+		return nil, newParameterError("Contaminated", "stdDev", "stdDev must be positive and finite")
+	}
+	if !(contamination >= 0 && contamination < 1) {
+		return nil, newParameterError("Contaminated", "contamination", "contamination must be in [0, 1)")
+	}
+	if !(outlierScale > 1) || math.IsInf(stdDev*outlierScale, 1) {
+		return nil, newParameterError("Contaminated", "outlierScale", "outlierScale must be finite and greater than 1")
+	}
+	clean := NewAdditive(mean, stdDev)
+	outlier := NewAdditive(mean, stdDev*outlierScale)
+	mixture, err := NewMixture([]Distribution{clean, outlier}, []float64{1 - contamination, contamination})
+	if err != nil {
+		return nil, err
+	}
+	return &Contaminated{
+		mean:          mean,
+		stdDev:        stdDev,
+		contamination: contamination,
+		outlierScale:  outlierScale,
+		mixture:       mixture,
+		clean:         clean,
+		outlier:       outlier,
+	}, nil
+}
+
+// Mean returns the mean parameter, shared by both components.
+func (c *Contaminated) Mean() float64 { return c.mean }
+
+// StdDev returns the standard deviation of the clean component.
+func (c *Contaminated) StdDev() float64 { return c.stdDev }
+
+// Contamination returns the probability of drawing from the outlier component.
+func (c *Contaminated) Contamination() float64 { return c.contamination }
+
+// OutlierScale returns the ratio of the outlier component's standard
+// deviation to the clean one's.
+func (c *Contaminated) OutlierScale() float64 { return c.outlierScale }
+
+// Sample draws one value as the two-component Mixture does: one uniform
+// picks the component, then one Additive draw follows.
+func (c *Contaminated) Sample(rng *Rng) float64 {
+	return c.mixture.Sample(rng)
+}
+
+// Samples generates multiple samples from the contaminated distribution.
+func (c *Contaminated) Samples(rng *Rng, count int) []float64 {
+	return sampleN(c, rng, count)
+}
+
+// SamplesInto fills dst with samples from the contaminated distribution without allocating.
+func (c *Contaminated) SamplesInto(rng *Rng, dst []float64) {
+	fillSamples(c, rng, dst)
+}
+
+// DrawsPerSample returns 3: one uniform to pick the component and two for
+// its Additive draw.
+func (c *Contaminated) DrawsPerSample() int {
+	return c.mixture.DrawsPerSample()
+}
+
+// Pdf returns the probability density at x.
+func (c *Contaminated) Pdf(x float64) float64 {
+	return (1-c.contamination)*c.clean.Pdf(x) + c.contamination*c.outlier.Pdf(x)
+}
+
+// Cdf returns P(X <= x).
+func (c *Contaminated) Cdf(x float64) float64 {
+	return (1-c.contamination)*c.clean.Cdf(x) + c.contamination*c.outlier.Cdf(x)
+}
+
+// Quantile returns the inverse CDF by bracketed Newton iteration.
+// Quantile(0) is -Inf, Quantile(1) is +Inf, and p outside [0, 1] gives NaN.
+func (c *Contaminated) Quantile(p float64) float64 {
+	return c.mixture.Quantile(p)
+}
+
+// TrueCenter returns the population Center, which equals the mean by
+// symmetry.
+func (c *Contaminated) TrueCenter() float64 {
+	return c.mean
+}
+
+// TrueSpread returns the population Spread, computed numerically from Cdf
+// and Quantile (there is no closed form).
+func (c *Contaminated) TrueSpread() float64 {
+	return numericTrueSpread(c)
+}
+
+// TrueMean returns the population mean, the mean parameter.
+func (c *Contaminated) TrueMean() float64 {
+	return c.mean
+}
+
+// TrueVariance returns the population variance
+// stdDev²·(1 - contamination + contamination·outlierScale²).
+func (c *Contaminated) TrueVariance() float64 {
+	s2 := c.outlierScale * c.outlierScale
+	return c.stdDev * c.stdDev * (1 - c.contamination + c.contamination*s2)
+}
+
+// TrueStdDev returns the population standard deviation, √TrueVariance.
+func (c *Contaminated) TrueStdDev() float64 {
+	return math.Sqrt(c.TrueVariance())
+}
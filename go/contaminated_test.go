@@ -0,0 +1,80 @@
+package pragmastat
+
+import (
+	"math"
+	"testing"
+)
+
+// TestContaminatedCenterVsMean shows, over repeated samples, that Center
+// stays near the mean while the sample mean drifts further from it as the
+// contamination grows.
+func TestContaminatedCenterVsMean(t *testing.T) {
+	const (
+		mean         = 10.0
+		n            = 50
+		replications = 200
+	)
+	rng := NewRngFromString("contaminated-center")
+	previousMeanError := 0.0
+	for _, contamination := range []float64{0, 0.05, 0.2} {
+		d := NewContaminated(mean, 1, contamination, 100)
+		var meanSq, centerSq float64
+		for r := 0; r < replications; r++ {
+			x := d.Samples(rng, n)
+			sum := 0.0
+			for _, v := range x {
+				sum += v
+			}
+			center, err := Center(x, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			meanSq += (sum/n - mean) * (sum/n - mean)
+			centerSq += (center - mean) * (center - mean)
+		}
+		meanError := math.Sqrt(meanSq / replications)
+		centerError := math.Sqrt(centerSq / replications)
+		t.Logf("contamination %.2f: RMS error of mean %.3f, of Center %.3f", contamination, meanError, centerError)
+
+		if centerError > 0.5 {
+			t.Errorf("contamination %v: Center RMS error %v, want <= 0.5", contamination, centerError)
+		}
+		if contamination > 0 {
+			if meanError <= 2*previousMeanError {
+				t.Errorf("contamination %v: mean RMS error %v did not grow from %v", contamination, meanError, previousMeanError)
+			}
+			if meanError <= 5*centerError {
+				t.Errorf("contamination %v: mean RMS error %v, Center %v", contamination, meanError, centerError)
+			}
+		}
+		previousMeanError = meanError
+	}
+}
+
+func TestContaminatedFunctions(t *testing.T) {
+	c := NewContaminated(1, 2, 0.1, 10)
+	if c.Mean() != 1 || c.StdDev() != 2 || c.Contamination() != 0.1 || c.OutlierScale() != 10 {
+		t.Errorf("parameters = %v, %v, %v, %v, want 1, 2, 0.1, 10",
+			c.Mean(), c.StdDev(), c.Contamination(), c.OutlierScale())
+	}
+	clean, outlier := NewAdditive(1, 2), NewAdditive(1, 20)
+	for _, x := range []float64{-40, -3, 0, 1, 2.5, 30} {
+		if got, want := c.Cdf(x), 0.9*clean.Cdf(x)+0.1*outlier.Cdf(x); !floatEquals(got, want, 1e-15) {
+			t.Errorf("Cdf(%v) = %v, want %v", x, got, want)
+		}
+		if got, want := c.Pdf(x), 0.9*clean.Pdf(x)+0.1*outlier.Pdf(x); !floatEquals(got, want, 1e-15) {
+			t.Errorf("Pdf(%v) = %v, want %v", x, got, want)
+		}
+	}
+
+	// Without contamination the stream is the Additive stream after the
+	// component-selection uniform.
+	pure := NewContaminated(1, 2, 0, 10)
+	mixed, direct := NewRngFromString("contaminated-pure"), NewRngFromString("contaminated-pure")
+	for i := 0; i < 100; i++ {
+		direct.UniformFloat64()
+		if got, want := pure.Sample(mixed), clean.Sample(direct); got != want {
+			t.Fatalf("draw %d: got %v, want %v", i, got, want)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package pragmastat
+
+// ToFloat64 converts a slice of any Number type to a new []float64, the
+// element type the raw estimators take. It is the recommended bridge when x
+// and y have different element types, e.g. counts as []int and rates as
+// []float64:
+//
+//	shift, err := Shift(ToFloat64(counts), rates, false)
+//
+// Integers beyond ±2^53 are rounded to the nearest float64. A nil input
+// returns nil.
+func ToFloat64[T Number](x []T) []float64 {
+	if x == nil {
+		return nil
+	}
+	result := make([]float64, len(x))
+	for i, v := range x {
+		result[i] = float64(v)
+	}
+	return result
+}
@@ -0,0 +1,62 @@
+package pragmastat
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestToFloat64(t *testing.T) {
+	want := []float64{1, 2, 3, 100}
+	cases := map[string][]float64{
+		"int":     ToFloat64([]int{1, 2, 3, 100}),
+		"int8":    ToFloat64([]int8{1, 2, 3, 100}),
+		"int16":   ToFloat64([]int16{1, 2, 3, 100}),
+		"int32":   ToFloat64([]int32{1, 2, 3, 100}),
+		"int64":   ToFloat64([]int64{1, 2, 3, 100}),
+		"uint":    ToFloat64([]uint{1, 2, 3, 100}),
+		"uint8":   ToFloat64([]uint8{1, 2, 3, 100}),
+		"uint16":  ToFloat64([]uint16{1, 2, 3, 100}),
+		"uint32":  ToFloat64([]uint32{1, 2, 3, 100}),
+		"uint64":  ToFloat64([]uint64{1, 2, 3, 100}),
+		"float32": ToFloat64([]float32{1, 2, 3, 100}),
+		"float64": ToFloat64([]float64{1, 2, 3, 100}),
+	}
+	for name, got := range cases {
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %v, want %v", name, got, want)
+		}
+	}
+
+	extremes := ToFloat64([]int8{math.MinInt8, math.MaxInt8})
+	if extremes[0] != -128 || extremes[1] != 127 {
+		t.Errorf("int8 extremes = %v", extremes)
+	}
+	if got := ToFloat64([]float32{0.1}); got[0] != float64(float32(0.1)) {
+		t.Errorf("float32 widening = %v", got[0])
+	}
+	if ToFloat64([]int(nil)) != nil || len(ToFloat64([]int{})) != 0 {
+		t.Error("nil must stay nil and empty must stay empty")
+	}
+
+	// The input is copied, not aliased.
+	src := []float64{1, 2}
+	dst := ToFloat64(src)
+	dst[0] = 9
+	if src[0] != 1 {
+		t.Error("ToFloat64 aliased its input")
+	}
+}
+
+func TestToFloat64MixedTypes(t *testing.T) {
+	counts := []int{10, 12, 11, 15, 13}
+	rates := []float64{5, 7, 6, 8}
+	got, err := Shift(ToFloat64(counts), rates, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := Shift([]float64{10, 12, 11, 15, 13}, rates, false)
+	if got != want {
+		t.Errorf("Shift = %v, want %v", got, want)
+	}
+}
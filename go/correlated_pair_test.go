@@ -0,0 +1,111 @@
+package pragmastat
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+// TestCorrelatedPairKendall checks the copula invariant: the Kendall
+// correlation of the pairs is (2/π)·arcsin(ρ) for continuous marginals.
+func TestCorrelatedPairKendall(t *testing.T) {
+	const n = 100_000
+	for _, rho := range []float64{-0.7, 0, 0.3, 0.9} {
+		c := NewCorrelatedPair(NewExp(1), NewMultiplic(0, 1), rho)
+		x, y := c.SamplePairs(NewRngFromString("correlated-kendall"), n)
+		if got, want := kendallTau(x, y), 2/math.Pi*math.Asin(rho); math.Abs(got-want) > 0.01 {
+			t.Errorf("rho = %v: Kendall tau = %v, want %v", rho, got, want)
+		}
+	}
+}
+
+func TestCorrelatedPairMarginals(t *testing.T) {
+	xDist, yDist := NewGamma(2, 1), NewUniform(-1, 3)
+	x, y := NewCorrelatedPair(xDist, yDist, 0.8).SamplePairs(NewRngFromString("correlated-marginals"), 20_000)
+	for _, c := range []struct {
+		name   string
+		sample []float64
+		dist   FullDistribution
+	}{{"X", x, xDist}, {"Y", y, yDist}} {
+		d, err := FitDistance(c.sample, c.dist)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d > 0.01 {
+			t.Errorf("%s marginal: FitDistance = %v, want at most 0.01", c.name, d)
+		}
+	}
+}
+
+// TestCorrelatedPairIndependent checks that rho = 0 maps two independent
+// standard normals through the marginal quantiles.
+func TestCorrelatedPairIndependent(t *testing.T) {
+	xDist, yDist := NewExp(2), NewAdditive(5, 2)
+	c := NewCorrelatedPair(xDist, yDist, 0)
+	rng := NewRngFromString("correlated-independent")
+	ref := NewRngFromString("correlated-independent")
+	for i := 0; i < 1000; i++ {
+		x, y := c.SamplePair(rng)
+		wantX := xDist.Quantile(copulaUniform(standardNormal(ref, false)))
+		wantY := yDist.Quantile(copulaUniform(standardNormal(ref, false)))
+		if x != wantX || y != wantY {
+			t.Fatalf("pair %d = (%v, %v), want (%v, %v)", i, x, y, wantX, wantY)
+		}
+	}
+}
+
+func TestCorrelatedPairTails(t *testing.T) {
+	// Extreme normals must not reach the infinite quantiles of Additive.
+	for _, z := range []float64{-9, -40, 9, 40} {
+		if u := copulaUniform(z); !(u >= 0 && u < 1) {
+			t.Errorf("copulaUniform(%v) = %v, want in [0, 1)", z, u)
+		}
+	}
+	if v := NewAdditive(0, 1).Quantile(copulaUniform(9)); math.IsInf(v, 0) {
+		t.Errorf("Quantile(copulaUniform(9)) = %v, want finite", v)
+	}
+}
+
+// kendallTau computes Kendall's tau for tie-free x and y in O(n log n) by
+// counting the inversions of y ordered by x.
+func kendallTau(x, y []float64) float64 {
+	n := len(x)
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool { return x[order[i]] < x[order[j]] })
+	ys := make([]float64, n)
+	for i, k := range order {
+		ys[i] = y[k]
+	}
+	inversions := countInversions(ys, make([]float64, n))
+	pairs := float64(n) * float64(n-1) / 2
+	return 1 - 2*float64(inversions)/pairs
+}
+
+// countInversions sorts a with a merge sort, using buf as scratch, and
+// returns the number of pairs i < j with a[i] > a[j].
+func countInversions(a, buf []float64) int64 {
+	if len(a) < 2 {
+		return 0
+	}
+	mid := len(a) / 2
+	count := countInversions(a[:mid], buf[:mid]) + countInversions(a[mid:], buf[mid:])
+	i, j, k := 0, mid, 0
+	for i < mid && j < len(a) {
+		if a[j] < a[i] {
+			buf[k] = a[j]
+			count += int64(mid - i)
+			j++
+		} else {
+			buf[k] = a[i]
+			i++
+		}
+		k++
+	}
+	k += copy(buf[k:], a[i:mid])
+	copy(buf[k:], a[j:])
+	copy(a, buf[:len(a)])
+	return count
+}
@@ -0,0 +1,173 @@
+package pragmastat
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Discrete is a distribution over a finite set of values with given
+// weights, e.g. timer-quantized latencies.
+type Discrete struct {
+	support []float64 // sorted distinct values with positive weight
+	probs   []float64 // normalized weights, aligned with support
+	cum     []float64 // running sums of probs
+	alias   *AliasTable
+}
+
+// NewDiscrete creates a discrete distribution taking values[i] with
+// probability proportional to weights[i]. Repeated values are merged by
+// summing their weights, and zero-weight values are dropped from the
+// support. Returns a *ParameterError if the lengths differ or are zero, a
+// value is not finite, or the weights are not finite and non-negative with
+// a positive sum.
+func NewDiscrete(values, weights []float64) (*Discrete, error) {
+	if len(values) == 0 {
+		return nil, newParameterError("Discrete", "values", "values cannot be empty")
+	}
+	if len(weights) != len(values) {
+		return nil, newParameterError("Discrete", "weights", "weights length must match values length")
+	}
+	total := 0.0
+	for i, w := range weights {
+		if math.IsNaN(values[i]) || math.IsInf(values[i], 0) {
+			return nil, newParameterError("Discrete", "values", fmt.Sprintf("values[%d] must be finite, got %v", i, values[i]))
+		}
+		if math.IsNaN(w) || math.IsInf(w, 0) || w < 0 {
+			return nil, newParameterError("Discrete", "weights", fmt.Sprintf("weights[%d] must be finite and non-negative, got %v", i, w))
+		}
+		total += w
+	}
+	if !(total > 0) || math.IsInf(total, 0) {
+		return nil, newParameterError("Discrete", "weights", "weights must have a positive, finite sum")
+	}
+
+	indices := make([]int, len(values))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.Slice(indices, func(i, j int) bool { return values[indices[i]] < values[indices[j]] })
+	d := &Discrete{}
+	for _, i := range indices {
+		if weights[i] == 0 {
+			continue
+		}
+		p := weights[i] / total
+		if k := len(d.support) - 1; k >= 0 && d.support[k] == values[i] {
+			d.probs[k] += p
+			continue
+		}
+		d.support = append(d.support, values[i])
+		d.probs = append(d.probs, p)
+	}
+	d.cum = make([]float64, len(d.probs))
+	sum := 0.0
+	for i, p := range d.probs {
+		sum += p
+		d.cum[i] = sum
+	}
+	alias, err := NewAliasTable(d.probs)
+	if err != nil {
+		return nil, newParameterError("Discrete", "weights", err.Error())
+	}
+	d.alias = alias
+	return d, nil
+}
+
+// Support returns a copy of the sorted distinct values with positive weight.
+func (d *Discrete) Support() []float64 {
+	return append([]float64(nil), d.support...)
+}
+
+// Sample draws one value from the alias table, consuming a uniform index and
+// a uniform float64.
+func (d *Discrete) Sample(rng *Rng) float64 {
+	return d.support[d.alias.Draw(rng)]
+}
+
+// Samples generates multiple samples from the discrete distribution.
+func (d *Discrete) Samples(rng *Rng, count int) []float64 {
+	return sampleN(d, rng, count)
+}
+
+// SamplesInto fills dst with samples from the discrete distribution without allocating.
+func (d *Discrete) SamplesInto(rng *Rng, dst []float64) {
+	fillSamples(d, rng, dst)
+}
+
+// DrawsPerSample returns 2, the column and coin draws of the alias table.
+func (d *Discrete) DrawsPerSample() int {
+	return 2
+}
+
+// Pmf returns P(X = x).
+func (d *Discrete) Pmf(x float64) float64 {
+	k := sort.SearchFloat64s(d.support, x)
+	if k < len(d.support) && d.support[k] == x {
+		return d.probs[k]
+	}
+	return 0
+}
+
+// Cdf returns P(X <= x).
+func (d *Discrete) Cdf(x float64) float64 {
+	k := sort.Search(len(d.support), func(i int) bool { return d.support[i] > x })
+	if k == 0 {
+		return 0
+	}
+	return math.Min(d.cum[k-1], 1)
+}
+
+// Quantile returns the right-continuous inverse CDF: the smallest support
+// value x with Cdf(x) > p, or the largest value for p = 1. At a jump of the
+// CDF it therefore returns the next value, and Quantile(p) equals
+// Quantile(p+ε) for small ε. p outside [0, 1] or NaN gives NaN.
+func (d *Discrete) Quantile(p float64) float64 {
+	if invalidProbability(p) {
+		return math.NaN()
+	}
+	k := sort.Search(len(d.cum), func(i int) bool { return d.cum[i] > p })
+	if k == len(d.cum) {
+		k--
+	}
+	return d.support[k]
+}
+
+// TrueCenter returns the population Center: the median of (X1 + X2)/2 over
+// all ordered pairs of support values, weighted by the product of their
+// probabilities. It uses the weighted pairwise median of the weighted
+// Shamos estimator, in O(k log² k) expected time for k support values.
+func (d *Discrete) TrueCenter() float64 {
+	return weightedPairwiseMedian(d.support, d.probs, true, true)
+}
+
+// TrueSpread returns the population Spread: the weighted median of |X1 - X2|
+// over ordered pairs, computed like TrueCenter.
+func (d *Discrete) TrueSpread() float64 {
+	return weightedPairwiseMedian(d.support, d.probs, false, true)
+}
+
+// TrueMean returns the population mean, the probability-weighted sum of the
+// support values.
+func (d *Discrete) TrueMean() float64 {
+	var mean float64
+	for i, x := range d.support {
+		mean += d.probs[i] * x
+	}
+	return mean
+}
+
+// TrueVariance returns the population variance around TrueMean.
+func (d *Discrete) TrueVariance() float64 {
+	mean := d.TrueMean()
+	var variance float64
+	for i, x := range d.support {
+		variance += d.probs[i] * (x - mean) * (x - mean)
+	}
+	return variance
+}
+
+// TrueStdDev returns the population standard deviation, √TrueVariance.
+func (d *Discrete) TrueStdDev() float64 {
+	return math.Sqrt(d.TrueVariance())
+}
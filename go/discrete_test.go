@@ -0,0 +1,133 @@
+package pragmastat
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// newTestDiscrete has support {1, 2, 5} with probabilities {0.25, 0.5, 0.25};
+// the value 2 is given twice and 7 has zero weight.
+func newTestDiscrete(t *testing.T) *Discrete {
+	t.Helper()
+	d, err := NewDiscrete([]float64{2, 1, 5, 2, 7}, []float64{0.25, 0.25, 0.25, 0.25, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestDiscreteFrequencies(t *testing.T) {
+	d := newTestDiscrete(t)
+	if support := d.Support(); len(support) != 3 || support[0] != 1 || support[1] != 2 || support[2] != 5 {
+		t.Fatalf("Support = %v, want [1 2 5]", support)
+	}
+	const n = 100_000
+	counts := map[float64]float64{}
+	for _, x := range d.Samples(NewRngFromString("discrete-frequencies"), n) {
+		counts[x]++
+	}
+	for value, p := range map[float64]float64{1: 0.25, 2: 0.5, 5: 0.25, 7: 0} {
+		if got := d.Pmf(value); !floatEquals(got, p, 1e-15) {
+			t.Errorf("Pmf(%v) = %v, want %v", value, got, p)
+		}
+		if got := counts[value] / n; math.Abs(got-p) > 5*math.Sqrt(p*(1-p)/n) {
+			t.Errorf("frequency of %v = %v, want %v", value, got, p)
+		}
+	}
+}
+
+func TestDiscreteCdfQuantile(t *testing.T) {
+	d := newTestDiscrete(t)
+	for _, c := range []struct{ x, cdf float64 }{{0, 0}, {1, 0.25}, {1.5, 0.25}, {2, 0.75}, {4.9, 0.75}, {5, 1}, {8, 1}} {
+		if got := d.Cdf(c.x); !floatEquals(got, c.cdf, 1e-15) {
+			t.Errorf("Cdf(%v) = %v, want %v", c.x, got, c.cdf)
+		}
+	}
+
+	// Right-continuity: at a jump the quantile already takes the next value.
+	support := d.Support()
+	for k := 0; k < len(support)-1; k++ {
+		p := d.Cdf(support[k])
+		if got := d.Quantile(p); got != support[k+1] {
+			t.Errorf("Quantile(%v) = %v, want %v", p, got, support[k+1])
+		}
+		if got := d.Quantile(p - 1e-9); got != support[k] {
+			t.Errorf("Quantile(%v - 1e-9) = %v, want %v", p, got, support[k])
+		}
+	}
+	if d.Quantile(0) != 1 || d.Quantile(1) != 5 || !math.IsNaN(d.Quantile(1.5)) {
+		t.Errorf("Quantile(0), Quantile(1), Quantile(1.5) = %v, %v, %v", d.Quantile(0), d.Quantile(1), d.Quantile(1.5))
+	}
+}
+
+// TestDiscreteTrueValues compares Center and Spread of a large sample with
+// the weighted pairwise medians computed directly on the support.
+func TestDiscreteTrueValues(t *testing.T) {
+	d := newTestDiscrete(t)
+	// Pairwise averages over ordered pairs: 1 (1/16), 1.5 (1/4), 2 (1/4),
+	// 3 (1/8), 3.5 (1/4), 5 (1/16), so the median is 2. Absolute
+	// differences: 0 (3/8), 1 (1/4), 3 (1/4), 4 (1/8), so the median is 1.
+	if got := d.TrueCenter(); got != 2 {
+		t.Errorf("TrueCenter = %v, want 2", got)
+	}
+	if got := d.TrueSpread(); got != 1 {
+		t.Errorf("TrueSpread = %v, want 1", got)
+	}
+
+	x := d.Samples(NewRngFromString("discrete-center"), 20000)
+	if center, _ := Center(x, false); center != d.TrueCenter() {
+		t.Errorf("Center of a large sample = %v, want %v", center, d.TrueCenter())
+	}
+	if spread, _ := Spread(x, false); spread != d.TrueSpread() {
+		t.Errorf("Spread of a large sample = %v, want %v", spread, d.TrueSpread())
+	}
+
+	coin, err := NewDiscrete([]float64{0, 1}, []float64{1, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Absolute differences are 0 or 1 with probability 1/2 each: the
+	// cumulative probability reaches exactly 1/2 at 0, so the midpoint is used.
+	if got := coin.TrueSpread(); got != 0.5 {
+		t.Errorf("coin TrueSpread = %v, want 0.5", got)
+	}
+
+	// Probabilities such as 1/10 are inexact, but the cumulative weight is
+	// compared exactly, so the half-way ties still resolve to midpoints.
+	for _, k := range []int{3, 6, 10} {
+		values := make([]float64, k)
+		for i := range values {
+			values[i] = float64(i)
+		}
+		u, _ := NewDiscrete(values, uniformWeights(k, 1))
+		center := bruteForceWeightedPairwiseMedian(u.support, u.probs, true, true)
+		spread := bruteForceWeightedPairwiseMedian(u.support, u.probs, false, true)
+		if u.TrueCenter() != center || u.TrueSpread() != spread {
+			t.Errorf("uniform over %d values: TrueCenter, TrueSpread = %v, %v, want %v, %v",
+				k, u.TrueCenter(), u.TrueSpread(), center, spread)
+		}
+	}
+}
+
+func TestDiscreteParameterErrors(t *testing.T) {
+	for _, c := range []struct {
+		name      string
+		values    []float64
+		weights   []float64
+		parameter string
+	}{
+		{"empty", nil, nil, "values"},
+		{"length mismatch", []float64{1, 2}, []float64{1}, "weights"},
+		{"NaN value", []float64{1, math.NaN()}, []float64{1, 1}, "values"},
+		{"negative weight", []float64{1, 2}, []float64{1, -1}, "weights"},
+		{"infinite weight", []float64{1, 2}, []float64{1, math.Inf(1)}, "weights"},
+		{"zero sum", []float64{1, 2}, []float64{0, 0}, "weights"},
+	} {
+		_, err := NewDiscrete(c.values, c.weights)
+		var pe *ParameterError
+		if !errors.As(err, &pe) || pe.Distribution != "Discrete" || pe.Parameter != c.parameter {
+			t.Errorf("%s: got %v, want Discrete.%s error", c.name, err, c.parameter)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package pragmastat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDisparityDetailMatchesDisparity(t *testing.T) {
+	rng := NewRngFromString("disparity-detail")
+	x := NewAdditive(12, 2).Samples(rng, 25)
+	y := NewAdditive(10, 2).Samples(rng, 30)
+	want, err := Disparity(x, y, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := DisparityDetail(x, y, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Degenerate || got.Value != want {
+		t.Errorf("DisparityDetail = %+v, want Value %v and not degenerate", got, want)
+	}
+	shift, _ := Shift(x, y, false)
+	avgSpread, _ := avgSpread(x, y, false)
+	if got.Shift != shift || got.AvgSpread != avgSpread {
+		t.Errorf("Shift, AvgSpread = %v, %v, want %v, %v", got.Shift, got.AvgSpread, shift, avgSpread)
+	}
+}
+
+func TestDisparityDetailDegenerate(t *testing.T) {
+	for _, c := range []struct {
+		name      string
+		x, y      []float64
+		value     float64
+		avgSpread float64
+	}{
+		{"identical constants", []float64{3, 3, 3}, []float64{3, 3, 3}, math.NaN(), 0},
+		{"shifted constants", []float64{1, 1, 1}, []float64{5, 5, 5, 5}, math.Inf(-1), 0},
+		{"one tie-dominant", []float64{2, 2, 2, 2}, []float64{0, 1, 2, 3}, 0.5 / 0.75, 0.75},
+	} {
+		if _, err := Disparity(c.x, c.y, false); err == nil {
+			t.Errorf("%s: Disparity should report sparity", c.name)
+		}
+		got, err := DisparityDetail(c.x, c.y, false)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if !got.Degenerate || !sameFloat(got.Value, c.value) || got.AvgSpread != c.avgSpread {
+			t.Errorf("%s: got %+v, want degenerate with Value %v, AvgSpread %v", c.name, got, c.value, c.avgSpread)
+		}
+	}
+
+	_, err := DisparityDetail([]float64{1, math.NaN()}, []float64{1, 2}, false)
+	assertViolation(t, err, Validity, SubjectX)
+}
@@ -1,5 +1,7 @@
 package pragmastat
 
+import "errors"
+
 // machineEpsilon is the machine epsilon for IEEE 754 double-precision (binary64).
 // Value: 2^(-52) ≈ 2.220446049250313e-16
 //
@@ -32,3 +34,171 @@ type Distribution interface {
 	// Samples generates multiple samples from this distribution.
 	Samples(rng *Rng, count int) []float64
 }
+
+// FullDistribution is a Distribution with a closed-form density, CDF, and
+// inverse CDF. All built-in distributions implement it.
+//
+// Outside the support Pdf returns 0 and Cdf returns 0 or 1. Quantile(0) and
+// Quantile(1) are the support endpoints (possibly infinite), and p outside
+// [0, 1] or NaN gives NaN.
+type FullDistribution interface {
+	Distribution
+
+	// Pdf returns the probability density at x.
+	Pdf(x float64) float64
+
+	// Cdf returns P(X <= x).
+	Cdf(x float64) float64
+
+	// Quantile returns the inverse CDF at p.
+	Quantile(p float64) float64
+}
+
+// invalidProbability reports whether p lies outside [0, 1] or is NaN.
+func invalidProbability(p float64) bool {
+	return !(p >= 0 && p <= 1)
+}
+
+// ParameterError reports an invalid distribution parameter from a TryNew…
+// constructor. Error returns the same message the panicking New… constructor
+// panics with.
+//
+// It is an AssumptionError-style domain violation whose subject is the
+// parameter name: Violation returns {Domain, Subject(Parameter)}, and
+// errors.As(err, &target) with target of type *AssumptionError succeeds, so
+// callers that already handle assumption violations need no second case. The
+// type stays separate because it also carries the distribution name and a
+// readable message (AssumptionError formats only as "domain(stdDev)"), and
+// because the estimators' subjects name samples (x, y, misrate), not
+// parameters.
+type ParameterError struct {
+	Distribution string // e.g. "Additive"
+	Parameter    string // e.g. "stdDev"
+	Message      string
+}
+
+func newParameterError(distribution, parameter, message string) *ParameterError {
+	return &ParameterError{Distribution: distribution, Parameter: parameter, Message: message}
+}
+
+func (e *ParameterError) Error() string {
+	return e.Message
+}
+
+// Violation returns the domain violation of the named parameter.
+func (e *ParameterError) Violation() Violation {
+	return Violation{ID: Domain, Subject: Subject(e.Parameter)}
+}
+
+// As lets errors.As convert e into an *AssumptionError carrying Violation.
+func (e *ParameterError) As(target any) bool {
+	if ae, ok := target.(**AssumptionError); ok {
+		*ae = &AssumptionError{Violation: e.Violation()}
+		return true
+	}
+	return false
+}
+
+// sampleN allocates count values and fills them through SamplesInto, so a
+// batch consumes exactly the same random numbers as count single draws.
+func sampleN(d Distribution, rng *Rng, count int) []float64 {
+	result := make([]float64, count)
+	SamplesInto(d, rng, result)
+	return result
+}
+
+// fillSamples fills dst by calling d.Sample sequentially.
+func fillSamples(d Distribution, rng *Rng, dst []float64) {
+	for i := range dst {
+		dst[i] = d.Sample(rng)
+	}
+}
+
+// intoSampler is implemented by the built-in distributions.
+type intoSampler interface {
+	SamplesInto(rng *Rng, dst []float64)
+}
+
+// SamplesInto fills dst with len(dst) samples from d without allocating, for
+// tight simulation loops that reuse one buffer. The values equal
+// d.Samples(rng, len(dst)). Every built-in distribution has a SamplesInto
+// method; for other distributions this falls back to repeated d.Sample.
+func SamplesInto(d Distribution, rng *Rng, dst []float64) {
+	if s, ok := d.(intoSampler); ok {
+		s.SamplesInto(rng, dst)
+		return
+	}
+	fillSamples(d, rng, dst)
+}
+
+// VariableDraws is returned by DrawsPerSample when the number of generator
+// outputs consumed by one Sample depends on the values drawn, as in
+// rejection sampling.
+const VariableDraws = -1
+
+// drawCounter is implemented by the built-in distributions.
+type drawCounter interface {
+	DrawsPerSample() int
+}
+
+// DrawsPerSample returns how many 64-bit generator outputs (uniforms) one
+// d.Sample consumes, for debugging cross-language determinism: Uniform, Exp,
+// Power, and Cauchy consume 1 and Additive 2. Returns VariableDraws if the
+// count varies or if d does not report it.
+func DrawsPerSample(d Distribution) int {
+	if c, ok := d.(drawCounter); ok {
+		return c.DrawsPerSample()
+	}
+	return VariableDraws
+}
+
+// addDraws returns a + b, or VariableDraws if either is VariableDraws.
+func addDraws(a, b int) int {
+	if a == VariableDraws || b == VariableDraws {
+		return VariableDraws
+	}
+	return a + b
+}
+
+// multiplyDraws returns k·n, or VariableDraws if n is VariableDraws.
+func multiplyDraws(k, n int) int {
+	if n == VariableDraws {
+		return VariableDraws
+	}
+	return k * n
+}
+
+// ErrNoAntithetic is returned by AntitheticPair for distributions that do not
+// provide antithetic sampling.
+var ErrNoAntithetic = errors.New("distribution does not support antithetic sampling")
+
+// antitheticSampler is implemented by the built-in distributions.
+type antitheticSampler interface {
+	antitheticPair(rng *Rng) (float64, float64)
+}
+
+// AntitheticPair draws a negatively correlated pair of samples from d. The
+// inverse-CDF distributions (Uniform, Exp, Power, Cauchy) transform u and 1-u
+// from UniformAntithetic; Additive and Multiplic reflect the standard normal
+// draw z to -z, and StudentT reflects its standard draw likewise. The first value equals what d.Sample would have returned, and
+// the pair consumes the same random numbers as one Sample call. Averaging
+// pairs instead of independent draws reduces the variance of mean estimators
+// for a fixed budget of samples.
+func AntitheticPair(d Distribution, rng *Rng) (float64, float64, error) {
+	a, ok := d.(antitheticSampler)
+	if !ok {
+		return 0, 0, ErrNoAntithetic
+	}
+	x, y := a.antitheticPair(rng)
+	return x, y, nil
+}
+
+// antitheticBelowOne maps the mirror value 1 - u of UniformAntithetic, which
+// is exactly 1 when u is 0, to the largest value the inverse-CDF samplers
+// tolerate, so an unbounded quantile stays finite.
+func antitheticBelowOne(v float64) float64 {
+	if v == 1 {
+		return 1 - machineEpsilon
+	}
+	return v
+}
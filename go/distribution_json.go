@@ -0,0 +1,933 @@
+package pragmastat
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// JSON schema of the built-in distributions. Every distribution is an object
+// with a "type" field; parameter names are the lowerCamelCase constructor
+// arguments. Optional fields are marked with "?".
+//
+//	{"type":"uniform","min":0,"max":1,"interval"?:"closed"|"open"}
+//	{"type":"additive","mean":0,"stdDev":1,"open"?:true}
+//	{"type":"multiplic","logMean":0,"logStdDev":1}
+//	{"type":"exp","rate":1,"open"?:true}
+//	{"type":"power","min":1,"shape":2,"open"?:true}
+//	{"type":"cauchy","location":0,"scale":1}
+//	{"type":"studentT","df":5,"location":0,"scale":1}
+//	{"type":"gamma","shape":2,"rate":1}
+//	{"type":"beta","alpha":2,"beta":3}
+//	{"type":"bernoulli","p":0.5}
+//	{"type":"binomial","n":10,"p":0.5}
+//	{"type":"contaminated","mean":0,"stdDev":1,"contamination":0.1,"outlierScale":10}
+//	{"type":"discrete","values":[1,2],"weights":[0.5,0.5]}
+//	{"type":"histogram","edges":[0,1,3],"counts":[0.25,0.75]}
+//	{"type":"empirical","values":[1,2,3],"weights"?:[1,1,2],"unit"?:"number"}
+//	{"type":"mixture","components":[{...},{...}],"weights":[0.9,0.1]}
+//	{"type":"affine","inner":{...},"scale":2,"offset":1}
+//	{"type":"sumOf","inner":{...},"k":3}
+//	{"type":"orderStat","inner":{...},"k":3,"rank":3}
+//
+// Omitting "interval" or "open" selects the default (half-open) variant.
+// Written weights are normalized, and "unit" is resolved against the
+// registry's unit registry. Unknown and missing fields are errors.
+var builtinDistributionDecoders = map[string]DistributionDecoder{
+	"uniform":      decodeUniform,
+	"additive":     decodeAdditive,
+	"multiplic":    decodeMultiplic,
+	"exp":          decodeExp,
+	"power":        decodePower,
+	"cauchy":       decodeCauchy,
+	"studentT":     decodeStudentT,
+	"gamma":        decodeGamma,
+	"beta":         decodeBeta,
+	"bernoulli":    decodeBernoulli,
+	"binomial":     decodeBinomial,
+	"contaminated": decodeContaminated,
+	"discrete":     decodeDiscrete,
+	"histogram":    decodeHistogram,
+	"empirical":    decodeEmpirical,
+	"mixture":      decodeMixture,
+	"affine":       decodeAffine,
+	"sumOf":        decodeSumOf,
+	"orderStat":    decodeOrderStat,
+}
+
+// jsonFields reads the fields of one distribution object, remembering the
+// first error and which fields were consumed so that done can reject
+// unknown ones.
+type jsonFields struct {
+	typeName string
+	fields   map[string]json.RawMessage
+	used     map[string]bool
+	err      error
+}
+
+func newJSONFields(raw []byte, typeName string) (*jsonFields, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, fmt.Errorf("%s: %w", typeName, err)
+	}
+	return &jsonFields{typeName: typeName, fields: fields, used: map[string]bool{"type": true}}, nil
+}
+
+// field decodes the named field into a T. A missing required field is an
+// error; a missing optional field gives the zero value.
+func field[T any](f *jsonFields, name string, required bool) T {
+	var v T
+	if f.err != nil {
+		return v
+	}
+	raw, ok := f.fields[name]
+	f.used[name] = true
+	if !ok {
+		if required {
+			f.err = fmt.Errorf("%s: missing field \"%s\"", f.typeName, name)
+		}
+		return v
+	}
+	if string(raw) == "null" {
+		f.err = fmt.Errorf("%s: field \"%s\" cannot be null", f.typeName, name)
+		return v
+	}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		f.err = fmt.Errorf("%s: field \"%s\": %w", f.typeName, name, err)
+	}
+	return v
+}
+
+// done returns the first decoding error, or an error naming the first
+// unknown field in sorted order.
+func (f *jsonFields) done() error {
+	if f.err != nil {
+		return f.err
+	}
+	var unknown []string
+	for name := range f.fields {
+		if !f.used[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("%s: unknown field \"%s\"", f.typeName, unknown[0])
+	}
+	return nil
+}
+
+// wrapParameterError prefixes a constructor error with the JSON type name,
+// keeping it reachable with errors.As.
+func wrapParameterError(typeName string, err error) error {
+	return fmt.Errorf("%s: %w", typeName, err)
+}
+
+// marshalNested encodes a wrapped distribution, which must implement
+// json.Marshaler to carry its "type" field.
+func marshalNested(d Distribution) (json.RawMessage, error) {
+	m, ok := d.(json.Marshaler)
+	if !ok {
+		return nil, fmt.Errorf("%T does not implement json.Marshaler", d)
+	}
+	return m.MarshalJSON()
+}
+
+// unmarshalBuiltin decodes data as the built-in typeName, nesting through
+// StandardDistributionRegistry. It backs the UnmarshalJSON methods.
+func unmarshalBuiltin[D Distribution](data []byte, typeName string) (D, error) {
+	var zero D
+	var header struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return zero, fmt.Errorf("%s: %w", typeName, err)
+	}
+	if header.Type != typeName {
+		return zero, fmt.Errorf("%s: \"type\" is '%s'", typeName, header.Type)
+	}
+	d, err := builtinDistributionDecoders[typeName](data, StandardDistributionRegistry())
+	if err != nil {
+		return zero, err
+	}
+	return d.(D), nil
+}
+
+func decodeUniform(raw json.RawMessage, _ *DistributionRegistry) (Distribution, error) {
+	f, err := newJSONFields(raw, "uniform")
+	if err != nil {
+		return nil, err
+	}
+	min := field[float64](f, "min", true)
+	max := field[float64](f, "max", true)
+	interval := field[string](f, "interval", false)
+	if err := f.done(); err != nil {
+		return nil, err
+	}
+	u, err := TryNewUniform(min, max)
+	if err != nil {
+		return nil, wrapParameterError("uniform", err)
+	}
+	switch interval {
+	case "":
+	case "closed":
+		u.closed = true
+	case "open":
+		u.open = true
+	default:
+		return nil, fmt.Errorf("uniform: \"interval\" must be \"closed\" or \"open\", got '%s'", interval)
+	}
+	return u, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (u *Uniform) MarshalJSON() ([]byte, error) {
+	interval := ""
+	switch {
+	case u.closed:
+		interval = "closed"
+	case u.open:
+		interval = "open"
+	}
+	return json.Marshal(struct {
+		Type     string  `json:"type"`
+		Min      float64 `json:"min"`
+		Max      float64 `json:"max"`
+		Interval string  `json:"interval,omitempty"`
+	}{"uniform", u.Min, u.Max, interval})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (u *Uniform) UnmarshalJSON(data []byte) error {
+	d, err := unmarshalBuiltin[*Uniform](data, "uniform")
+	if err != nil {
+		return err
+	}
+	*u = *d
+	return nil
+}
+
+func decodeAdditive(raw json.RawMessage, _ *DistributionRegistry) (Distribution, error) {
+	f, err := newJSONFields(raw, "additive")
+	if err != nil {
+		return nil, err
+	}
+	mean := field[float64](f, "mean", true)
+	stdDev := field[float64](f, "stdDev", true)
+	open := field[bool](f, "open", false)
+	if err := f.done(); err != nil {
+		return nil, err
+	}
+	a, err := TryNewAdditive(mean, stdDev)
+	if err != nil {
+		return nil, wrapParameterError("additive", err)
+	}
+	a.open = open
+	return a, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (a *Additive) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type   string  `json:"type"`
+		Mean   float64 `json:"mean"`
+		StdDev float64 `json:"stdDev"`
+		Open   bool    `json:"open,omitempty"`
+	}{"additive", a.Mean, a.StdDev, a.open})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (a *Additive) UnmarshalJSON(data []byte) error {
+	d, err := unmarshalBuiltin[*Additive](data, "additive")
+	if err != nil {
+		return err
+	}
+	*a = *d
+	return nil
+}
+
+func decodeMultiplic(raw json.RawMessage, _ *DistributionRegistry) (Distribution, error) {
+	f, err := newJSONFields(raw, "multiplic")
+	if err != nil {
+		return nil, err
+	}
+	logMean := field[float64](f, "logMean", true)
+	logStdDev := field[float64](f, "logStdDev", true)
+	if err := f.done(); err != nil {
+		return nil, err
+	}
+	m, err := TryNewMultiplic(logMean, logStdDev)
+	if err != nil {
+		return nil, wrapParameterError("multiplic", err)
+	}
+	return m, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (m *Multiplic) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type      string  `json:"type"`
+		LogMean   float64 `json:"logMean"`
+		LogStdDev float64 `json:"logStdDev"`
+	}{"multiplic", m.LogMean, m.LogStdDev})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (m *Multiplic) UnmarshalJSON(data []byte) error {
+	d, err := unmarshalBuiltin[*Multiplic](data, "multiplic")
+	if err != nil {
+		return err
+	}
+	*m = *d
+	return nil
+}
+
+func decodeExp(raw json.RawMessage, _ *DistributionRegistry) (Distribution, error) {
+	f, err := newJSONFields(raw, "exp")
+	if err != nil {
+		return nil, err
+	}
+	rate := field[float64](f, "rate", true)
+	open := field[bool](f, "open", false)
+	if err := f.done(); err != nil {
+		return nil, err
+	}
+	e, err := TryNewExp(rate)
+	if err != nil {
+		return nil, wrapParameterError("exp", err)
+	}
+	e.open = open
+	return e, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e *Exp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type string  `json:"type"`
+		Rate float64 `json:"rate"`
+		Open bool    `json:"open,omitempty"`
+	}{"exp", e.Rate, e.open})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *Exp) UnmarshalJSON(data []byte) error {
+	d, err := unmarshalBuiltin[*Exp](data, "exp")
+	if err != nil {
+		return err
+	}
+	*e = *d
+	return nil
+}
+
+func decodePower(raw json.RawMessage, _ *DistributionRegistry) (Distribution, error) {
+	f, err := newJSONFields(raw, "power")
+	if err != nil {
+		return nil, err
+	}
+	min := field[float64](f, "min", true)
+	shape := field[float64](f, "shape", true)
+	open := field[bool](f, "open", false)
+	if err := f.done(); err != nil {
+		return nil, err
+	}
+	p, err := TryNewPower(min, shape)
+	if err != nil {
+		return nil, wrapParameterError("power", err)
+	}
+	p.open = open
+	return p, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p *Power) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type  string  `json:"type"`
+		Min   float64 `json:"min"`
+		Shape float64 `json:"shape"`
+		Open  bool    `json:"open,omitempty"`
+	}{"power", p.Min, p.Shape, p.open})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *Power) UnmarshalJSON(data []byte) error {
+	d, err := unmarshalBuiltin[*Power](data, "power")
+	if err != nil {
+		return err
+	}
+	*p = *d
+	return nil
+}
+
+func decodeCauchy(raw json.RawMessage, _ *DistributionRegistry) (Distribution, error) {
+	f, err := newJSONFields(raw, "cauchy")
+	if err != nil {
+		return nil, err
+	}
+	location := field[float64](f, "location", true)
+	scale := field[float64](f, "scale", true)
+	if err := f.done(); err != nil {
+		return nil, err
+	}
+	c, err := TryNewCauchy(location, scale)
+	if err != nil {
+		return nil, wrapParameterError("cauchy", err)
+	}
+	return c, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c *Cauchy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type     string  `json:"type"`
+		Location float64 `json:"location"`
+		Scale    float64 `json:"scale"`
+	}{"cauchy", c.Location, c.Scale})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *Cauchy) UnmarshalJSON(data []byte) error {
+	d, err := unmarshalBuiltin[*Cauchy](data, "cauchy")
+	if err != nil {
+		return err
+	}
+	*c = *d
+	return nil
+}
+
+func decodeStudentT(raw json.RawMessage, _ *DistributionRegistry) (Distribution, error) {
+	f, err := newJSONFields(raw, "studentT")
+	if err != nil {
+		return nil, err
+	}
+	df := field[float64](f, "df", true)
+	location := field[float64](f, "location", true)
+	scale := field[float64](f, "scale", true)
+	if err := f.done(); err != nil {
+		return nil, err
+	}
+	s, err := TryNewStudentT(df, location, scale)
+	if err != nil {
+		return nil, wrapParameterError("studentT", err)
+	}
+	return s, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s *StudentT) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type     string  `json:"type"`
+		Df       float64 `json:"df"`
+		Location float64 `json:"location"`
+		Scale    float64 `json:"scale"`
+	}{"studentT", s.Df, s.Location, s.Scale})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *StudentT) UnmarshalJSON(data []byte) error {
+	d, err := unmarshalBuiltin[*StudentT](data, "studentT")
+	if err != nil {
+		return err
+	}
+	*s = *d
+	return nil
+}
+
+func decodeGamma(raw json.RawMessage, _ *DistributionRegistry) (Distribution, error) {
+	f, err := newJSONFields(raw, "gamma")
+	if err != nil {
+		return nil, err
+	}
+	shape := field[float64](f, "shape", true)
+	rate := field[float64](f, "rate", true)
+	if err := f.done(); err != nil {
+		return nil, err
+	}
+	g, err := TryNewGamma(shape, rate)
+	if err != nil {
+		return nil, wrapParameterError("gamma", err)
+	}
+	return g, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (g *Gamma) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type  string  `json:"type"`
+		Shape float64 `json:"shape"`
+		Rate  float64 `json:"rate"`
+	}{"gamma", g.Shape, g.Rate})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (g *Gamma) UnmarshalJSON(data []byte) error {
+	d, err := unmarshalBuiltin[*Gamma](data, "gamma")
+	if err != nil {
+		return err
+	}
+	*g = *d
+	return nil
+}
+
+func decodeBeta(raw json.RawMessage, _ *DistributionRegistry) (Distribution, error) {
+	f, err := newJSONFields(raw, "beta")
+	if err != nil {
+		return nil, err
+	}
+	alpha := field[float64](f, "alpha", true)
+	beta := field[float64](f, "beta", true)
+	if err := f.done(); err != nil {
+		return nil, err
+	}
+	b, err := TryNewBeta(alpha, beta)
+	if err != nil {
+		return nil, wrapParameterError("beta", err)
+	}
+	return b, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (b *Beta) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type  string  `json:"type"`
+		Alpha float64 `json:"alpha"`
+		Beta  float64 `json:"beta"`
+	}{"beta", b.Alpha, b.Beta})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Beta) UnmarshalJSON(data []byte) error {
+	d, err := unmarshalBuiltin[*Beta](data, "beta")
+	if err != nil {
+		return err
+	}
+	*b = *d
+	return nil
+}
+
+func decodeBernoulli(raw json.RawMessage, _ *DistributionRegistry) (Distribution, error) {
+	f, err := newJSONFields(raw, "bernoulli")
+	if err != nil {
+		return nil, err
+	}
+	p := field[float64](f, "p", true)
+	if err := f.done(); err != nil {
+		return nil, err
+	}
+	b, err := TryNewBernoulli(p)
+	if err != nil {
+		return nil, wrapParameterError("bernoulli", err)
+	}
+	return b, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (b *Bernoulli) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type string  `json:"type"`
+		P    float64 `json:"p"`
+	}{"bernoulli", b.P})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Bernoulli) UnmarshalJSON(data []byte) error {
+	d, err := unmarshalBuiltin[*Bernoulli](data, "bernoulli")
+	if err != nil {
+		return err
+	}
+	*b = *d
+	return nil
+}
+
+func decodeBinomial(raw json.RawMessage, _ *DistributionRegistry) (Distribution, error) {
+	f, err := newJSONFields(raw, "binomial")
+	if err != nil {
+		return nil, err
+	}
+	n := field[int](f, "n", true)
+	p := field[float64](f, "p", true)
+	if err := f.done(); err != nil {
+		return nil, err
+	}
+	b, err := TryNewBinomial(n, p)
+	if err != nil {
+		return nil, wrapParameterError("binomial", err)
+	}
+	return b, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (b *Binomial) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type string  `json:"type"`
+		N    int     `json:"n"`
+		P    float64 `json:"p"`
+	}{"binomial", b.N, b.P})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Binomial) UnmarshalJSON(data []byte) error {
+	d, err := unmarshalBuiltin[*Binomial](data, "binomial")
+	if err != nil {
+		return err
+	}
+	*b = *d
+	return nil
+}
+
+func decodeContaminated(raw json.RawMessage, _ *DistributionRegistry) (Distribution, error) {
+	f, err := newJSONFields(raw, "contaminated")
+	if err != nil {
+		return nil, err
+	}
+	mean := field[float64](f, "mean", true)
+	stdDev := field[float64](f, "stdDev", true)
+	contamination := field[float64](f, "contamination", true)
+	outlierScale := field[float64](f, "outlierScale", true)
+	if err := f.done(); err != nil {
+		return nil, err
+	}
+	c, err := TryNewContaminated(mean, stdDev, contamination, outlierScale)
+	if err != nil {
+		return nil, wrapParameterError("contaminated", err)
+	}
+	return c, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c *Contaminated) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type          string  `json:"type"`
+		Mean          float64 `json:"mean"`
+		StdDev        float64 `json:"stdDev"`
+		Contamination float64 `json:"contamination"`
+		OutlierScale  float64 `json:"outlierScale"`
+	}{"contaminated", c.mean, c.stdDev, c.contamination, c.outlierScale})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *Contaminated) UnmarshalJSON(data []byte) error {
+	d, err := unmarshalBuiltin[*Contaminated](data, "contaminated")
+	if err != nil {
+		return err
+	}
+	*c = *d
+	return nil
+}
+
+func decodeDiscrete(raw json.RawMessage, _ *DistributionRegistry) (Distribution, error) {
+	f, err := newJSONFields(raw, "discrete")
+	if err != nil {
+		return nil, err
+	}
+	values := field[[]float64](f, "values", true)
+	weights := field[[]float64](f, "weights", true)
+	if err := f.done(); err != nil {
+		return nil, err
+	}
+	d, err := NewDiscrete(values, weights)
+	if err != nil {
+		return nil, wrapParameterError("discrete", err)
+	}
+	return d, nil
+}
+
+// MarshalJSON implements json.Marshaler. The support is written with its
+// normalized probabilities as weights.
+func (d *Discrete) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type    string    `json:"type"`
+		Values  []float64 `json:"values"`
+		Weights []float64 `json:"weights"`
+	}{"discrete", d.support, d.probs})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Discrete) UnmarshalJSON(data []byte) error {
+	decoded, err := unmarshalBuiltin[*Discrete](data, "discrete")
+	if err != nil {
+		return err
+	}
+	*d = *decoded
+	return nil
+}
+
+func decodeHistogram(raw json.RawMessage, _ *DistributionRegistry) (Distribution, error) {
+	f, err := newJSONFields(raw, "histogram")
+	if err != nil {
+		return nil, err
+	}
+	edges := field[[]float64](f, "edges", true)
+	counts := field[[]float64](f, "counts", true)
+	if err := f.done(); err != nil {
+		return nil, err
+	}
+	h, err := NewHistogramDistribution(edges, counts)
+	if err != nil {
+		return nil, wrapParameterError("histogram", err)
+	}
+	return h, nil
+}
+
+// MarshalJSON implements json.Marshaler. The bins are written with their
+// normalized probabilities as counts.
+func (h *HistogramDist) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type   string    `json:"type"`
+		Edges  []float64 `json:"edges"`
+		Counts []float64 `json:"counts"`
+	}{"histogram", h.edges, h.probs})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (h *HistogramDist) UnmarshalJSON(data []byte) error {
+	decoded, err := unmarshalBuiltin[*HistogramDist](data, "histogram")
+	if err != nil {
+		return err
+	}
+	*h = *decoded
+	return nil
+}
+
+func decodeEmpirical(raw json.RawMessage, r *DistributionRegistry) (Distribution, error) {
+	f, err := newJSONFields(raw, "empirical")
+	if err != nil {
+		return nil, err
+	}
+	values := field[[]float64](f, "values", true)
+	weights := field[[]float64](f, "weights", false)
+	unitID := field[string](f, "unit", false)
+	if err := f.done(); err != nil {
+		return nil, err
+	}
+	unit := NumberUnit
+	if unitID != "" {
+		if unit, err = r.units.Resolve(unitID); err != nil {
+			return nil, fmt.Errorf("empirical: %w", err)
+		}
+	}
+	s, err := NewWeightedSample(values, weights, unit)
+	if err != nil {
+		return nil, fmt.Errorf("empirical: %w", err)
+	}
+	e, err := NewEmpirical(s)
+	if err != nil {
+		return nil, fmt.Errorf("empirical: %w", err)
+	}
+	return e, nil
+}
+
+// MarshalJSON implements json.Marshaler. Values and weights are written in
+// the sample's original order, with the unit as its ID.
+func (e *Empirical) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type    string    `json:"type"`
+		Values  []float64 `json:"values"`
+		Weights []float64 `json:"weights,omitempty"`
+		Unit    string    `json:"unit"`
+	}{"empirical", e.sample.values, e.sample.weights, e.sample.unit.ID})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Units resolve against
+// StandardRegistry; use a DistributionRegistry with SetUnitRegistry for
+// custom units.
+func (e *Empirical) UnmarshalJSON(data []byte) error {
+	d, err := unmarshalBuiltin[*Empirical](data, "empirical")
+	if err != nil {
+		return err
+	}
+	e.sample, e.sorted, e.cum = d.sample, d.sorted, d.cum
+	e.aliasOnce, e.alias = sync.Once{}, nil
+	return nil
+}
+
+func decodeMixture(raw json.RawMessage, r *DistributionRegistry) (Distribution, error) {
+	f, err := newJSONFields(raw, "mixture")
+	if err != nil {
+		return nil, err
+	}
+	rawComponents := field[[]json.RawMessage](f, "components", true)
+	weights := field[[]float64](f, "weights", true)
+	if err := f.done(); err != nil {
+		return nil, err
+	}
+	components := make([]Distribution, len(rawComponents))
+	for i, rc := range rawComponents {
+		if components[i], err = r.Parse(rc); err != nil {
+			return nil, fmt.Errorf("mixture: components[%d]: %w", i, err)
+		}
+	}
+	m, err := NewMixture(components, weights)
+	if err != nil {
+		return nil, fmt.Errorf("mixture: %w", err)
+	}
+	return m, nil
+}
+
+// MarshalJSON implements json.Marshaler. Components must implement
+// json.Marshaler themselves; weights are written normalized.
+func (m *Mixture) MarshalJSON() ([]byte, error) {
+	components := make([]json.RawMessage, len(m.components))
+	for i, c := range m.components {
+		raw, err := marshalNested(c)
+		if err != nil {
+			return nil, fmt.Errorf("mixture: components[%d]: %w", i, err)
+		}
+		components[i] = raw
+	}
+	return json.Marshal(struct {
+		Type       string            `json:"type"`
+		Components []json.RawMessage `json:"components"`
+		Weights    []float64         `json:"weights"`
+	}{"mixture", components, m.probs})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Components must be built-in
+// types; use a DistributionRegistry to nest registered user types.
+func (m *Mixture) UnmarshalJSON(data []byte) error {
+	d, err := unmarshalBuiltin[*Mixture](data, "mixture")
+	if err != nil {
+		return err
+	}
+	*m = *d
+	return nil
+}
+
+func decodeAffine(raw json.RawMessage, r *DistributionRegistry) (Distribution, error) {
+	f, err := newJSONFields(raw, "affine")
+	if err != nil {
+		return nil, err
+	}
+	rawInner := field[json.RawMessage](f, "inner", true)
+	scale := field[float64](f, "scale", true)
+	offset := field[float64](f, "offset", true)
+	if err := f.done(); err != nil {
+		return nil, err
+	}
+	inner, err := r.Parse(rawInner)
+	if err != nil {
+		return nil, fmt.Errorf("affine: inner: %w", err)
+	}
+	a, err := TryNewAffine(inner, scale, offset)
+	if err != nil {
+		return nil, wrapParameterError("affine", err)
+	}
+	return a, nil
+}
+
+// MarshalJSON implements json.Marshaler. Inner must implement json.Marshaler.
+func (a *Affine) MarshalJSON() ([]byte, error) {
+	inner, err := marshalNested(a.Inner)
+	if err != nil {
+		return nil, fmt.Errorf("affine: inner: %w", err)
+	}
+	return json.Marshal(struct {
+		Type   string          `json:"type"`
+		Inner  json.RawMessage `json:"inner"`
+		Scale  float64         `json:"scale"`
+		Offset float64         `json:"offset"`
+	}{"affine", inner, a.Scale, a.Offset})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Inner must be a built-in type;
+// use a DistributionRegistry to nest registered user types.
+func (a *Affine) UnmarshalJSON(data []byte) error {
+	d, err := unmarshalBuiltin[*Affine](data, "affine")
+	if err != nil {
+		return err
+	}
+	*a = *d
+	return nil
+}
+
+func decodeSumOf(raw json.RawMessage, r *DistributionRegistry) (Distribution, error) {
+	f, err := newJSONFields(raw, "sumOf")
+	if err != nil {
+		return nil, err
+	}
+	rawInner := field[json.RawMessage](f, "inner", true)
+	k := field[int](f, "k", true)
+	if err := f.done(); err != nil {
+		return nil, err
+	}
+	inner, err := r.Parse(rawInner)
+	if err != nil {
+		return nil, fmt.Errorf("sumOf: inner: %w", err)
+	}
+	s, err := TryNewSumOf(inner, k)
+	if err != nil {
+		return nil, wrapParameterError("sumOf", err)
+	}
+	return s, nil
+}
+
+// MarshalJSON implements json.Marshaler. Inner must implement json.Marshaler.
+func (s *SumOf) MarshalJSON() ([]byte, error) {
+	inner, err := marshalNested(s.Inner)
+	if err != nil {
+		return nil, fmt.Errorf("sumOf: inner: %w", err)
+	}
+	return json.Marshal(struct {
+		Type  string          `json:"type"`
+		Inner json.RawMessage `json:"inner"`
+		K     int             `json:"k"`
+	}{"sumOf", inner, s.K})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Inner must be a built-in type;
+// use a DistributionRegistry to nest registered user types.
+func (s *SumOf) UnmarshalJSON(data []byte) error {
+	d, err := unmarshalBuiltin[*SumOf](data, "sumOf")
+	if err != nil {
+		return err
+	}
+	*s = *d
+	return nil
+}
+
+func decodeOrderStat(raw json.RawMessage, r *DistributionRegistry) (Distribution, error) {
+	f, err := newJSONFields(raw, "orderStat")
+	if err != nil {
+		return nil, err
+	}
+	rawInner := field[json.RawMessage](f, "inner", true)
+	k := field[int](f, "k", true)
+	rank := field[int](f, "rank", true)
+	if err := f.done(); err != nil {
+		return nil, err
+	}
+	inner, err := r.Parse(rawInner)
+	if err != nil {
+		return nil, fmt.Errorf("orderStat: inner: %w", err)
+	}
+	o, err := TryNewOrderStat(inner, k, rank)
+	if err != nil {
+		return nil, wrapParameterError("orderStat", err)
+	}
+	return o, nil
+}
+
+// MarshalJSON implements json.Marshaler. Inner must implement json.Marshaler.
+func (o *OrderStat) MarshalJSON() ([]byte, error) {
+	inner, err := marshalNested(o.Inner)
+	if err != nil {
+		return nil, fmt.Errorf("orderStat: inner: %w", err)
+	}
+	return json.Marshal(struct {
+		Type  string          `json:"type"`
+		Inner json.RawMessage `json:"inner"`
+		K     int             `json:"k"`
+		Rank  int             `json:"rank"`
+	}{"orderStat", inner, o.K, o.Rank})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Inner must be a built-in type;
+// use a DistributionRegistry to nest registered user types.
+func (o *OrderStat) UnmarshalJSON(data []byte) error {
+	d, err := unmarshalBuiltin[*OrderStat](data, "orderStat")
+	if err != nil {
+		return err
+	}
+	*o = *d
+	return nil
+}
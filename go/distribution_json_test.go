@@ -0,0 +1,216 @@
+package pragmastat
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// jsonDistributions covers every built-in type and variant, including
+// wrappers nested inside each other.
+func jsonDistributions(t *testing.T) map[string]Distribution {
+	t.Helper()
+	discrete, err := NewDiscrete([]float64{3, 1, 2}, []float64{0.2, 0.3, 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sample, err := NewWeightedSample([]float64{4, 1, 3}, []float64{1, 2, 1}, RatioUnit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	weighted, err := NewEmpirical(sample)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, err := NewSample([]float64{5, 2, 7, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	unweighted, err := NewEmpirical(plain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inner, err := NewMixture([]Distribution{NewExp(1), NewAffine(NewBeta(2, 3), -2, 1)}, []float64{1, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	nested, err := NewMixture([]Distribution{inner, NewAdditiveOpen(0, 1), discrete}, []float64{0.5, 0.3, 0.2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	histogram, err := NewHistogramDistribution([]float64{0, 1, 2.5, 3, 10}, []float64{3, 0, 5, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return map[string]Distribution{
+		"Uniform":           NewUniform(-1, 2),
+		"UniformClosed":     NewUniformClosed(0, 1),
+		"UniformOpen":       NewUniformOpen(0, 1),
+		"Additive":          NewAdditive(1, 2),
+		"AdditiveOpen":      NewAdditiveOpen(1, 2),
+		"Multiplic":         NewMultiplic(0.5, 0.25),
+		"Exp":               NewExp(2),
+		"ExpOpen":           NewExpOpen(2),
+		"Power":             NewPower(1, 3),
+		"PowerOpen":         NewPowerOpen(1, 3),
+		"Cauchy":            NewCauchy(0, 1.5),
+		"StudentT":          NewStudentT(5, 1, 2),
+		"Gamma":             NewGamma(2.5, 0.5),
+		"Beta":              NewBeta(0.7, 3),
+		"Bernoulli":         NewBernoulli(0.3),
+		"Binomial":          NewBinomial(20, 0.3),
+		"Contaminated":      NewContaminated(1, 2, 0.1, 10),
+		"Discrete":          discrete,
+		"Histogram":         histogram,
+		"EmpiricalWeighted": weighted,
+		"Empirical":         unweighted,
+		"Mixture":           nested,
+		"Affine":            NewAffine(nested, 3, -1),
+		"SumOf":             NewSumOf(NewExp(2), 3),
+		"OrderStat":         NewOrderStat(NewAffine(NewGamma(2, 1), 2, 0), 5, 4),
+	}
+}
+
+// TestDistributionJSONRoundTrip checks that ParseDistribution restores a
+// distribution that marshals identically and draws the same stream.
+func TestDistributionJSONRoundTrip(t *testing.T) {
+	for name, d := range jsonDistributions(t) {
+		data, err := json.Marshal(d)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		parsed, err := ParseDistribution(data)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		again, err := json.Marshal(parsed)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if string(again) != string(data) {
+			t.Errorf("%s: round trip gives %s, want %s", name, again, data)
+		}
+		want := d.Samples(NewRngFromString("json-"+name), 50)
+		got := parsed.Samples(NewRngFromString("json-"+name), 50)
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s: draw %d = %v, want %v", name, i, got[i], want[i])
+				break
+			}
+		}
+	}
+}
+
+func TestDistributionUnmarshalJSON(t *testing.T) {
+	var m Multiplic
+	if err := json.Unmarshal([]byte(`{"type":"multiplic","logMean":0,"logStdDev":1}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	if m.LogMean != 0 || m.LogStdDev != 1 || m.Sample(NewRngFromString("json")) != NewMultiplic(0, 1).Sample(NewRngFromString("json")) {
+		t.Errorf("decoded %+v", m)
+	}
+
+	var a Affine
+	if err := json.Unmarshal([]byte(`{"type":"affine","inner":{"type":"exp","rate":1},"scale":2,"offset":1}`), &a); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := a.Inner.(*Exp); !ok || a.Scale != 2 || a.Offset != 1 {
+		t.Errorf("decoded %+v", a)
+	}
+
+	var e Exp
+	if err := json.Unmarshal([]byte(`{"type":"additive","mean":0,"stdDev":1}`), &e); err == nil {
+		t.Error("decoding an additive into Exp: expected an error")
+	}
+}
+
+func TestParseDistributionErrors(t *testing.T) {
+	for _, c := range []struct {
+		name, json, message string
+	}{
+		{"not an object", `[1, 2]`, "must be a JSON object"},
+		{"missing type", `{"rate": 1}`, `missing the "type" field`},
+		{"unknown type", `{"type": "weibull"}`, "unknown distribution type: 'weibull'"},
+		{"missing field", `{"type": "additive", "mean": 0}`, `additive: missing field "stdDev"`},
+		{"unknown field", `{"type": "exp", "rate": 1, "scale": 2}`, `exp: unknown field "scale"`},
+		{"null field", `{"type": "exp", "rate": null}`, `exp: field "rate" cannot be null`},
+		{"wrong field type", `{"type": "binomial", "n": 2.5, "p": 0.5}`, `binomial: field "n"`},
+		{"bad interval", `{"type": "uniform", "min": 0, "max": 1, "interval": "left"}`, `"interval" must be`},
+		{"nested", `{"type": "mixture", "components": [{"type": "exp", "rate": 1}, {"type": "foo"}], "weights": [1, 1]}`,
+			"mixture: components[1]: unknown distribution type: 'foo'"},
+		{"unknown unit", `{"type": "empirical", "values": [1, 2], "unit": "parsec"}`, "unknown unit id: 'parsec'"},
+	} {
+		_, err := ParseDistribution([]byte(c.json))
+		if err == nil || !strings.Contains(err.Error(), c.message) {
+			t.Errorf("%s: got %v, want an error containing %q", c.name, err, c.message)
+		}
+	}
+
+	_, err := ParseDistribution([]byte(`{"type": "additive", "mean": 0, "stdDev": -1}`))
+	var pe *ParameterError
+	if !errors.As(err, &pe) || pe.Distribution != "Additive" || pe.Parameter != "stdDev" {
+		t.Errorf("invalid parameter: got %v, want Additive.stdDev error", err)
+	}
+	if !strings.HasPrefix(err.Error(), "additive: ") {
+		t.Errorf("invalid parameter: message %q lacks the type prefix", err)
+	}
+
+	if _, err := json.Marshal(NewAffine(dirac(1), 2, 0)); err == nil {
+		t.Error("marshaling a wrapper of a non-marshalable distribution: expected an error")
+	}
+}
+
+// dirac is a user distribution for the registry tests.
+type dirac float64
+
+func (d dirac) Sample(*Rng) float64                   { return float64(d) }
+func (d dirac) Samples(rng *Rng, count int) []float64 { return sampleN(d, rng, count) }
+
+func TestDistributionRegistry(t *testing.T) {
+	r := StandardDistributionRegistry()
+	decode := func(raw json.RawMessage, _ *DistributionRegistry) (Distribution, error) {
+		var v struct {
+			At float64 `json:"at"`
+		}
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return nil, err
+		}
+		return dirac(v.At), nil
+	}
+	if err := r.Register("dirac", decode); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Register("dirac", decode); err == nil {
+		t.Error("duplicate registration: expected an error")
+	}
+	if err := r.Register("exp", decode); err == nil {
+		t.Error("overriding a built-in: expected an error")
+	}
+
+	// User types nest inside built-in wrappers through the registry.
+	d, err := r.Parse([]byte(`{"type": "affine", "inner": {"type": "dirac", "at": 2}, "scale": 3, "offset": 1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := d.Sample(NewRngFromString("dirac")); got != 7 {
+		t.Errorf("affine of dirac(2) = %v, want 7", got)
+	}
+	if _, err := ParseDistribution([]byte(`{"type": "dirac", "at": 2}`)); err == nil {
+		t.Error("ParseDistribution knows only built-ins: expected an error")
+	}
+
+	units := NewUnitRegistry()
+	furlong, err := NewUnit(units, "furlong", "Length", "fur", "Furlong", 201168)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.SetUnitRegistry(units)
+	d, err = r.Parse([]byte(`{"type": "empirical", "values": [1, 2], "unit": "furlong"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := d.(*Empirical).Unit(); got != furlong {
+		t.Errorf("unit = %v, want furlong", got)
+	}
+}
@@ -0,0 +1,82 @@
+package pragmastat
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// DistributionDecoder builds a distribution from its JSON object (including
+// the "type" field). The registry is passed along so that wrappers such as
+// Mixture and Affine can decode nested distributions of any registered type.
+type DistributionDecoder func(raw json.RawMessage, registry *DistributionRegistry) (Distribution, error)
+
+// DistributionRegistry maps JSON type names to decoders and enables parsing
+// distributions declared as data, e.g. {"type":"exp","rate":2}.
+type DistributionRegistry struct {
+	byType map[string]DistributionDecoder
+	units  *UnitRegistry
+}
+
+// NewDistributionRegistry creates an empty registry. Empirical units are
+// resolved against StandardRegistry until SetUnitRegistry is called.
+func NewDistributionRegistry() *DistributionRegistry {
+	return &DistributionRegistry{
+		byType: make(map[string]DistributionDecoder),
+		units:  StandardRegistry(),
+	}
+}
+
+// Register adds a decoder for the given type name.
+func (r *DistributionRegistry) Register(typeName string, decode DistributionDecoder) error {
+	if typeName == "" {
+		return fmt.Errorf("distribution type name cannot be empty")
+	}
+	if decode == nil {
+		return fmt.Errorf("decoder for distribution type '%s' cannot be nil", typeName)
+	}
+	if _, exists := r.byType[typeName]; exists {
+		return fmt.Errorf("distribution type '%s' is already registered", typeName)
+	}
+	r.byType[typeName] = decode
+	return nil
+}
+
+// SetUnitRegistry sets the registry used to resolve the "unit" field of
+// empirical distributions.
+func (r *DistributionRegistry) SetUnitRegistry(units *UnitRegistry) {
+	r.units = units
+}
+
+// Parse decodes one distribution, dispatching on its "type" field.
+func (r *DistributionRegistry) Parse(raw []byte) (Distribution, error) {
+	var header struct {
+		Type *string `json:"type"`
+	}
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return nil, fmt.Errorf("distribution must be a JSON object: %w", err)
+	}
+	if header.Type == nil {
+		return nil, fmt.Errorf("distribution is missing the \"type\" field")
+	}
+	decode, ok := r.byType[*header.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown distribution type: '%s'", *header.Type)
+	}
+	return decode(raw, r)
+}
+
+// StandardDistributionRegistry returns a registry pre-populated with every
+// built-in distribution (see distribution_json.go for the schema).
+func StandardDistributionRegistry() *DistributionRegistry {
+	r := NewDistributionRegistry()
+	for typeName, decode := range builtinDistributionDecoders {
+		_ = r.Register(typeName, decode)
+	}
+	return r
+}
+
+// ParseDistribution decodes a built-in distribution (possibly nesting other
+// built-ins) with StandardDistributionRegistry.
+func ParseDistribution(raw []byte) (Distribution, error) {
+	return StandardDistributionRegistry().Parse(raw)
+}
@@ -0,0 +1,574 @@
+package pragmastat
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// TestSamplesMatchSequentialSample guards against a batch path that consumes a
+// different number of random numbers than repeated single draws: for every
+// distribution, Samples(rng, k) must equal k calls of Sample on a freshly
+// seeded generator, and both generators must end in the same state.
+func TestSamplesMatchSequentialSample(t *testing.T) {
+	distributions := map[string]Distribution{
+		"Uniform":       NewUniform(-2, 5),
+		"UniformOpen":   NewUniformOpen(-2, 5),
+		"UniformClosed": NewUniformClosed(-2, 5),
+		"Additive":      NewAdditive(10, 3),
+		"Multiplic":     NewMultiplic(0, 1),
+		"Exp":           NewExp(0.5),
+		"Power":         NewPower(1, 2),
+		"Cauchy":        NewCauchy(0, 1),
+		"StudentT":      NewStudentT(3, 0, 1),
+		"Gamma":         NewGamma(0.7, 2),
+		"Beta":          NewBeta(2, 3),
+		"BetaJohnk":     NewBeta(0.4, 0.6),
+	}
+	for name, d := range distributions {
+		for _, k := range []int{0, 1, 2, 7, 100} {
+			batchRng := NewRngFromString("samples-" + name)
+			singleRng := NewRngFromString("samples-" + name)
+
+			batch := d.Samples(batchRng, k)
+			if len(batch) != k {
+				t.Fatalf("%s: len(Samples(%d)) = %d", name, k, len(batch))
+			}
+			for i := 0; i < k; i++ {
+				single := d.Sample(singleRng)
+				if math.Float64bits(batch[i]) != math.Float64bits(single) {
+					t.Fatalf("%s, k=%d: Samples[%d] = %v, Sample = %v", name, k, i, batch[i], single)
+				}
+			}
+			if batchRng.UniformFloat64() != singleRng.UniformFloat64() {
+				t.Errorf("%s, k=%d: generators diverge after the batch", name, k)
+			}
+		}
+	}
+}
+
+func TestUniformAntithetic(t *testing.T) {
+	rng := NewRngFromSeed(1729)
+	ref := NewRngFromSeed(1729)
+	for i := 0; i < 1000; i++ {
+		u, v := rng.UniformAntithetic()
+		if u+v != 1 {
+			t.Fatalf("pair %d: %v + %v != 1", i, u, v)
+		}
+		if want := ref.UniformFloat64(); u != want {
+			t.Fatalf("pair %d: u = %v, want the plain draw %v", i, u, want)
+		}
+	}
+}
+
+func TestAntitheticPair(t *testing.T) {
+	distributions := map[string]Distribution{
+		"Uniform":       NewUniform(-2, 5),
+		"UniformOpen":   NewUniformOpen(-2, 5),
+		"UniformClosed": NewUniformClosed(-2, 5),
+		"Additive":      NewAdditive(10, 3),
+		"Multiplic":     NewMultiplic(0, 1),
+		"Exp":           NewExp(0.5),
+		"Power":         NewPower(1, 2),
+		"Cauchy":        NewCauchy(0, 1),
+		"StudentT":      NewStudentT(3, 0, 1),
+	}
+	for name, d := range distributions {
+		pairRng := NewRngFromString("antithetic-" + name)
+		sampleRng := NewRngFromString("antithetic-" + name)
+		for i := 0; i < 100; i++ {
+			x, _, err := AntitheticPair(d, pairRng)
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			// The first value and the stream consumption match Sample.
+			if want := d.Sample(sampleRng); x != want {
+				t.Fatalf("%s, pair %d: first = %v, Sample = %v", name, i, x, want)
+			}
+		}
+	}
+
+	x, y, _ := AntitheticPair(NewAdditive(10, 3), NewRngFromSeed(1))
+	if !floatEquals(x+y, 20, 1e-12) {
+		t.Errorf("Additive pair %v, %v is not symmetric around the mean", x, y)
+	}
+
+	if _, _, err := AntitheticPair(customDistribution{}, NewRngFromSeed(1)); err != ErrNoAntithetic {
+		t.Errorf("custom distribution: err = %v, want ErrNoAntithetic", err)
+	}
+}
+
+type customDistribution struct{}
+
+func (customDistribution) Sample(rng *Rng) float64 { return rng.UniformFloat64() }
+func (c customDistribution) Samples(rng *Rng, count int) []float64 {
+	return sampleN(c, rng, count)
+}
+
+// TestAntitheticVarianceReduction estimates the mean of Exp(1) from a fixed
+// budget of draws, many times over. For Exp the antithetic correlation is
+// 1 - π²/6 ≈ -0.645, so the estimator variance should drop to about 0.36 of
+// the independent-sampling variance.
+func TestAntitheticVarianceReduction(t *testing.T) {
+	const (
+		budget       = 100
+		replications = 2000
+	)
+	d := NewExp(1)
+	rng := NewRngFromSeed(1729)
+	independent := make([]float64, replications)
+	antithetic := make([]float64, replications)
+	for r := 0; r < replications; r++ {
+		sum := 0.0
+		for _, v := range d.Samples(rng, budget) {
+			sum += v
+		}
+		independent[r] = sum / budget
+
+		sum = 0
+		for i := 0; i < budget/2; i++ {
+			x, y, _ := AntitheticPair(d, rng)
+			sum += x + y
+		}
+		antithetic[r] = sum / budget
+	}
+	ratio := variance(antithetic) / variance(independent)
+	if ratio > 0.5 || ratio < 0.2 {
+		t.Errorf("variance ratio = %.3f, want about 0.355", ratio)
+	}
+}
+
+func variance(x []float64) float64 {
+	mean := 0.0
+	for _, v := range x {
+		mean += v
+	}
+	mean /= float64(len(x))
+	sum := 0.0
+	for _, v := range x {
+		sum += (v - mean) * (v - mean)
+	}
+	return sum / float64(len(x)-1)
+}
+
+// zeroFirstRng returns a generator whose next output is exactly 0: with
+// s0 = s3 = 0, xoshiro256++ returns rotl(s0+s3, 23) + s0 = 0, so
+// UniformFloat64 yields 0 and the endpoint clamps would fire.
+func zeroFirstRng() *Rng {
+	return NewRngFromState([4]uint64{0, 1, 2, 0})
+}
+
+func TestUniformOpen(t *testing.T) {
+	if u := zeroFirstRng().UniformFloat64(); u != 0 {
+		t.Fatalf("crafted state: UniformFloat64 = %v, want 0", u)
+	}
+	rng := zeroFirstRng()
+	ref := zeroFirstRng()
+	ref.UniformFloat64() // the rejected 0
+	for i := 0; i < 100_000; i++ {
+		u := rng.UniformOpen()
+		if u <= 0 || u >= 1 {
+			t.Fatalf("draw %d: %v outside (0, 1)", i, u)
+		}
+		if want := ref.UniformFloat64(); u != want {
+			t.Fatalf("draw %d: %v, want %v (only 0 is skipped)", i, u, want)
+		}
+	}
+}
+
+func TestOpenConstructors(t *testing.T) {
+	pairs := map[string][2]Distribution{
+		"Additive": {NewAdditive(1, 2), NewAdditiveOpen(1, 2)},
+		"Exp":      {NewExp(3), NewExpOpen(3)},
+		"Power":    {NewPower(1, 2), NewPowerOpen(1, 2)},
+	}
+	for name, p := range pairs {
+		// Until the generator yields a zero draw both variants agree.
+		legacy := p[0].Samples(NewRngFromString("open-"+name), 1000)
+		open := p[1].Samples(NewRngFromString("open-"+name), 1000)
+		for i := range legacy {
+			if math.Float64bits(legacy[i]) != math.Float64bits(open[i]) {
+				t.Fatalf("%s: value %d differs: %v vs %v", name, i, legacy[i], open[i])
+			}
+		}
+		// On a draw of exactly 0 the open variant redraws and stays finite.
+		if v := p[1].Sample(zeroFirstRng()); math.IsInf(v, 0) || math.IsNaN(v) {
+			t.Errorf("%s: open variant returned %v", name, v)
+		}
+	}
+
+	// Exp and Power map a zero draw to their lower bound; the open variants
+	// redraw it, which shifts the rest of the stream by one draw.
+	for name, p := range map[string][2]FullDistribution{
+		"Exp":   {NewExp(3), NewExpOpen(3)},
+		"Power": {NewPower(1, 2), NewPowerOpen(1, 2)},
+	} {
+		legacy, open := p[0], p[1]
+		lower := legacy.Quantile(0)
+		if v := legacy.Sample(zeroFirstRng()); v != lower {
+			t.Errorf("%s: legacy variant on a zero draw = %v, want %v", name, v, lower)
+		}
+		ref := zeroFirstRng()
+		ref.UniformFloat64() // the draw the open variant rejects
+		want := legacy.Samples(ref, 100)
+		got := open.Samples(zeroFirstRng(), 100)
+		for i := range want {
+			if got[i] != want[i] || got[i] <= lower {
+				t.Fatalf("%s: open draw %d = %v, want %v (stream shifted by one)", name, i, got[i], want[i])
+			}
+		}
+		// The antithetic mirror of a zero draw is 1, which must stay finite.
+		if _, y, _ := AntitheticPair(legacy, zeroFirstRng()); math.IsInf(y, 0) || math.IsNaN(y) {
+			t.Errorf("%s: antithetic mirror of a zero draw = %v", name, y)
+		}
+	}
+
+	// The legacy Box-Muller clamp path is unchanged: u1 = 0 becomes the
+	// smallest subnormal, giving the largest possible radius.
+	legacy := NewAdditive(0, 1).Sample(zeroFirstRng())
+	open := NewAdditiveOpen(0, 1).Sample(zeroFirstRng())
+	if legacy == open {
+		t.Errorf("expected the clamp and the redraw to differ, both gave %v", legacy)
+	}
+	if r := math.Sqrt(-2 * math.Log(smallestPositiveSubnormal)); math.Abs(legacy) > r {
+		t.Errorf("legacy clamped draw %v exceeds the maximal radius %v", legacy, r)
+	}
+}
+
+// oneFirstRng returns a generator whose first output is all ones: with s0 = 0
+// and s3 = 2^64-1, xoshiro256++ returns rotl(s3, 23) = 2^64-1, so
+// UniformFloat64 yields 1 - 2^-53 and UniformClosed yields exactly 1.
+func oneFirstRng() *Rng {
+	return NewRngFromState([4]uint64{0, 1, 2, math.MaxUint64})
+}
+
+func TestUniformBoundaries(t *testing.T) {
+	if u := oneFirstRng().UniformFloat64(); u != 1-1.0/(1<<53) {
+		t.Fatalf("crafted state: UniformFloat64 = %v, want 1 - 2^-53", u)
+	}
+	if u := oneFirstRng().UniformClosed(); u != 1 {
+		t.Fatalf("crafted state: UniformClosed = %v, want 1", u)
+	}
+	if u := zeroFirstRng().UniformClosed(); u != 0 {
+		t.Fatalf("crafted state: UniformClosed = %v, want 0", u)
+	}
+
+	const min, max = -2.0, 5.0
+	if x := NewUniformClosed(min, max).Sample(oneFirstRng()); x != max {
+		t.Errorf("closed variant on a near-1 draw = %v, want %v", x, max)
+	}
+	if x := NewUniform(min, max).Sample(oneFirstRng()); x >= max {
+		t.Errorf("half-open variant on a near-1 draw = %v, want < %v", x, max)
+	}
+	if x := NewUniformOpen(min, max).Sample(oneFirstRng()); x >= max {
+		t.Errorf("open variant on a near-1 draw = %v, want < %v", x, max)
+	}
+	if x := NewUniformOpen(min, max).Sample(zeroFirstRng()); x <= min {
+		t.Errorf("open variant on a zero draw = %v, want > %v", x, min)
+	}
+	if x := NewUniform(min, max).Sample(zeroFirstRng()); x != min {
+		t.Errorf("half-open variant on a zero draw = %v, want %v", x, min)
+	}
+
+	// Antithetic pairs stay within each variant's interval on the draws that
+	// mirror onto an endpoint.
+	for _, rng := range []*Rng{zeroFirstRng(), oneFirstRng()} {
+		if x, y, _ := AntitheticPair(NewUniformOpen(min, max), rng); x <= min || x >= max || y <= min || y >= max {
+			t.Errorf("open antithetic pair = (%v, %v), want both in (%v, %v)", x, y, min, max)
+		}
+	}
+	if x, y, _ := AntitheticPair(NewUniform(min, max), zeroFirstRng()); x != min || y >= max {
+		t.Errorf("half-open antithetic pair on a zero draw = (%v, %v), want (%v, < %v)", x, y, min, max)
+	}
+	if x, y, _ := AntitheticPair(NewUniformClosed(min, max), oneFirstRng()); x != max || y != min {
+		t.Errorf("closed antithetic pair on a one draw = (%v, %v), want (%v, %v)", x, y, max, min)
+	}
+
+	// Away from the endpoints the open variant matches the default stream,
+	// and every variant stays within its interval.
+	halfOpen := NewUniform(min, max).Samples(NewRngFromString("uniform-boundaries"), 10000)
+	open := NewUniformOpen(min, max).Samples(NewRngFromString("uniform-boundaries"), 10000)
+	closed := NewUniformClosed(min, max).Samples(NewRngFromString("uniform-boundaries"), 10000)
+	for i := range halfOpen {
+		if open[i] != halfOpen[i] {
+			t.Fatalf("draw %d: open %v, half-open %v", i, open[i], halfOpen[i])
+		}
+		if closed[i] < min || closed[i] > max || open[i] <= min || open[i] >= max {
+			t.Fatalf("draw %d outside its interval: closed %v, open %v", i, closed[i], open[i])
+		}
+	}
+}
+
+var fullDistributions = map[string]FullDistribution{
+	"Uniform":      NewUniform(-2, 5),
+	"Additive":     NewAdditive(10, 3),
+	"Multiplic":    NewMultiplic(0.5, 0.8),
+	"Exp":          NewExp(0.5),
+	"Power":        NewPower(1, 2),
+	"Cauchy":       NewCauchy(1, 2),
+	"StudentT":     NewStudentT(3, 1, 2),
+	"Gamma":        NewGamma(2.5, 0.5),
+	"Beta":         NewBeta(0.7, 3),
+	"Contaminated": NewContaminated(1, 2, 0.1, 10),
+}
+
+func TestFullDistributionRoundTrip(t *testing.T) {
+	for name, d := range fullDistributions {
+		for i := 1; i < 1000; i++ {
+			p := float64(i) / 1000
+			x := d.Quantile(p)
+			if got := d.Cdf(x); math.Abs(got-p) > 1e-12 {
+				t.Errorf("%s: Cdf(Quantile(%v)) = %v", name, p, got)
+			}
+			if back := d.Quantile(d.Cdf(x)); math.Abs(back-x) > 1e-9*math.Max(1, math.Abs(x)) {
+				t.Errorf("%s: Quantile(Cdf(%v)) = %v", name, x, back)
+			}
+		}
+	}
+}
+
+// TestFullDistributionPdfIsCdfDerivative compares Pdf with a central finite
+// difference of Cdf at interior quantiles.
+func TestFullDistributionPdfIsCdfDerivative(t *testing.T) {
+	for name, d := range fullDistributions {
+		for _, p := range []float64{0.05, 0.25, 0.5, 0.75, 0.95} {
+			x := d.Quantile(p)
+			h := 1e-5 * math.Max(1, math.Abs(x))
+			numeric := (d.Cdf(x+h) - d.Cdf(x-h)) / (2 * h)
+			if pdf := d.Pdf(x); math.Abs(pdf-numeric) > 1e-6*math.Max(1, pdf) {
+				t.Errorf("%s: Pdf(%v) = %v, finite difference %v", name, x, pdf, numeric)
+			}
+		}
+	}
+}
+
+func TestFullDistributionOutsideSupport(t *testing.T) {
+	below := map[string]float64{"Uniform": -3, "Exp": -1, "Power": 0.5, "Multiplic": -1, "Gamma": -1, "Beta": -0.5}
+	for name, x := range below {
+		d := fullDistributions[name]
+		if d.Pdf(x) != 0 || d.Cdf(x) != 0 {
+			t.Errorf("%s: Pdf(%v) = %v, Cdf(%v) = %v, want 0, 0", name, x, d.Pdf(x), x, d.Cdf(x))
+		}
+	}
+	u := fullDistributions["Uniform"]
+	if u.Pdf(5) != 0 || u.Cdf(6) != 1 {
+		t.Errorf("Uniform above max: Pdf(5) = %v, Cdf(6) = %v", u.Pdf(5), u.Cdf(6))
+	}
+
+	for name, d := range fullDistributions {
+		for _, p := range []float64{-0.1, 1.1, math.NaN()} {
+			if q := d.Quantile(p); !math.IsNaN(q) {
+				t.Errorf("%s: Quantile(%v) = %v, want NaN", name, p, q)
+			}
+		}
+	}
+
+	endpoints := map[string][2]float64{
+		"Uniform":      {-2, 5},
+		"Additive":     {math.Inf(-1), math.Inf(1)},
+		"Multiplic":    {0, math.Inf(1)},
+		"Exp":          {0, math.Inf(1)},
+		"Power":        {1, math.Inf(1)},
+		"Cauchy":       {math.Inf(-1), math.Inf(1)},
+		"StudentT":     {math.Inf(-1), math.Inf(1)},
+		"Gamma":        {0, math.Inf(1)},
+		"Beta":         {0, 1},
+		"Contaminated": {math.Inf(-1), math.Inf(1)},
+	}
+	for name, want := range endpoints {
+		d := fullDistributions[name]
+		if lo, hi := d.Quantile(0), d.Quantile(1); lo != want[0] || hi != want[1] {
+			t.Errorf("%s: Quantile(0), Quantile(1) = %v, %v, want %v, %v", name, lo, hi, want[0], want[1])
+		}
+	}
+}
+
+func TestTryNewParameterErrors(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+	cases := []struct {
+		distribution, parameter string
+		try                     func() error
+		panicking               func()
+	}{
+		{"Uniform", "min", func() error { _, err := TryNewUniform(1, 1); return err }, func() { NewUniform(1, 1) }},
+		{"Uniform", "min", func() error { _, err := TryNewUniform(nan, 1); return err }, func() { NewUniform(nan, 1) }},
+		{"Additive", "stdDev", func() error { _, err := TryNewAdditive(0, 0); return err }, func() { NewAdditive(0, 0) }},
+		{"Multiplic", "logStdDev", func() error { _, err := TryNewMultiplic(0, -1); return err }, func() { NewMultiplic(0, -1) }},
+		{"Exp", "rate", func() error { _, err := TryNewExp(nan); return err }, func() { NewExp(nan) }},
+		{"Power", "min", func() error { _, err := TryNewPower(0, 1); return err }, func() { NewPower(0, 1) }},
+		{"Power", "shape", func() error { _, err := TryNewPower(1, -2); return err }, func() { NewPower(1, -2) }},
+		{"Cauchy", "scale", func() error { _, err := TryNewCauchy(0, 0); return err }, func() { NewCauchy(0, 0) }},
+		{"StudentT", "df", func() error { _, err := TryNewStudentT(0, 0, 1); return err }, func() { NewStudentT(0, 0, 1) }},
+		{"StudentT", "scale", func() error { _, err := TryNewStudentT(3, 0, 0); return err }, func() { NewStudentT(3, 0, 0) }},
+		{"Gamma", "shape", func() error { _, err := TryNewGamma(0, 1); return err }, func() { NewGamma(0, 1) }},
+		{"Gamma", "rate", func() error { _, err := TryNewGamma(1, -1); return err }, func() { NewGamma(1, -1) }},
+		{"Beta", "alpha", func() error { _, err := TryNewBeta(0, 1); return err }, func() { NewBeta(0, 1) }},
+		{"Beta", "beta", func() error { _, err := TryNewBeta(1, nan); return err }, func() { NewBeta(1, nan) }},
+		{"Contaminated", "contamination", func() error { _, err := TryNewContaminated(0, 1, 1, 10); return err }, func() { NewContaminated(0, 1, 1, 10) }},
+		{"Contaminated", "outlierScale", func() error { _, err := TryNewContaminated(0, 1, 0.1, 1); return err }, func() { NewContaminated(0, 1, 0.1, 1) }},
+		{"Uniform", "min", func() error { _, err := TryNewUniform(inf, 1); return err }, func() { NewUniform(inf, 1) }},
+		{"Uniform", "max", func() error { _, err := TryNewUniform(0, inf); return err }, func() { NewUniform(0, inf) }},
+		{"Uniform", "max", func() error { _, err := TryNewUniform(0, nan); return err }, func() { NewUniform(0, nan) }},
+		{"Additive", "mean", func() error { _, err := TryNewAdditive(nan, 1); return err }, func() { NewAdditive(nan, 1) }},
+		{"Additive", "mean", func() error { _, err := TryNewAdditive(-inf, 1); return err }, func() { NewAdditive(-inf, 1) }},
+		{"Additive", "stdDev", func() error { _, err := TryNewAdditive(0, inf); return err }, func() { NewAdditive(0, inf) }},
+		{"Additive", "stdDev", func() error { _, err := TryNewAdditive(0, nan); return err }, func() { NewAdditive(0, nan) }},
+		{"Multiplic", "logMean", func() error { _, err := TryNewMultiplic(inf, 1); return err }, func() { NewMultiplic(inf, 1) }},
+		{"Multiplic", "logStdDev", func() error { _, err := TryNewMultiplic(0, inf); return err }, func() { NewMultiplic(0, inf) }},
+		{"Exp", "rate", func() error { _, err := TryNewExp(0); return err }, func() { NewExp(0) }},
+		{"Exp", "rate", func() error { _, err := TryNewExp(inf); return err }, func() { NewExp(inf) }},
+		{"Power", "min", func() error { _, err := TryNewPower(inf, 1); return err }, func() { NewPower(inf, 1) }},
+		{"Power", "shape", func() error { _, err := TryNewPower(1, inf); return err }, func() { NewPower(1, inf) }},
+		{"Power", "shape", func() error { _, err := TryNewPower(1, nan); return err }, func() { NewPower(1, nan) }},
+		{"Cauchy", "scale", func() error { _, err := TryNewCauchy(0, nan); return err }, func() { NewCauchy(0, nan) }},
+		{"Bernoulli", "p", func() error { _, err := TryNewBernoulli(1.5); return err }, func() { NewBernoulli(1.5) }},
+		{"Bernoulli", "p", func() error { _, err := TryNewBernoulli(nan); return err }, func() { NewBernoulli(nan) }},
+		{"Binomial", "n", func() error { _, err := TryNewBinomial(-1, 0.5); return err }, func() { NewBinomial(-1, 0.5) }},
+		{"Binomial", "p", func() error { _, err := TryNewBinomial(3, -0.1); return err }, func() { NewBinomial(3, -0.1) }},
+		{"Affine", "inner", func() error { _, err := TryNewAffine(nil, 1, 0); return err }, func() { NewAffine(nil, 1, 0) }},
+		{"Affine", "scale", func() error { _, err := TryNewAffine(NewExp(1), 0, 0); return err }, func() { NewAffine(NewExp(1), 0, 0) }},
+		{"Affine", "offset", func() error { _, err := TryNewAffine(NewExp(1), 1, nan); return err }, func() { NewAffine(NewExp(1), 1, nan) }},
+		{"CorrelatedPair", "xDist", func() error { _, err := TryNewCorrelatedPair(nil, NewExp(1), 0); return err }, func() { NewCorrelatedPair(nil, NewExp(1), 0) }},
+		{"CorrelatedPair", "yDist", func() error { _, err := TryNewCorrelatedPair(NewExp(1), nil, 0); return err }, func() { NewCorrelatedPair(NewExp(1), nil, 0) }},
+		{"CorrelatedPair", "rho", func() error { _, err := TryNewCorrelatedPair(NewExp(1), NewExp(1), 1); return err }, func() { NewCorrelatedPair(NewExp(1), NewExp(1), 1) }},
+		{"CorrelatedPair", "rho", func() error { _, err := TryNewCorrelatedPair(NewExp(1), NewExp(1), nan); return err }, func() { NewCorrelatedPair(NewExp(1), NewExp(1), nan) }},
+		{"SumOf", "inner", func() error { _, err := TryNewSumOf(nil, 2); return err }, func() { NewSumOf(nil, 2) }},
+		{"SumOf", "k", func() error { _, err := TryNewSumOf(NewExp(1), 0); return err }, func() { NewSumOf(NewExp(1), 0) }},
+		{"OrderStat", "inner", func() error { _, err := TryNewOrderStat(nil, 2, 1); return err }, func() { NewOrderStat(nil, 2, 1) }},
+		{"OrderStat", "k", func() error { _, err := TryNewOrderStat(NewExp(1), 0, 1); return err }, func() { NewOrderStat(NewExp(1), 0, 1) }},
+		{"OrderStat", "rank", func() error { _, err := TryNewOrderStat(NewExp(1), 3, 0); return err }, func() { NewOrderStat(NewExp(1), 3, 0) }},
+		{"OrderStat", "rank", func() error { _, err := TryNewOrderStat(NewExp(1), 3, 4); return err }, func() { NewOrderStat(NewExp(1), 3, 4) }},
+		{"Contaminated", "mean", func() error { _, err := TryNewContaminated(nan, 1, 0.1, 10); return err }, func() { NewContaminated(nan, 1, 0.1, 10) }},
+		{"Contaminated", "stdDev", func() error { _, err := TryNewContaminated(0, inf, 0.1, 10); return err }, func() { NewContaminated(0, inf, 0.1, 10) }},
+		{"Contaminated", "outlierScale", func() error { _, err := TryNewContaminated(0, 1e300, 0.1, 1e10); return err }, func() { NewContaminated(0, 1e300, 0.1, 1e10) }},
+	}
+	for _, c := range cases {
+		err := c.try()
+		pe, ok := err.(*ParameterError)
+		if !ok {
+			t.Errorf("%s.%s: err = %v (%T), want *ParameterError", c.distribution, c.parameter, err, err)
+			continue
+		}
+		if pe.Distribution != c.distribution || pe.Parameter != c.parameter {
+			t.Errorf("got %s.%s, want %s.%s", pe.Distribution, pe.Parameter, c.distribution, c.parameter)
+		}
+		// The same error reads as a domain violation of the parameter.
+		var ae *AssumptionError
+		if !errors.As(err, &ae) || ae.Violation != (Violation{ID: Domain, Subject: Subject(c.parameter)}) {
+			t.Errorf("%s.%s: errors.As AssumptionError = %v, want domain(%s)", c.distribution, c.parameter, ae, c.parameter)
+		}
+		func() {
+			defer func() {
+				if r := recover(); r != pe.Message {
+					t.Errorf("%s.%s: New panicked with %v, want %q", c.distribution, c.parameter, r, pe.Message)
+				}
+			}()
+			c.panicking()
+		}()
+	}
+
+	if d, err := TryNewPower(1, 2); err != nil || d.Min != 1 || d.Shape != 2 {
+		t.Errorf("TryNewPower(1, 2) = %v, %v", d, err)
+	}
+	if d, err := TryNewMultiplic(0, 1); err != nil || d.Sample(NewRngFromSeed(1)) <= 0 {
+		t.Errorf("TryNewMultiplic(0, 1) = %v, %v", d, err)
+	}
+}
+
+func TestSamplesIntoMatchesSamples(t *testing.T) {
+	buf := make([]float64, 64)
+	for name, d := range jsonDistributions(t) {
+		want := d.Samples(NewRngFromString("into-"+name), len(buf))
+		SamplesInto(d, NewRngFromString("into-"+name), buf)
+		for i := range want {
+			if buf[i] != want[i] {
+				t.Errorf("%s: draw %d = %v, want %v", name, i, buf[i], want[i])
+				break
+			}
+		}
+		rng := NewRngFromString("into-allocs")
+		if allocs := testing.AllocsPerRun(20, func() { SamplesInto(d, rng, buf) }); allocs != 0 {
+			t.Errorf("%s: SamplesInto allocated %v times per call", name, allocs)
+		}
+	}
+
+	// Distributions without the method fall back to repeated Sample.
+	want := customDistribution{}.Samples(NewRngFromSeed(3), 5)
+	got := make([]float64, 5)
+	SamplesInto(customDistribution{}, NewRngFromSeed(3), got)
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("custom: draw %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func BenchmarkSamples(b *testing.B) {
+	d := NewAdditive(0, 1)
+	rng := NewRngFromSeed(1)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		d.Samples(rng, 1000)
+	}
+}
+
+func BenchmarkSamplesInto(b *testing.B) {
+	d := NewAdditive(0, 1)
+	rng := NewRngFromSeed(1)
+	buf := make([]float64, 1000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		d.SamplesInto(rng, buf)
+	}
+}
+
+// TestDrawsPerSample checks the reported counts and that every fixed count
+// matches how far one Sample advances the generator state.
+func TestDrawsPerSample(t *testing.T) {
+	distributions := jsonDistributions(t)
+	distributions["BinomialDegenerate"] = NewBinomial(5, 1)
+	distributions["BinomialBtrs"] = NewBinomial(100, 0.5)
+	distributions["SumOfAdditive"] = NewSumOf(NewAdditive(0, 1), 3)
+	mixture, err := NewMixture([]Distribution{NewExp(1), NewUniform(0, 1)}, []float64{1, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	distributions["MixtureFixed"] = mixture
+
+	for name, want := range map[string]int{
+		"Uniform": 1, "UniformClosed": 1, "UniformOpen": VariableDraws,
+		"Additive": 2, "AdditiveOpen": VariableDraws, "Multiplic": 2,
+		"Exp": 1, "ExpOpen": VariableDraws, "Power": 1, "Cauchy": 1,
+		"StudentT": VariableDraws, "Gamma": VariableDraws, "Beta": VariableDraws,
+		"Bernoulli": 1, "Binomial": 1, "BinomialDegenerate": 0, "BinomialBtrs": VariableDraws,
+		"Contaminated": 3, "Discrete": 2, "Histogram": 3, "Empirical": 1, "EmpiricalWeighted": 2,
+		"Mixture": VariableDraws, "MixtureFixed": 2, "Affine": VariableDraws,
+		"SumOf": 3, "SumOfAdditive": 6, "OrderStat": VariableDraws,
+	} {
+		if got := DrawsPerSample(distributions[name]); got != want {
+			t.Errorf("%s: DrawsPerSample = %d, want %d", name, got, want)
+		}
+	}
+	if got := DrawsPerSample(customDistribution{}); got != VariableDraws {
+		t.Errorf("custom: DrawsPerSample = %d, want VariableDraws", got)
+	}
+
+	for name, d := range distributions {
+		draws := DrawsPerSample(d)
+		if draws == VariableDraws {
+			continue
+		}
+		rng := NewRngFromString("draws-" + name)
+		ref := NewRngFromString("draws-" + name)
+		for i := 0; i < 100; i++ {
+			d.Sample(rng)
+			for j := 0; j < draws; j++ {
+				ref.inner.nextU64()
+			}
+			if rng.State() != ref.State() {
+				t.Errorf("%s: sample %d did not consume exactly %d outputs", name, i, draws)
+				break
+			}
+		}
+	}
+}
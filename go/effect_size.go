@@ -0,0 +1,47 @@
+package pragmastat
+
+import "math"
+
+// EffectSizeThresholds holds the cutoffs on |Disparity| that separate the
+// qualitative effect-size labels: values below Small are "negligible", values
+// in [Small, Medium) are "small", in [Medium, Large) are "medium", and values
+// at or above Large are "large".
+type EffectSizeThresholds struct {
+	Small  float64
+	Medium float64
+	Large  float64
+}
+
+// DefaultEffectSizeThresholds are Cohen's conventional 0.2/0.5/0.8 cutoffs.
+//
+// Disparity is a robust analog of Cohen's d: for normal data Spread ≈ 0.954σ,
+// so Disparity ≈ 1.048·d and the defaults correspond to d ≈ 0.19/0.48/0.76.
+// The difference is well within the resolution of Cohen's conventions, so the
+// familiar cutoffs are kept unchanged; use ClassifyDisparityWith for
+// domain-specific calibration.
+var DefaultEffectSizeThresholds = EffectSizeThresholds{Small: 0.2, Medium: 0.5, Large: 0.8}
+
+// ClassifyDisparity returns a qualitative label ("negligible", "small",
+// "medium", or "large") for a Disparity value using DefaultEffectSizeThresholds.
+// The sign is ignored: the label describes the magnitude |d|.
+func ClassifyDisparity(d float64) string {
+	return ClassifyDisparityWith(d, DefaultEffectSizeThresholds)
+}
+
+// ClassifyDisparityWith returns a qualitative label for |d| using the given
+// thresholds. NaN yields "undefined".
+func ClassifyDisparityWith(d float64, thresholds EffectSizeThresholds) string {
+	abs := math.Abs(d)
+	switch {
+	case math.IsNaN(abs):
+		return "undefined"
+	case abs >= thresholds.Large:
+		return "large"
+	case abs >= thresholds.Medium:
+		return "medium"
+	case abs >= thresholds.Small:
+		return "small"
+	default:
+		return "negligible"
+	}
+}
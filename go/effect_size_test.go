@@ -0,0 +1,46 @@
+package pragmastat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestClassifyDisparity(t *testing.T) {
+	cases := []struct {
+		d    float64
+		want string
+	}{
+		{0, "negligible"},
+		{0.19999, "negligible"},
+		{0.2, "small"},
+		{0.49999, "small"},
+		{0.5, "medium"},
+		{0.79999, "medium"},
+		{0.8, "large"},
+		{3, "large"},
+		{-0.2, "small"},
+		{-0.5, "medium"},
+		{-0.8, "large"},
+		{-0.1, "negligible"},
+		{math.Inf(-1), "large"},
+		{math.NaN(), "undefined"},
+	}
+	for _, c := range cases {
+		if got := ClassifyDisparity(c.d); got != c.want {
+			t.Errorf("ClassifyDisparity(%v) = %q, want %q", c.d, got, c.want)
+		}
+	}
+}
+
+func TestClassifyDisparityWithCustomThresholds(t *testing.T) {
+	thresholds := EffectSizeThresholds{Small: 0.1, Medium: 0.3, Large: 1.2}
+	if got := ClassifyDisparityWith(0.1, thresholds); got != "small" {
+		t.Errorf("got %q, want small", got)
+	}
+	if got := ClassifyDisparityWith(-1.0, thresholds); got != "medium" {
+		t.Errorf("got %q, want medium", got)
+	}
+	if got := ClassifyDisparityWith(1.2, thresholds); got != "large" {
+		t.Errorf("got %q, want large", got)
+	}
+}
@@ -0,0 +1,111 @@
+package pragmastat
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+)
+
+// Empirical is the distribution of a sample's values: a reusable,
+// unit-aware bootstrap sampler. Unweighted samples draw every value with
+// equal probability; weighted samples draw proportionally to weight.
+type Empirical struct {
+	sample *Sample
+	sorted []float64
+	cum    []float64 // running sums of the sorted weights; nil if unweighted
+
+	aliasOnce sync.Once
+	alias     *AliasTable
+}
+
+// NewEmpirical creates the empirical distribution of s. The values are
+// sorted once here; s itself is not modified.
+func NewEmpirical(s *Sample) (*Empirical, error) {
+	if s == nil {
+		return nil, fmt.Errorf("sample cannot be nil")
+	}
+	e := &Empirical{sample: s, sorted: s.cachedSortedValues()}
+	if s.isWeighted {
+		weights := s.sortCache.weights
+		e.cum = make([]float64, len(weights))
+		total := 0.0
+		for i, w := range weights {
+			total += w
+			e.cum[i] = total
+		}
+		if math.IsNaN(total) || math.IsInf(total, 0) {
+			return nil, fmt.Errorf("weights must be finite")
+		}
+	}
+	return e, nil
+}
+
+// Unit returns the measurement unit of the underlying sample.
+func (e *Empirical) Unit() *MeasurementUnit { return e.sample.unit }
+
+// Sample draws one value. Unweighted samples consume one index draw exactly
+// like RngResample on Sample.Values, so Samples(rng, k) equals
+// ResampleSlice(values, k) for the same seed. Weighted samples draw from an
+// alias table built on first use, consuming an index and a uniform per draw.
+func (e *Empirical) Sample(rng *Rng) float64 {
+	s := e.sample
+	if !s.isWeighted {
+		return s.values[rng.index(len(s.values))]
+	}
+	e.aliasOnce.Do(func() {
+		// Weights were validated by the Sample constructor and NewEmpirical.
+		e.alias, _ = NewAliasTable(s.weights)
+	})
+	return s.values[e.alias.Draw(rng)]
+}
+
+// Samples generates multiple samples from the empirical distribution.
+func (e *Empirical) Samples(rng *Rng, count int) []float64 {
+	return sampleN(e, rng, count)
+}
+
+// SamplesInto fills dst with samples from the empirical distribution without allocating.
+func (e *Empirical) SamplesInto(rng *Rng, dst []float64) {
+	fillSamples(e, rng, dst)
+}
+
+// DrawsPerSample returns 2 for a weighted sample (alias table) and 1 for an
+// unweighted one. The unweighted count assumes the default index draws;
+// after rng.SetUnbiased(true) a draw may consume more.
+func (e *Empirical) DrawsPerSample() int {
+	if e.sample.isWeighted {
+		return 2
+	}
+	return 1
+}
+
+// Cdf returns the fraction of values (or of the total weight) at or below x,
+// found by binary search over the sorted values.
+func (e *Empirical) Cdf(x float64) float64 {
+	k := sort.Search(len(e.sorted), func(i int) bool { return e.sorted[i] > x })
+	if k == 0 {
+		return 0
+	}
+	if e.cum == nil {
+		return float64(k) / float64(len(e.sorted))
+	}
+	return math.Min(e.cum[k-1]/e.cum[len(e.cum)-1], 1)
+}
+
+// Quantile returns the p-quantile, the same value as Sample.Quantile on the
+// underlying sample. Unweighted samples use type-7 linear interpolation
+// between order statistics, so Quantile(i/(n-1)) is the i-th smallest value;
+// weighted samples interpolate between the weighted plotting positions of
+// Sample.Quantile, which is continuous in p rather than the step inverse of
+// Cdf. Quantile(0) and Quantile(1) are the smallest and largest values (with
+// positive weight), and p outside [0, 1] or NaN gives NaN.
+func (e *Empirical) Quantile(p float64) float64 {
+	if invalidProbability(p) {
+		return math.NaN()
+	}
+	if e.cum == nil {
+		return quantileSorted(e.sorted, p)
+	}
+	return weightedQuantileSorted(e.sorted, e.sample.sortCache.weights, p)
+}
@@ -0,0 +1,104 @@
+package pragmastat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEmpiricalResampling(t *testing.T) {
+	values := NewAdditive(10, 2).Samples(NewRngFromString("empirical-source"), 2000)
+	s := mustSampleOf(values)
+	e, err := NewEmpirical(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Unweighted draws match RngResample on the same stream.
+	got := e.Samples(NewRngFromString("empirical-draws"), 100)
+	want := NewRngFromString("empirical-draws").ResampleSlice(values, 100)
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("draw %d: got %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	resampled := e.Samples(NewRngFromString("empirical-large"), 20000)
+	for _, c := range []struct {
+		name      string
+		estimator func([]float64, bool) (float64, error)
+	}{{"Center", Center}, {"Spread", Spread}} {
+		original, _ := c.estimator(values, false)
+		again, _ := c.estimator(resampled, false)
+		if math.Abs(again-original) > 0.05 {
+			t.Errorf("%s: resampled %v, original %v", c.name, again, original)
+		}
+	}
+}
+
+func TestEmpiricalWeighted(t *testing.T) {
+	s, err := NewWeightedSample([]float64{3, 1, 2, 4}, []float64{0.5, 0.2, 0.3, 0}, testSecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := NewEmpirical(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Unit() != testSecond {
+		t.Errorf("Unit = %v, want testSecond", e.Unit())
+	}
+
+	const n = 100_000
+	counts := map[float64]float64{}
+	for _, x := range e.Samples(NewRngFromString("empirical-weighted"), n) {
+		counts[x]++
+	}
+	for value, p := range map[float64]float64{1: 0.2, 2: 0.3, 3: 0.5, 4: 0} {
+		if got := counts[value] / n; math.Abs(got-p) > 5*math.Sqrt(p*(1-p)/n) {
+			t.Errorf("frequency of %v = %v, want %v", value, got, p)
+		}
+	}
+
+	for _, c := range []struct{ x, cdf float64 }{{0.5, 0}, {1, 0.2}, {2.5, 0.5}, {3, 1}, {10, 1}} {
+		if got := e.Cdf(c.x); !floatEquals(got, c.cdf, 1e-15) {
+			t.Errorf("Cdf(%v) = %v, want %v", c.x, got, c.cdf)
+		}
+	}
+	// Sample.Quantile's plotting positions: 1, 2, 3 at 0, 0.25, 0.65 of 0.65.
+	for _, c := range []struct{ p, q float64 }{{0, 1}, {0.2, 1.52}, {0.5, 2.1875}, {0.9, 2.8375}, {1, 3}} {
+		if got := e.Quantile(c.p); !floatEquals(got, c.q, 1e-12) {
+			t.Errorf("Quantile(%v) = %v, want %v", c.p, got, c.q)
+		}
+		if m, _ := s.Quantile(c.p); m.Value != e.Quantile(c.p) {
+			t.Errorf("Quantile(%v): Sample %v, Empirical %v", c.p, m.Value, e.Quantile(c.p))
+		}
+	}
+}
+
+func TestEmpiricalCdfQuantile(t *testing.T) {
+	values := NewExp(1).Samples(NewRngFromString("empirical-cdf"), 200)
+	e, err := NewEmpirical(mustSampleOf(values))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sorted := mustSampleOf(values).SortedValues()
+	n := len(sorted)
+	for i, v := range sorted {
+		if got := e.Quantile(float64(i) / float64(n-1)); !floatEquals(got, v, 1e-12) {
+			t.Errorf("Quantile(%d/%d) = %v, want %v", i, n-1, got, v)
+		}
+		if got, want := e.Cdf(v), float64(i+1)/float64(n); got != want {
+			t.Errorf("Cdf(%v) = %v, want %v", v, got, want)
+		}
+	}
+	if got, want := e.Quantile(0.5), 0.5*sorted[99]+0.5*sorted[100]; !floatEquals(got, want, 1e-12) {
+		t.Errorf("Quantile(0.5) = %v, want %v", got, want)
+	}
+	if e.Cdf(sorted[0]-1) != 0 || !math.IsNaN(e.Quantile(-0.1)) || !math.IsNaN(e.Quantile(math.NaN())) {
+		t.Error("expected Cdf 0 below the minimum and NaN quantiles outside [0, 1]")
+	}
+
+	if _, err := NewEmpirical(nil); err == nil {
+		t.Error("expected an error for a nil sample")
+	}
+}
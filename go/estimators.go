@@ -2,6 +2,8 @@
 package pragmastat
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math"
 	"sort"
@@ -23,11 +25,24 @@ type Bounds struct {
 	Unit  *MeasurementUnit
 }
 
-// Contains returns true if value is within [Lower, Upper].
+// Contains returns true if value is within [Lower, Upper]. Infinite bounds
+// are closed too: [-Inf, Inf] contains every non-NaN value, and a degenerate
+// [x, x] contains only x. NaN is never contained.
 func (b Bounds) Contains(value float64) bool {
 	return b.Lower <= value && value <= b.Upper
 }
 
+// Width returns Upper - Lower (+Inf if either bound is infinite).
+func (b Bounds) Width() float64 {
+	return b.Upper - b.Lower
+}
+
+// Midpoint returns the center of the interval, halving before summing so it
+// cannot overflow. It is NaN for [-Inf, Inf] and infinite when one bound is.
+func (b Bounds) Midpoint() float64 {
+	return 0.5*b.Lower + 0.5*b.Upper
+}
+
 func (b Bounds) String() string {
 	if b.Unit != nil && len(b.Unit.Abbreviation) > 0 {
 		return fmt.Sprintf("[%v;%v] %s", b.Lower, b.Upper, b.Unit.Abbreviation)
@@ -63,12 +78,13 @@ func (b Bounds) String() string {
 
 // checkValidity returns a validity error if the slice is empty or contains any
 // NaN or infinite value.
-func checkValidity(x []float64, subject Subject) error {
+func checkValidity[T Number](x []T, subject Subject) error {
 	if len(x) == 0 {
 		return NewValidityError(subject)
 	}
 	for _, v := range x {
-		if math.IsNaN(v) || math.IsInf(v, 0) {
+		fv := float64(v)
+		if math.IsNaN(fv) || math.IsInf(fv, 0) {
 			return NewValidityError(subject)
 		}
 	}
@@ -81,10 +97,35 @@ func checkValidity(x []float64, subject Subject) error {
 // If assumeSorted is true, x is assumed already sorted ascending and the
 // internal sort is skipped (undefined behavior on unsorted input).
 func Center(x []float64, assumeSorted bool) (float64, error) {
+	return CenterCtx(context.Background(), x, assumeSorted)
+}
+
+// CenterCtx is Center with cancellation: if ctx is done before the selection
+// converges, it returns a *CancelledError wrapping ctx.Err().
+func CenterCtx(ctx context.Context, x []float64, assumeSorted bool) (float64, error) {
 	if err := checkValidity(x, SubjectX); err != nil {
 		return 0, err
 	}
-	return centerImpl(x, assumeSorted)
+	return centerImpl(ctx, x, assumeSorted)
+}
+
+// CountPairwiseAveragesLE counts the pairwise (Walsh) averages
+// (x[i] + x[j])/2 with i <= j that are <= target, out of n(n+1)/2. It is
+// the counting primitive behind Center and CenterBounds and runs in O(n)
+// on sorted data (O(n log n) with the sort). Dividing by n(n+1)/2 gives the
+// fraction of Walsh averages at or below target, e.g. for sign-type tests
+// on the center with target 0.
+//
+// If assumeSorted is true, x is assumed already sorted ascending and the
+// internal sort is skipped (undefined behavior on unsorted input).
+func CountPairwiseAveragesLE(x []float64, target float64, assumeSorted bool) (int64, error) {
+	if err := checkValidity(x, SubjectX); err != nil {
+		return 0, err
+	}
+	if math.IsNaN(target) {
+		return 0, fmt.Errorf("target must not be NaN")
+	}
+	return centerCountPairsLessOrEqualImpl(sortedOne(x, assumeSorted), target), nil
 }
 
 // Spread estimates data dispersion (variability or scatter).
@@ -109,19 +150,184 @@ func Spread(x []float64, assumeSorted bool) (float64, error) {
 	return spreadVal, nil
 }
 
+// RelSpread measures the relative dispersion of the data: Spread / |Center|.
+// RelSpread is undefined when Center equals zero; that case is reported as a
+// domain(x) assumption violation.
+//
+// Assumptions:
+//   - domain(x) - Center must be non-zero
+//
+// Sparity is NOT required: a tie-dominant sample has Spread 0 and therefore
+// RelSpread 0 (e.g. {5, 5, 5}); only the bounds estimators need Spread > 0.
+//
+// If assumeSorted is true, x is assumed already sorted ascending and the
+// internal sort is skipped (undefined behavior on unsorted input).
+func RelSpread(x []float64, assumeSorted bool) (float64, error) {
+	if err := checkValidity(x, SubjectX); err != nil {
+		return 0, err
+	}
+	sorted := sortedOne(x, assumeSorted)
+	if relSpread, ok, err := relSpreadConstant(sorted); ok {
+		return relSpread, err
+	}
+	centerVal, err := centerImpl(context.Background(), sorted, true)
+	if err != nil {
+		return 0, err
+	}
+	if centerVal == 0 {
+		return 0, NewDomainError(SubjectX)
+	}
+	spreadVal, err := spreadImpl(sorted, true)
+	if err != nil {
+		return 0, err
+	}
+	return spreadVal / math.Abs(centerVal), nil
+}
+
+// relSpreadConstant is the all-equal fast path shared by RelSpread and
+// RelSpreadWith: for sorted input whose first and last values are equal,
+// every location estimate is that value and Spread is 0. It reports ok and
+// returns 0, or a domain(x) error when the value is zero.
+func relSpreadConstant(sorted []float64) (float64, bool, error) {
+	if sorted[0] != sorted[len(sorted)-1] {
+		return 0, false, nil
+	}
+	if sorted[0] == 0 {
+		return 0, true, NewDomainError(SubjectX)
+	}
+	return 0, true, nil
+}
+
+// RelSpreadDenominator selects the location estimate RelSpreadWith divides by.
+type RelSpreadDenominator int
+
+const (
+	// RelSpreadCenter divides by |Center|, as RelSpread does.
+	RelSpreadCenter RelSpreadDenominator = iota
+	// RelSpreadMedian divides by |Median|, the classical choice for a
+	// coefficient-of-variation-style ratio.
+	RelSpreadMedian
+)
+
+// String returns the string representation of the denominator.
+func (d RelSpreadDenominator) String() string {
+	switch d {
+	case RelSpreadCenter:
+		return "center"
+	case RelSpreadMedian:
+		return "median"
+	default:
+		return fmt.Sprintf("RelSpreadDenominator(%d)", int(d))
+	}
+}
+
+// RelSpreadWith is RelSpread with a selectable denominator: Spread / |Center|
+// for RelSpreadCenter (identical to RelSpread) or Spread / |Median| for
+// RelSpreadMedian.
+//
+// Assumptions:
+//   - domain(x) - the chosen denominator must be non-zero
+//
+// As for RelSpread, a tie-dominant sample gives 0 rather than an error.
+//
+// Returns a plain error for an unknown denominator. If assumeSorted is true,
+// x is assumed already sorted ascending and the internal sort is skipped
+// (undefined behavior on unsorted input).
+func RelSpreadWith(x []float64, denom RelSpreadDenominator, assumeSorted bool) (float64, error) {
+	switch denom {
+	case RelSpreadCenter:
+		return RelSpread(x, assumeSorted)
+	case RelSpreadMedian:
+	default:
+		return 0, fmt.Errorf("unknown RelSpread denominator %v", denom)
+	}
+	if err := checkValidity(x, SubjectX); err != nil {
+		return 0, err
+	}
+	sorted := sortedOne(x, assumeSorted)
+	if relSpread, ok, err := relSpreadConstant(sorted); ok {
+		return relSpread, err
+	}
+	medianVal := medianImpl(sorted)
+	if medianVal == 0 {
+		return 0, NewDomainError(SubjectX)
+	}
+	spreadVal, err := spreadImpl(sorted, true)
+	if err != nil {
+		return 0, err
+	}
+	return spreadVal / math.Abs(medianVal), nil
+}
+
+// CenterCompressed computes Center for heavily tied data.
+// The sample is run-length encoded into distinct values with multiplicities
+// and the pairwise-average median is selected over those pairs, so the cost
+// after encoding depends on the number of distinct values rather than on n.
+// The result is identical to Center.
+//
+// If assumeSorted is true, x is assumed already sorted ascending and is
+// encoded in a single pass (undefined behavior on unsorted input).
+func CenterCompressed(x []float64, assumeSorted bool) (float64, error) {
+	if err := checkValidity(x, SubjectX); err != nil {
+		return 0, err
+	}
+	return centerCompressedImpl(newRunLengths(x, assumeSorted))
+}
+
+// SpreadCompressed computes Spread for heavily tied data.
+// Uses the same run-length encoding as CenterCompressed; the result is
+// identical to Spread.
+//
+// Assumptions:
+//   - sparity(x) - sample must be non tie-dominant (Spread > 0)
+//
+// If assumeSorted is true, x is assumed already sorted ascending and is
+// encoded in a single pass (undefined behavior on unsorted input).
+func SpreadCompressed(x []float64, assumeSorted bool) (float64, error) {
+	if err := checkValidity(x, SubjectX); err != nil {
+		return 0, err
+	}
+	spreadVal, err := spreadCompressedImpl(newRunLengths(x, assumeSorted))
+	if err != nil {
+		return 0, err
+	}
+	if spreadVal <= 0 {
+		return 0, NewSparityError(SubjectX)
+	}
+	return spreadVal, nil
+}
+
 // Shift measures the typical difference between elements of x and y.
 // Calculates the median of all pairwise differences (x[i] - y[j]).
 //
 // If assumeSorted is true, both x and y are assumed already sorted ascending
 // and the internal sort is skipped (undefined behavior on unsorted input).
 func Shift(x, y []float64, assumeSorted bool) (float64, error) {
-	if err := checkValidity(x, SubjectX); err != nil {
+	if err := checkTwoSample(x, y, false); err != nil {
 		return 0, err
 	}
-	if err := checkValidity(y, SubjectY); err != nil {
+	result, err := shiftQuantilesImpl(context.Background(), x, y, []float64{0.5}, assumeSorted)
+	if err != nil {
+		return 0, err
+	}
+	return result[0], nil
+}
+
+// ShiftWithPrecision is Shift with an explicit cap on the binary-search steps
+// used to select each pairwise difference (Shift uses 128). Integer or
+// low-precision data needs far fewer steps; adversarial floating-point data
+// may need more. Any budget that converges gives exactly the Shift result; a
+// budget too small to isolate the median difference returns an error
+// wrapping ErrShiftNotConverged instead of an approximate value. Returns an
+// error if maxIter < 1.
+func ShiftWithPrecision(x, y []float64, maxIter int, assumeSorted bool) (float64, error) {
+	if err := checkTwoSample(x, y, false); err != nil {
 		return 0, err
 	}
-	result, err := shiftQuantilesImpl(x, y, []float64{0.5}, assumeSorted)
+	if maxIter < 1 {
+		return 0, fmt.Errorf("maxIter must be positive, got %d", maxIter)
+	}
+	result, err := shiftQuantilesWithIterations(context.Background(), x, y, []float64{0.5}, assumeSorted, maxIter)
 	if err != nil {
 		return 0, err
 	}
@@ -138,57 +344,57 @@ func Shift(x, y []float64, assumeSorted bool) (float64, error) {
 // If assumeSorted is true, both x and y are assumed already sorted ascending
 // and the internal sort is skipped (undefined behavior on unsorted input).
 func Ratio(x, y []float64, assumeSorted bool) (float64, error) {
-	if err := checkValidity(x, SubjectX); err != nil {
+	if err := checkTwoSample(x, y, true); err != nil {
 		return 0, err
 	}
-	if err := checkValidity(y, SubjectY); err != nil {
+	result, err := ratioQuantilesImpl(x, y, []float64{0.5}, assumeSorted)
+	if err != nil {
 		return 0, err
 	}
-	for _, v := range x {
-		if v <= 0 {
-			return 0, NewPositivityError(SubjectX)
-		}
+	return result[0], nil
+}
+
+// SignedRatio computes the median of all pairwise ratios (x[i] / y[j]) for
+// signed data. Unlike Ratio, y may contain negative values as long as none is
+// exactly zero, and x may take any sign.
+//
+// Interpretation caveats: SignedRatio is a plain median of quotients, not a
+// multiplicative effect. A negative y[j] flips the sign of every ratio in its
+// column, so when y straddles zero the estimate mixes opposite-signed ratios
+// and can land anywhere between them; y values near zero produce very large
+// ratios. It is not log-symmetric (SignedRatio(x, y) generally differs from
+// 1 / SignedRatio(y, x)) and has no bounds counterpart. Prefer Ratio whenever
+// both samples are strictly positive.
+//
+// Assumptions:
+//   - domain(y) - no value in y may be exactly zero
+func SignedRatio(x, y []float64) (float64, error) {
+	if err := checkTwoSample(x, y, false); err != nil {
+		return 0, err
 	}
 	for _, v := range y {
-		if v <= 0 {
-			return 0, NewPositivityError(SubjectY)
+		if v == 0 {
+			return 0, NewDomainError(SubjectY)
 		}
 	}
-	result, err := ratioQuantilesImpl(x, y, []float64{0.5}, assumeSorted)
-	if err != nil {
-		return 0, err
-	}
-	return result[0], nil
+	return signedRatioImpl(x, y)
 }
 
 // avgSpread measures the typical variability when considering both samples together.
 // Internal estimator backing the Sample-based avgSpread method. Operates on raw
 // slices. Disparity does not call this; it inlines the equivalent computation.
 func avgSpread(x, y []float64, assumeSorted bool) (float64, error) {
-	if err := checkValidity(x, SubjectX); err != nil {
-		return 0, err
-	}
-	if err := checkValidity(y, SubjectY); err != nil {
+	if err := checkTwoSample(x, y, false); err != nil {
 		return 0, err
 	}
 
 	n := float64(len(x))
 	m := float64(len(y))
 
-	spreadX, err := spreadImpl(x, assumeSorted)
+	spreadX, spreadY, err := checkSparityPair(x, y, assumeSorted)
 	if err != nil {
 		return 0, err
 	}
-	if spreadX <= 0 {
-		return 0, NewSparityError(SubjectX)
-	}
-	spreadY, err := spreadImpl(y, assumeSorted)
-	if err != nil {
-		return 0, err
-	}
-	if spreadY <= 0 {
-		return 0, NewSparityError(SubjectY)
-	}
 
 	return (n*spreadX + m*spreadY) / (n + m), nil
 }
@@ -203,38 +409,80 @@ func avgSpread(x, y []float64, assumeSorted bool) (float64, error) {
 // If assumeSorted is true, both x and y are assumed already sorted ascending
 // and the internal sort is skipped (undefined behavior on unsorted input).
 func Disparity(x, y []float64, assumeSorted bool) (float64, error) {
-	if err := checkValidity(x, SubjectX); err != nil {
+	return disparityImpl(x, y, assumeSorted)
+}
+
+// disparityImpl is Disparity for any element type (see DisparityFloat32).
+func disparityImpl[T Number](x, y []T, assumeSorted bool) (float64, error) {
+	if err := checkTwoSample(x, y, false); err != nil {
 		return 0, err
 	}
-	if err := checkValidity(y, SubjectY); err != nil {
+
+	n := float64(len(x))
+	m := float64(len(y))
+
+	spreadX, spreadY, err := checkSparityPair(x, y, assumeSorted)
+	if err != nil {
 		return 0, err
 	}
 
+	shiftVal, err := shiftQuantilesImpl(context.Background(), x, y, []float64{0.5}, assumeSorted)
+	if err != nil {
+		return 0, err
+	}
+	avgSpreadVal := (n*spreadX + m*spreadY) / (n + m)
+
+	return shiftVal[0] / avgSpreadVal, nil
+}
+
+// DisparityResult is the outcome of DisparityDetail.
+type DisparityResult struct {
+	Value     float64 // Shift / AvgSpread; ±Inf or NaN when AvgSpread is zero
+	Shift     float64
+	AvgSpread float64
+	// Degenerate reports that x or y is tie-dominant (Spread = 0), the case
+	// Disparity rejects with a sparity error. Value is then not an effect
+	// size: it is infinite (or NaN for zero Shift) when both spreads are zero.
+	Degenerate bool
+}
+
+// DisparityDetail is Disparity reporting tie-dominant samples as a
+// Degenerate result instead of a sparity error, so callers can tell "no
+// spread" apart from a genuine large effect and from invalid input. For
+// non-degenerate samples Value equals Disparity(x, y). Validity errors are
+// still returned.
+//
+// If assumeSorted is true, both x and y are assumed already sorted ascending
+// and the internal sort is skipped (undefined behavior on unsorted input).
+func DisparityDetail(x, y []float64, assumeSorted bool) (DisparityResult, error) {
+	if err := checkTwoSample(x, y, false); err != nil {
+		return DisparityResult{}, err
+	}
+
 	n := float64(len(x))
 	m := float64(len(y))
 
 	spreadX, err := spreadImpl(x, assumeSorted)
 	if err != nil {
-		return 0, err
-	}
-	if spreadX <= 0 {
-		return 0, NewSparityError(SubjectX)
+		return DisparityResult{}, err
 	}
 	spreadY, err := spreadImpl(y, assumeSorted)
 	if err != nil {
-		return 0, err
-	}
-	if spreadY <= 0 {
-		return 0, NewSparityError(SubjectY)
+		return DisparityResult{}, err
 	}
 
-	shiftVal, err := shiftQuantilesImpl(x, y, []float64{0.5}, assumeSorted)
+	shiftVal, err := shiftQuantilesImpl(context.Background(), x, y, []float64{0.5}, assumeSorted)
 	if err != nil {
-		return 0, err
+		return DisparityResult{}, err
 	}
 	avgSpreadVal := (n*spreadX + m*spreadY) / (n + m)
 
-	return shiftVal[0] / avgSpreadVal, nil
+	return DisparityResult{
+		Value:      shiftVal[0] / avgSpreadVal,
+		Shift:      shiftVal[0],
+		AvgSpread:  avgSpreadVal,
+		Degenerate: spreadX <= 0 || spreadY <= 0,
+	}, nil
 }
 
 // ShiftBounds provides bounds on the Shift estimator with specified misclassification rate.
@@ -242,15 +490,72 @@ func Disparity(x, y []float64, assumeSorted bool) (float64, error) {
 // If assumeSorted is true, both x and y are assumed already sorted ascending
 // and the internal sort is skipped (undefined behavior on unsorted input).
 func ShiftBounds(x, y []float64, misrate float64, assumeSorted bool) (Bounds, error) {
+	return ShiftBoundsCtx(context.Background(), x, y, misrate, assumeSorted)
+}
+
+// ShiftBoundsCtx is ShiftBounds with cancellation: if ctx is done before the
+// exact margin or the quantile selection finishes, it returns a
+// *CancelledError wrapping ctx.Err().
+func ShiftBoundsCtx(ctx context.Context, x, y []float64, misrate float64, assumeSorted bool) (Bounds, error) {
+	bounds, _, err := shiftBoundsImpl(ctx, x, y, misrate, assumeSorted)
+	return bounds, err
+}
+
+// ShiftBoundsDetail is ShiftBounds that also reports how the interval was
+// built. usedMargin is the number of extreme pairwise differences actually
+// excluded (half from each end). It is the margin for the requested misrate
+// unless that would leave no differences, in which case it is clamped so the
+// bounds keep at least the middle difference. effectiveMisrate is the
+// probability that the bounds miss the true shift for that margin (exact for
+// n+m <= 400, Edgeworth-approximated above). It can differ from misrate
+// because the margin is discrete, and it is noticeably smaller when the
+// clamp applies.
+func ShiftBoundsDetail(x, y []float64, misrate float64, assumeSorted bool) (bounds Bounds, usedMargin int, effectiveMisrate float64, err error) {
+	ctx := context.Background()
+	bounds, halfMargin, err := shiftBoundsImpl(ctx, x, y, misrate, assumeSorted)
+	if err != nil {
+		return Bounds{}, 0, 0, err
+	}
+	tail, err := mannWhitneyCdf(ctx, len(x), len(y), halfMargin)
+	if err != nil {
+		return Bounds{}, 0, 0, err
+	}
+	return bounds, int(2 * halfMargin), math.Min(2*tail, 1), nil
+}
+
+// ShiftTest is a two-sample test of the hypothesis Shift = delta0 built on
+// ShiftBounds: it rejects when delta0 falls strictly outside the bounds and
+// returns them alongside the decision. It is the confidence-interval form of
+// the two-sided Mann–Whitney (Wilcoxon rank-sum) test of x - delta0 against
+// y: delta0 lies outside the bounds exactly when that test, with the same
+// margin, rejects, so the false-rejection rate is at most about misrate.
+// Errors from ShiftBounds are returned unchanged; a NaN delta0 is an error.
+//
+// If assumeSorted is true, both x and y are assumed already sorted ascending
+// and the internal sort is skipped (undefined behavior on unsorted input).
+func ShiftTest(x, y []float64, delta0, misrate float64, assumeSorted bool) (reject bool, bounds Bounds, err error) {
+	bounds, err = ShiftBounds(x, y, misrate, assumeSorted)
+	if err != nil {
+		return false, Bounds{}, err
+	}
+	if math.IsNaN(delta0) {
+		return false, Bounds{}, errors.New("delta0 must not be NaN")
+	}
+	return !bounds.Contains(delta0), bounds, nil
+}
+
+// shiftBoundsImpl computes ShiftBounds and also returns the number of
+// pairwise differences excluded from each end after clamping.
+func shiftBoundsImpl[T Number](ctx context.Context, x, y []T, misrate float64, assumeSorted bool) (Bounds, int64, error) {
 	if err := checkValidity(x, SubjectX); err != nil {
-		return Bounds{}, err
+		return Bounds{}, 0, err
 	}
 	if err := checkValidity(y, SubjectY); err != nil {
-		return Bounds{}, err
+		return Bounds{}, 0, err
 	}
 
 	if math.IsNaN(misrate) || misrate < 0 || misrate > 1 {
-		return Bounds{}, NewDomainError(SubjectMisrate)
+		return Bounds{}, 0, NewDomainError(SubjectMisrate)
 	}
 
 	n := len(x)
@@ -258,10 +563,10 @@ func ShiftBounds(x, y []float64, misrate float64, assumeSorted bool) (Bounds, er
 
 	minMisrate, err := minAchievableMisrateTwoSample(n, m)
 	if err != nil {
-		return Bounds{}, err
+		return Bounds{}, 0, err
 	}
 	if misrate < minMisrate {
-		return Bounds{}, NewDomainError(SubjectMisrate)
+		return Bounds{}, 0, NewDomainError(SubjectMisrate)
 	}
 
 	xSorted := sortedOne(x, assumeSorted)
@@ -270,13 +575,13 @@ func ShiftBounds(x, y []float64, misrate float64, assumeSorted bool) (Bounds, er
 	total := int64(n) * int64(m)
 
 	if total == 1 {
-		value := xSorted[0] - ySorted[0]
-		return Bounds{Lower: value, Upper: value, Unit: NumberUnit}, nil
+		value := float64(xSorted[0]) - float64(ySorted[0])
+		return Bounds{Lower: value, Upper: value, Unit: NumberUnit}, 0, nil
 	}
 
-	margin, err := pairwiseMargin(n, m, misrate)
+	margin, err := pairwiseMargin(ctx, n, m, misrate)
 	if err != nil {
-		return Bounds{}, err
+		return Bounds{}, 0, err
 	}
 	halfMargin := int64(margin / 2)
 	maxHalfMargin := (total - 1) / 2
@@ -289,9 +594,9 @@ func ShiftBounds(x, y []float64, misrate float64, assumeSorted bool) (Bounds, er
 	// total >= 2 here (the total == 1 case returned early above), so total-1 >= 1.
 	denominator := float64(total - 1)
 	p := []float64{float64(kLeft) / denominator, float64(kRight) / denominator}
-	bounds, err := shiftQuantilesImpl(xSorted, ySorted, p, true)
+	bounds, err := shiftQuantilesImpl(ctx, xSorted, ySorted, p, true)
 	if err != nil {
-		return Bounds{}, err
+		return Bounds{}, 0, err
 	}
 
 	lower := bounds[0]
@@ -300,7 +605,7 @@ func ShiftBounds(x, y []float64, misrate float64, assumeSorted bool) (Bounds, er
 		lower, upper = upper, lower
 	}
 
-	return Bounds{Lower: lower, Upper: upper, Unit: NumberUnit}, nil
+	return Bounds{Lower: lower, Upper: upper, Unit: NumberUnit}, halfMargin, nil
 }
 
 // RatioBounds provides bounds on the Ratio estimator with specified misclassification rate.
@@ -312,6 +617,12 @@ func ShiftBounds(x, y []float64, misrate float64, assumeSorted bool) (Bounds, er
 // If assumeSorted is true, both x and y are assumed already sorted ascending
 // and the internal sort is skipped (undefined behavior on unsorted input).
 func RatioBounds(x, y []float64, misrate float64, assumeSorted bool) (Bounds, error) {
+	return ratioBoundsImpl(x, y, misrate, assumeSorted)
+}
+
+// ratioBoundsImpl is RatioBounds for any element type. The log-transformed
+// values are float64 whatever T is.
+func ratioBoundsImpl[T Number](x, y []T, misrate float64, assumeSorted bool) (Bounds, error) {
 	if err := checkValidity(x, SubjectX); err != nil {
 		return Bounds{}, err
 	}
@@ -360,6 +671,11 @@ func RatioBounds(x, y []float64, misrate float64, assumeSorted bool) (Bounds, er
 // If assumeSorted is true, x is assumed already sorted ascending and the
 // internal sort is skipped (undefined behavior on unsorted input).
 func CenterBounds(x []float64, misrate float64, assumeSorted bool) (Bounds, error) {
+	return centerBoundsImpl(x, misrate, assumeSorted)
+}
+
+// centerBoundsImpl is CenterBounds for any element type.
+func centerBoundsImpl[T Number](x []T, misrate float64, assumeSorted bool) (Bounds, error) {
 	if err := checkValidity(x, SubjectX); err != nil {
 		return Bounds{}, err
 	}
@@ -401,6 +717,26 @@ func CenterBounds(x []float64, misrate float64, assumeSorted bool) (Bounds, erro
 	return Bounds{Lower: lo, Upper: hi, Unit: NumberUnit}, nil
 }
 
+// CenterTest is a one-sample test of the hypothesis Center = mu0 built on
+// CenterBounds: it rejects when mu0 falls strictly outside the bounds, so
+// under weak symmetry the false-rejection rate is at most misrate. The bounds
+// are returned alongside the decision. Errors from CenterBounds (including a
+// misrate below the minimum achievable for len(x)) are returned unchanged; a
+// NaN mu0 is an error.
+//
+// If assumeSorted is true, x is assumed already sorted ascending and the
+// internal sort is skipped (undefined behavior on unsorted input).
+func CenterTest(x []float64, mu0, misrate float64, assumeSorted bool) (reject bool, bounds Bounds, err error) {
+	bounds, err = CenterBounds(x, misrate, assumeSorted)
+	if err != nil {
+		return false, Bounds{}, err
+	}
+	if math.IsNaN(mu0) {
+		return false, Bounds{}, errors.New("mu0 must not be NaN")
+	}
+	return !bounds.Contains(mu0), bounds, nil
+}
+
 // SpreadBounds provides distribution-free bounds for Spread using disjoint pairs.
 //
 // The disjoint-pair shuffle always runs on x's current order, so assumeSorted
@@ -447,7 +783,7 @@ func DisparityBoundsWithSeed(x, y []float64, misrate float64, seed string, assum
 // sorted view for the order-independent sparity check (skipping a re-sort). The
 // shuffle always runs on the original slice regardless, so the flag never
 // changes the result.
-func sortedView(x []float64, assumeSorted bool) []float64 {
+func sortedView[T Number](x []T, assumeSorted bool) []T {
 	if assumeSorted {
 		return x
 	}
@@ -457,14 +793,14 @@ func sortedView(x []float64, assumeSorted bool) []float64 {
 // spreadForSparity computes the spread value for the sparity check. The result
 // is order-independent, so a pre-sorted view (when available) is used to skip
 // re-sorting; otherwise the original slice is sorted internally.
-func spreadForSparity(orig, sorted []float64) (float64, error) {
+func spreadForSparity[T Number](orig, sorted []T) (float64, error) {
 	if sorted != nil {
 		return spreadImpl(sorted, true)
 	}
 	return spreadImpl(orig, false)
 }
 
-func spreadBoundsImpl(x, sortedX []float64, misrate float64, rng *Rng) (Bounds, error) {
+func spreadBoundsImpl[T Number](x, sortedX []T, misrate float64, rng *Rng) (Bounds, error) {
 	if err := checkValidity(x, SubjectX); err != nil {
 		return Bounds{}, err
 	}
@@ -500,7 +836,7 @@ func spreadBoundsImpl(x, sortedX []float64, misrate float64, rng *Rng) (Bounds,
 // spreadBoundsInner shuffles the original order into disjoint pairs and returns
 // order-statistic bounds. The caller is responsible for validity, domain and
 // sparity checks (so avgSpreadBounds can reuse it without re-checking).
-func spreadBoundsInner(x []float64, misrate float64, rng *Rng) (Bounds, error) {
+func spreadBoundsInner[T Number](x []T, misrate float64, rng *Rng) (Bounds, error) {
 	n := len(x)
 	m := n / 2
 
@@ -526,7 +862,7 @@ func spreadBoundsInner(x []float64, misrate float64, rng *Rng) (Bounds, error) {
 
 	diffs := make([]float64, m)
 	for i := 0; i < m; i++ {
-		diffs[i] = math.Abs(x[shuffled[2*i]] - x[shuffled[2*i+1]])
+		diffs[i] = math.Abs(float64(x[shuffled[2*i]]) - float64(x[shuffled[2*i+1]]))
 	}
 	sort.Float64s(diffs)
 
@@ -535,7 +871,7 @@ func spreadBoundsInner(x []float64, misrate float64, rng *Rng) (Bounds, error) {
 
 // avgSpreadBoundsImpl computes weighted-average spread bounds. x/y are always in
 // original order; sortedX/sortedY are sparity-only pre-sorted views.
-func avgSpreadBoundsImpl(x, sortedX, y, sortedY []float64, misrate float64, rngX, rngY *Rng) (Bounds, error) {
+func avgSpreadBoundsImpl[T Number](x, sortedX, y, sortedY []T, misrate float64, rngX, rngY *Rng) (Bounds, error) {
 	if err := checkValidity(x, SubjectX); err != nil {
 		return Bounds{}, err
 	}
@@ -606,7 +942,7 @@ func avgSpreadBoundsImpl(x, sortedX, y, sortedY []float64, misrate float64, rngX
 	}, nil
 }
 
-func disparityBoundsImpl(x, sortedX, y, sortedY []float64, misrate float64, rngX, rngY *Rng) (Bounds, error) {
+func disparityBoundsImpl[T Number](x, sortedX, y, sortedY []T, misrate float64, rngX, rngY *Rng) (Bounds, error) {
 	if err := checkValidity(x, SubjectX); err != nil {
 		return Bounds{}, err
 	}
@@ -656,9 +992,9 @@ func disparityBoundsImpl(x, sortedX, y, sortedY []float64, misrate float64, rngX
 	// sorted input; use sorted views when present.
 	var sb Bounds
 	if sortedX != nil && sortedY != nil {
-		sb, err = ShiftBounds(sortedX, sortedY, alphaShift, true)
+		sb, _, err = shiftBoundsImpl(context.Background(), sortedX, sortedY, alphaShift, true)
 	} else {
-		sb, err = ShiftBounds(x, y, alphaShift, false)
+		sb, _, err = shiftBoundsImpl(context.Background(), x, y, alphaShift, false)
 	}
 	if err != nil {
 		return Bounds{}, err
@@ -720,13 +1056,17 @@ func disparityBoundsImpl(x, sortedX, y, sortedY []float64, misrate float64, rngX
 
 // sortedOne returns a sorted view of x: returns x unchanged if assumeSorted,
 // otherwise returns a sorted copy (x is never mutated).
-func sortedOne(x []float64, assumeSorted bool) []float64 {
+func sortedOne[T Number](x []T, assumeSorted bool) []T {
 	if assumeSorted {
 		return x
 	}
-	sorted := make([]float64, len(x))
+	sorted := make([]T, len(x))
 	copy(sorted, x)
-	sort.Float64s(sorted)
+	if f, ok := any(sorted).([]float64); ok {
+		sort.Float64s(f)
+	} else {
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	}
 	return sorted
 }
 
@@ -762,6 +1102,19 @@ func (s *Sample) Spread() (Measurement, error) {
 	return NewMeasurement(result, s.unit), nil
 }
 
+// RelSpread measures the relative dispersion of the sample. The result is
+// dimensionless (NumberUnit).
+func (s *Sample) RelSpread() (Measurement, error) {
+	if err := checkNonWeighted("x", s); err != nil {
+		return Measurement{}, err
+	}
+	result, err := RelSpread(s.cachedSortedValues(), true)
+	if err != nil {
+		return Measurement{}, err
+	}
+	return NewMeasurement(result, NumberUnit), nil
+}
+
 // Shift measures the typical difference between this sample and other.
 func (s *Sample) Shift(other *Sample) (Measurement, error) {
 	x, y, err := s.preparePair(other)
@@ -801,6 +1154,25 @@ func (s *Sample) Disparity(other *Sample) (Measurement, error) {
 	return NewMeasurement(result, DisparityUnit), nil
 }
 
+// ShiftOf is a.Shift(b) as a package function: both samples are converted to
+// the finer of their compatible units (UnitMismatchError otherwise), and the
+// result is in that unit.
+func ShiftOf(a, b *Sample) (Measurement, error) {
+	return a.Shift(b)
+}
+
+// RatioOf is a.Ratio(b) as a package function, with the same unit
+// reconciliation as ShiftOf; the result is in RatioUnit.
+func RatioOf(a, b *Sample) (Measurement, error) {
+	return a.Ratio(b)
+}
+
+// DisparityOf is a.Disparity(b) as a package function, with the same unit
+// reconciliation as ShiftOf; the result is in DisparityUnit.
+func DisparityOf(a, b *Sample) (Measurement, error) {
+	return a.Disparity(b)
+}
+
 // avgSpread is the internal Sample-based weighted-average spread estimator.
 func (s *Sample) avgSpread(other *Sample) (Measurement, error) {
 	x, y, err := s.preparePair(other)
@@ -814,6 +1186,57 @@ func (s *Sample) avgSpread(other *Sample) (Measurement, error) {
 	return NewMeasurement(result, x.unit), nil
 }
 
+// AvgSpread pools the Spread of two samples, weighting each by its effective
+// size: (nA·spreadA + nB·spreadB) / (nA + nB) with n = WeightedSize. For
+// unweighted samples WeightedSize is the count, so the result matches the
+// unweighted estimator. Weighted samples use the weighted Shamos estimator
+// (weighted median of pairwise differences, pair weight w[i]·w[j]).
+//
+// Both samples are converted to the finer of their units, and the result is
+// expressed in that unit.
+//
+// Assumptions:
+//   - sparity(a) - first sample must be non tie-dominant (Spread > 0)
+//   - sparity(b) - second sample must be non tie-dominant (Spread > 0)
+func AvgSpread(a, b *Sample) (Measurement, error) {
+	if a == nil || b == nil {
+		return Measurement{}, fmt.Errorf("samples cannot be nil")
+	}
+	if err := checkCompatibleUnits(a, b); err != nil {
+		return Measurement{}, err
+	}
+	x, y, err := convertToFiner(a, b)
+	if err != nil {
+		return Measurement{}, err
+	}
+	spreadX, err := x.effectiveSpread()
+	if err != nil {
+		return Measurement{}, err
+	}
+	if spreadX <= 0 {
+		return Measurement{}, NewSparityError(SubjectX)
+	}
+	spreadY, err := y.effectiveSpread()
+	if err != nil {
+		return Measurement{}, err
+	}
+	if spreadY <= 0 {
+		return Measurement{}, NewSparityError(SubjectY)
+	}
+	n, m := x.weightedSize, y.weightedSize
+	return NewMeasurement((n*spreadX+m*spreadY)/(n+m), x.unit), nil
+}
+
+// effectiveSpread is Spread for unweighted samples and the weighted Shamos
+// estimator for weighted ones.
+func (s *Sample) effectiveSpread() (float64, error) {
+	if !s.isWeighted {
+		return spreadImpl(s.cachedSortedValues(), true)
+	}
+	sorted := s.cachedSortedValues()
+	return weightedSpreadImpl(sorted, s.sortCache.weights)
+}
+
 // CenterBounds provides distribution-free bounds for Center.
 func (s *Sample) CenterBounds(misrate float64) (Bounds, error) {
 	if err := checkNonWeighted("x", s); err != nil {
@@ -0,0 +1,123 @@
+package pragmastat
+
+import "context"
+
+// =============================================================================
+// Native float32 raw API
+//
+// The float32 variants run the same generic kernels as the float64 raw API
+// directly on the caller's []float32, so the internal sort copies stay in
+// float32 (half the memory of converting to []float64 first). Pairwise sums
+// and differences are still accumulated in float64, and every float32 value
+// converts to float64 exactly, so the results equal the float64 path on the
+// widened input. The results are float32-accurate: they are only as precise as
+// the float32 input values themselves.
+// =============================================================================
+
+// CenterFloat32 is the float32 counterpart of Center.
+func CenterFloat32(x []float32, assumeSorted bool) (float64, error) {
+	if err := checkValidity(x, SubjectX); err != nil {
+		return 0, err
+	}
+	return centerImpl(context.Background(), x, assumeSorted)
+}
+
+// SpreadFloat32 is the float32 counterpart of Spread.
+//
+// Assumptions:
+//   - sparity(x) - sample must be non tie-dominant (Spread > 0)
+func SpreadFloat32(x []float32, assumeSorted bool) (float64, error) {
+	if err := checkValidity(x, SubjectX); err != nil {
+		return 0, err
+	}
+	spreadVal, err := spreadImpl(x, assumeSorted)
+	if err != nil {
+		return 0, err
+	}
+	if spreadVal <= 0 {
+		return 0, NewSparityError(SubjectX)
+	}
+	return spreadVal, nil
+}
+
+// ShiftFloat32 is the float32 counterpart of Shift.
+func ShiftFloat32(x, y []float32, assumeSorted bool) (float64, error) {
+	if err := checkTwoSample(x, y, false); err != nil {
+		return 0, err
+	}
+	result, err := shiftQuantilesImpl(context.Background(), x, y, []float64{0.5}, assumeSorted)
+	if err != nil {
+		return 0, err
+	}
+	return result[0], nil
+}
+
+// RatioFloat32 is the float32 counterpart of Ratio.
+//
+// Assumptions:
+//   - positivity(x) - all values in x must be strictly positive
+//   - positivity(y) - all values in y must be strictly positive
+func RatioFloat32(x, y []float32, assumeSorted bool) (float64, error) {
+	if err := checkTwoSample(x, y, true); err != nil {
+		return 0, err
+	}
+	result, err := ratioQuantilesImpl(x, y, []float64{0.5}, assumeSorted)
+	if err != nil {
+		return 0, err
+	}
+	return result[0], nil
+}
+
+// DisparityFloat32 is the float32 counterpart of Disparity.
+//
+// Assumptions:
+//   - sparity(x) - first sample must be non tie-dominant (Spread > 0)
+//   - sparity(y) - second sample must be non tie-dominant (Spread > 0)
+func DisparityFloat32(x, y []float32, assumeSorted bool) (float64, error) {
+	return disparityImpl(x, y, assumeSorted)
+}
+
+// CenterBoundsFloat32 is the float32 counterpart of CenterBounds.
+func CenterBoundsFloat32(x []float32, misrate float64, assumeSorted bool) (Bounds, error) {
+	return centerBoundsImpl(x, misrate, assumeSorted)
+}
+
+// SpreadBoundsFloat32 is the float32 counterpart of SpreadBounds.
+func SpreadBoundsFloat32(x []float32, misrate float64, assumeSorted bool) (Bounds, error) {
+	return spreadBoundsImpl(x, sortedView(x, assumeSorted), misrate, NewRng())
+}
+
+// SpreadBoundsWithSeedFloat32 is the float32 counterpart of
+// SpreadBoundsWithSeed; it returns the same bounds as SpreadBoundsWithSeed on
+// the widened input.
+func SpreadBoundsWithSeedFloat32(x []float32, misrate float64, seed string, assumeSorted bool) (Bounds, error) {
+	return spreadBoundsImpl(x, sortedView(x, assumeSorted), misrate, NewRngFromString(seed))
+}
+
+// ShiftBoundsFloat32 is the float32 counterpart of ShiftBounds.
+func ShiftBoundsFloat32(x, y []float32, misrate float64, assumeSorted bool) (Bounds, error) {
+	bounds, _, err := shiftBoundsImpl(context.Background(), x, y, misrate, assumeSorted)
+	return bounds, err
+}
+
+// RatioBoundsFloat32 is the float32 counterpart of RatioBounds. The
+// log-transformed copies it works on are float64, so it saves no memory over
+// RatioBounds on widened input.
+//
+// Assumptions:
+//   - positivity(x) - all values in x must be strictly positive
+//   - positivity(y) - all values in y must be strictly positive
+func RatioBoundsFloat32(x, y []float32, misrate float64, assumeSorted bool) (Bounds, error) {
+	return ratioBoundsImpl(x, y, misrate, assumeSorted)
+}
+
+// DisparityBoundsFloat32 is the float32 counterpart of DisparityBounds.
+func DisparityBoundsFloat32(x, y []float32, misrate float64, assumeSorted bool) (Bounds, error) {
+	return disparityBoundsImpl(x, sortedView(x, assumeSorted), y, sortedView(y, assumeSorted), misrate, NewRng(), NewRng())
+}
+
+// DisparityBoundsWithSeedFloat32 is the float32 counterpart of
+// DisparityBoundsWithSeed.
+func DisparityBoundsWithSeedFloat32(x, y []float32, misrate float64, seed string, assumeSorted bool) (Bounds, error) {
+	return disparityBoundsImpl(x, sortedView(x, assumeSorted), y, sortedView(y, assumeSorted), misrate, NewRngFromString(seed), NewRngFromString(seed))
+}
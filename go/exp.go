@@ -6,33 +6,120 @@ import "math"
 // The mean of this distribution is 1/rate.
 type Exp struct {
 	Rate float64
+	open bool // draw with UniformOpen (see NewExpOpen)
 }
 
 // NewExp creates a new exponential distribution with given rate.
-// Panics if rate <= 0.
+// Panics if rate is not positive and finite.
 func NewExp(rate float64) *Exp {
-	if rate <= 0 {
-		panic("rate must be positive")
+	e, err := TryNewExp(rate)
+	if err != nil {
+		panic(err.Error())
 	}
-	return &Exp{Rate: rate}
+	return e
+}
+
+// TryNewExp is NewExp returning a *ParameterError instead of panicking.
+func TryNewExp(rate float64) (*Exp, error) {
+	if !(rate > 0) || math.IsInf(rate, 1) {
+		return nil, newParameterError("Exp", "rate", "rate must be positive and finite")
+	}
+	return &Exp{Rate: rate}, nil
+}
+
+// NewExpOpen is NewExp drawing its uniform with UniformOpen, so a sample is
+// never exactly 0. The values match NewExp for the same seed only until the
+// generator first yields a zero uniform: UniformOpen redraws it, and every
+// later value is shifted by the extra draw.
+func NewExpOpen(rate float64) *Exp {
+	e := NewExp(rate)
+	e.open = true
+	return e
 }
 
 // Sample generates a single sample from the exponential distribution.
 func (e *Exp) Sample(rng *Rng) float64 {
-	// Inverse CDF method: -ln(1 - U) / rate
-	u := rng.UniformFloat64()
-	// Avoid log(0)
-	if u == 1.0 {
-		u = 1.0 - machineEpsilon
-	}
+	return e.quantile(rng.uniformFor(e.open))
+}
+
+// quantile is the inverse CDF: -ln(1 - u) / rate, for u in [0, 1).
+func (e *Exp) quantile(u float64) float64 {
 	return -math.Log(1.0-u) / e.Rate
 }
 
+func (e *Exp) antitheticPair(rng *Rng) (float64, float64) {
+	u, v := rng.UniformAntithetic()
+	return e.quantile(u), e.quantile(antitheticBelowOne(v))
+}
+
 // Samples generates multiple samples from the exponential distribution.
 func (e *Exp) Samples(rng *Rng, count int) []float64 {
-	result := make([]float64, count)
-	for i := 0; i < count; i++ {
-		result[i] = e.Sample(rng)
+	return sampleN(e, rng, count)
+}
+
+// SamplesInto fills dst with samples from the exponential distribution without allocating.
+func (e *Exp) SamplesInto(rng *Rng, dst []float64) {
+	fillSamples(e, rng, dst)
+}
+
+// DrawsPerSample returns 1, or VariableDraws for NewExpOpen, which rejects
+// a zero uniform.
+func (e *Exp) DrawsPerSample() int {
+	if e.open {
+		return VariableDraws
+	}
+	return 1
+}
+
+// Pdf returns the probability density at x: rate·e^(-rate·x) for x >= 0.
+func (e *Exp) Pdf(x float64) float64 {
+	if x < 0 {
+		return 0
 	}
-	return result
+	return e.Rate * math.Exp(-e.Rate*x)
+}
+
+// Cdf returns P(X <= x): 1 - e^(-rate·x) for x >= 0.
+func (e *Exp) Cdf(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	return -math.Expm1(-e.Rate * x)
+}
+
+// Quantile returns the inverse CDF: -ln(1 - p) / rate. Quantile(1) is +Inf,
+// and p outside [0, 1] gives NaN.
+func (e *Exp) Quantile(p float64) float64 {
+	if invalidProbability(p) {
+		return math.NaN()
+	}
+	return -math.Log1p(-p) / e.Rate
+}
+
+// TrueCenter returns the population Center: half the median of a
+// Gamma(2, rate) variable, about 0.8392/rate. It has no closed form and is
+// computed numerically.
+func (e *Exp) TrueCenter() float64 {
+	return numericTrueCenter(e)
+}
+
+// TrueSpread returns the population Spread. X1-X2 is Laplace with scale
+// 1/rate, so |X1-X2| is again exponential and its median is ln 2 / rate.
+func (e *Exp) TrueSpread() float64 {
+	return math.Ln2 / e.Rate
+}
+
+// TrueMean returns the population mean 1/rate.
+func (e *Exp) TrueMean() float64 {
+	return 1 / e.Rate
+}
+
+// TrueVariance returns the population variance 1/rate².
+func (e *Exp) TrueVariance() float64 {
+	return 1 / (e.Rate * e.Rate)
+}
+
+// TrueStdDev returns the population standard deviation, √TrueVariance.
+func (e *Exp) TrueStdDev() float64 {
+	return math.Sqrt(e.TrueVariance())
 }
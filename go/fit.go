@@ -0,0 +1,167 @@
+package pragmastat
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// FitAdditive estimates an Additive (normal) distribution from x with the
+// robust estimators: mean = Center(x) and stdDev = Spread(x)/spreadOfNormal,
+// where the consistency constant spreadOfNormal = √2·Φ⁻¹(3/4) ≈ 0.9539 is the
+// Spread of a standard normal. Reports validity(x) for empty or non-finite
+// input and sparity(x) for tie-dominant input.
+func FitAdditive(x []float64) (*Additive, error) {
+	if err := checkValidity(x, SubjectX); err != nil {
+		return nil, err
+	}
+	sorted := sortedOne(x, false)
+	center, err := Center(sorted, true)
+	if err != nil {
+		return nil, err
+	}
+	spread, err := Spread(sorted, true)
+	if err != nil {
+		return nil, err
+	}
+	return TryNewAdditive(center, spread/spreadOfNormal)
+}
+
+// FitMultiplic estimates a Multiplic (log-normal) distribution by fitting an
+// Additive to log(x), so it uses the same consistency constant on the log
+// scale. Reports validity(x), then positivity(x) for non-positive values,
+// then sparity(x).
+func FitMultiplic(x []float64) (*Multiplic, error) {
+	if err := checkValidity(x, SubjectX); err != nil {
+		return nil, err
+	}
+	logs, err := Log(x, SubjectX)
+	if err != nil {
+		return nil, err
+	}
+	a, err := FitAdditive(logs)
+	if err != nil {
+		return nil, err
+	}
+	return TryNewMultiplic(a.Mean, a.StdDev)
+}
+
+// FitExp estimates an Exp distribution as rate = ln 2 / Median(x): the
+// median of Exp(rate) is ln 2 / rate, so ln 2 is the consistency constant.
+// Reports validity(x), then positivity(x) for non-positive values.
+func FitExp(x []float64) (*Exp, error) {
+	if err := checkValidity(x, SubjectX); err != nil {
+		return nil, err
+	}
+	if err := checkPositivity(x, SubjectX); err != nil {
+		return nil, err
+	}
+	return TryNewExp(math.Ln2 / medianImpl(x))
+}
+
+// FitPower estimates a Power (Pareto) distribution with min = min(x) and
+// shape = ln 2 / median(log(x/min)): log(X/min) is Exp(shape), so ln 2 is
+// again the consistency constant. Reports validity(x), then positivity(x)
+// for non-positive values, then sparity(x) when more than half of the values
+// equal the minimum.
+func FitPower(x []float64) (*Power, error) {
+	if err := checkValidity(x, SubjectX); err != nil {
+		return nil, err
+	}
+	if err := checkPositivity(x, SubjectX); err != nil {
+		return nil, err
+	}
+	min := x[0]
+	for _, v := range x[1:] {
+		min = math.Min(min, v)
+	}
+	logRatios := make([]float64, len(x))
+	for i, v := range x {
+		logRatios[i] = math.Log(v / min)
+	}
+	m := medianImpl(logRatios)
+	if m <= 0 {
+		return nil, NewSparityError(SubjectX)
+	}
+	return TryNewPower(min, math.Ln2/m)
+}
+
+// fitDistanceIterations is the number of bootstrap resamples behind
+// FitDistanceBounds.
+const fitDistanceIterations = 1000
+
+// FitDistance measures how far the sample x is from the model dist: the
+// median over the sample points of |ECDF(x_i) - dist.Cdf(x_i)|, where the
+// ECDF takes the midpoint of its jump at x_i (so tied points share one
+// value). It is a median-based, Kolmogorov-style discrepancy for comparing
+// candidate models, not a formal test; for data drawn from dist it shrinks
+// like 1/√n. Reports validity(x) and a plain error for a nil dist.
+func FitDistance(x []float64, dist FullDistribution) (float64, error) {
+	if err := checkValidity(x, SubjectX); err != nil {
+		return 0, err
+	}
+	if dist == nil {
+		return 0, fmt.Errorf("dist cannot be nil")
+	}
+	return fitDistanceSorted(sortedOne(x, false), dist, nil), nil
+}
+
+// FitDistanceBounds gives percentile-bootstrap bounds for FitDistance from
+// 1000 resamples of x: the misrate/2 and 1 - misrate/2 quantiles of the
+// resampled distances. The bounds are approximate. Reports domain(misrate)
+// for misrate outside [0.002, 1], the smallest misrate the resample count
+// can resolve.
+func FitDistanceBounds(x []float64, dist FullDistribution, misrate float64) (Bounds, error) {
+	return fitDistanceBoundsImpl(x, dist, misrate, NewRng())
+}
+
+// FitDistanceBoundsWithSeed is FitDistanceBounds with deterministic
+// resampling.
+func FitDistanceBoundsWithSeed(x []float64, dist FullDistribution, misrate float64, seed string) (Bounds, error) {
+	return fitDistanceBoundsImpl(x, dist, misrate, NewRngFromString(seed))
+}
+
+func fitDistanceBoundsImpl(x []float64, dist FullDistribution, misrate float64, rng *Rng) (Bounds, error) {
+	if err := checkValidity(x, SubjectX); err != nil {
+		return Bounds{}, err
+	}
+	if dist == nil {
+		return Bounds{}, fmt.Errorf("dist cannot be nil")
+	}
+	if math.IsNaN(misrate) || misrate < 2.0/fitDistanceIterations || misrate > 1 {
+		return Bounds{}, NewDomainError(SubjectMisrate)
+	}
+	resample := make([]float64, len(x))
+	gaps := make([]float64, 0, len(x))
+	distances := make([]float64, fitDistanceIterations)
+	for i := range distances {
+		ResampleInto(rng, x, resample)
+		sort.Float64s(resample)
+		distances[i] = fitDistanceSorted(resample, dist, gaps)
+	}
+	sort.Float64s(distances)
+	return Bounds{
+		Lower: quantileSorted(distances, misrate/2),
+		Upper: quantileSorted(distances, 1-misrate/2),
+		Unit:  NumberUnit,
+	}, nil
+}
+
+// fitDistanceSorted computes FitDistance on sorted values, reusing buf for
+// the per-point gaps when it has enough capacity.
+func fitDistanceSorted(sorted []float64, dist FullDistribution, buf []float64) float64 {
+	n := len(sorted)
+	gaps := buf[:0]
+	for lo := 0; lo < n; {
+		hi := lo + 1
+		for hi < n && sorted[hi] == sorted[lo] {
+			hi++
+		}
+		gap := math.Abs(float64(lo+hi)/float64(2*n) - dist.Cdf(sorted[lo]))
+		for k := lo; k < hi; k++ {
+			gaps = append(gaps, gap)
+		}
+		lo = hi
+	}
+	return medianImpl(gaps)
+}
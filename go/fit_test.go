@@ -0,0 +1,179 @@
+package pragmastat
+
+import (
+	"math"
+	"testing"
+)
+
+// TestFitRoundTrip fits each model to 10^4 draws from a known distribution,
+// then fits again to 10^4 draws from the fitted model; both fits must recover
+// the parameters within 5% (several standard errors at this size).
+func TestFitRoundTrip(t *testing.T) {
+	const n = 10_000
+	for _, c := range []struct {
+		name string
+		d    Distribution
+		fit  func(x []float64) ([]float64, Distribution, error)
+		want []float64
+	}{
+		{"Additive", NewAdditive(10, 3), func(x []float64) ([]float64, Distribution, error) {
+			a, err := FitAdditive(x)
+			if err != nil {
+				return nil, nil, err
+			}
+			return []float64{a.Mean, a.StdDev}, a, nil
+		}, []float64{10, 3}},
+		{"Multiplic", NewMultiplic(1, 0.5), func(x []float64) ([]float64, Distribution, error) {
+			m, err := FitMultiplic(x)
+			if err != nil {
+				return nil, nil, err
+			}
+			return []float64{m.LogMean, m.LogStdDev}, m, nil
+		}, []float64{1, 0.5}},
+		{"Exp", NewExp(2), func(x []float64) ([]float64, Distribution, error) {
+			e, err := FitExp(x)
+			if err != nil {
+				return nil, nil, err
+			}
+			return []float64{e.Rate}, e, nil
+		}, []float64{2}},
+		{"Power", NewPower(1.5, 3), func(x []float64) ([]float64, Distribution, error) {
+			p, err := FitPower(x)
+			if err != nil {
+				return nil, nil, err
+			}
+			return []float64{p.Min, p.Shape}, p, nil
+		}, []float64{1.5, 3}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			rng := NewRngFromString("fit-" + c.name)
+			got, fitted, err := c.fit(c.d.Samples(rng, n))
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkFitted(t, "fit", got, c.want)
+
+			refit, _, err := c.fit(fitted.Samples(rng, n))
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkFitted(t, "refit", refit, got)
+		})
+	}
+}
+
+func checkFitted(t *testing.T, stage string, got, want []float64) {
+	t.Helper()
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 0.05*math.Abs(want[i]) {
+			t.Errorf("%s: parameter %d = %v, want %v", stage, i, got[i], want[i])
+		}
+	}
+}
+
+// TestFitConsistencyConstants checks the constants against their closed
+// forms and against the estimators on population quantiles, so the fits are
+// unbiased in the large-sample limit.
+func TestFitConsistencyConstants(t *testing.T) {
+	if !floatEquals(spreadOfNormal, 0.9538725524089, 1e-12) {
+		t.Errorf("spreadOfNormal = %v, want 0.9538725524089", spreadOfNormal)
+	}
+	if got := NewExp(1).Quantile(0.5); !floatEquals(got, math.Ln2, 1e-15) {
+		t.Errorf("Exp(1) median = %v, want ln 2", got)
+	}
+	if got := math.Log(NewPower(1, 1).Quantile(0.5)); !floatEquals(got, math.Ln2, 1e-15) {
+		t.Errorf("median of log(X/min) for Power(1, 1) = %v, want ln 2", got)
+	}
+	if got := NewAdditive(0, 1).TrueSpread(); got != spreadOfNormal {
+		t.Errorf("Additive(0, 1).TrueSpread = %v, want %v", got, spreadOfNormal)
+	}
+}
+
+func TestFitErrors(t *testing.T) {
+	nan := []float64{1, math.NaN(), 3}
+	nonPositive := []float64{1, 0, 3}
+	for _, c := range []struct {
+		name    string
+		fit     func(x []float64) error
+		x       []float64
+		id      AssumptionID
+		subject Subject
+	}{
+		{"Additive empty", fitErr(FitAdditive), nil, Validity, SubjectX},
+		{"Additive NaN", fitErr(FitAdditive), nan, Validity, SubjectX},
+		{"Additive ties", fitErr(FitAdditive), []float64{1, 1, 1, 1, 2}, Sparity, SubjectX},
+		{"Multiplic NaN", fitErr(FitMultiplic), nan, Validity, SubjectX},
+		{"Multiplic non-positive", fitErr(FitMultiplic), nonPositive, Positivity, SubjectX},
+		{"Exp non-positive", fitErr(FitExp), nonPositive, Positivity, SubjectX},
+		{"Exp NaN", fitErr(FitExp), nan, Validity, SubjectX},
+		{"Power non-positive", fitErr(FitPower), []float64{-1, 2, 3}, Positivity, SubjectX},
+		{"Power ties at min", fitErr(FitPower), []float64{2, 2, 2, 5}, Sparity, SubjectX},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			assertViolation(t, c.fit(c.x), c.id, c.subject)
+		})
+	}
+}
+
+func fitErr[D any](fit func(x []float64) (D, error)) func(x []float64) error {
+	return func(x []float64) error {
+		_, err := fit(x)
+		return err
+	}
+}
+
+func TestFitDistanceShrinksWithShift(t *testing.T) {
+	x := NewAdditive(0, 1).Samples(NewRngFromString("fit-distance"), 2000)
+	previous := math.Inf(1)
+	for _, shift := range []float64{2, 1, 0.5, 0.25, 0} {
+		d, err := FitDistance(x, NewAdditive(shift, 1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d >= previous {
+			t.Errorf("shift %v: distance %v, want below %v", shift, d, previous)
+		}
+		previous = d
+	}
+	if previous > 0.03 {
+		t.Errorf("distance to the true model is %v, want at most 0.03", previous)
+	}
+}
+
+func TestFitDistanceBounds(t *testing.T) {
+	x := NewExp(1).Samples(NewRngFromString("fit-distance-bounds"), 500)
+	truth, err := FitDistanceBoundsWithSeed(x, NewExp(1), 0.05, "seed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrong, err := FitDistanceBoundsWithSeed(x, NewExp(2), 0.05, "seed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !(truth.Lower <= truth.Upper && truth.Upper < wrong.Lower) {
+		t.Errorf("true model bounds %v, wrong model bounds %v", truth, wrong)
+	}
+	again, _ := FitDistanceBoundsWithSeed(x, NewExp(1), 0.05, "seed")
+	if again != truth {
+		t.Errorf("seeded bounds %v, then %v", truth, again)
+	}
+	if _, err := FitDistanceBounds(x, NewExp(1), 0.05); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFitDistanceErrors(t *testing.T) {
+	x := []float64{1, 2, 3}
+	d := NewUniform(0, 4)
+	_, err := FitDistance(nil, d)
+	assertViolation(t, err, Validity, SubjectX)
+	_, err = FitDistanceBounds([]float64{1, math.NaN()}, d, 0.1)
+	assertViolation(t, err, Validity, SubjectX)
+	for _, misrate := range []float64{math.NaN(), 0, 0.001, 1.5} {
+		_, err = FitDistanceBounds(x, d, misrate)
+		assertViolation(t, err, Domain, SubjectMisrate)
+	}
+	if _, err := FitDistance(x, nil); err == nil {
+		t.Error("expected an error for a nil distribution")
+	}
+}
@@ -0,0 +1,42 @@
+package pragmastat
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FormatPercent formats v, already expressed in percent, with the given number
+// of decimals and a trailing "%" sign: FormatPercent(12.345, 1) is "12.3%".
+// Rounding is round-half-to-even on the exact binary value (strconv
+// semantics). Negative decimals are treated as 0, and values that round to
+// zero never carry a minus sign ("0.0%", not "-0.0%").
+func FormatPercent(v float64, decimals int) string {
+	if decimals < 0 {
+		decimals = 0
+	}
+	s := strconv.FormatFloat(v, 'f', decimals, 64)
+	if strings.HasPrefix(s, "-") && strings.Trim(s[1:], "0.") == "" {
+		s = s[1:]
+	}
+	return s + "%"
+}
+
+// RelSpreadPercent returns RelSpread(x) expressed in percent (RelSpread*100).
+// Errors are those of RelSpread.
+func RelSpreadPercent(x []float64, assumeSorted bool) (float64, error) {
+	relSpread, err := RelSpread(x, assumeSorted)
+	if err != nil {
+		return 0, err
+	}
+	return relSpread * 100, nil
+}
+
+// RatioPercent returns Ratio(x, y) expressed in percent, so that a result of
+// 120 reads "x is typically 120% of y". Errors are those of Ratio.
+func RatioPercent(x, y []float64, assumeSorted bool) (float64, error) {
+	ratio, err := Ratio(x, y, assumeSorted)
+	if err != nil {
+		return 0, err
+	}
+	return ratio * 100, nil
+}
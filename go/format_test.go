@@ -0,0 +1,62 @@
+package pragmastat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFormatPercent(t *testing.T) {
+	cases := []struct {
+		v        float64
+		decimals int
+		want     string
+	}{
+		{12.345, 1, "12.3%"},
+		{12.35, 1, "12.3%"}, // 12.35 is stored as 12.3499999...
+		{12.25, 1, "12.2%"}, // exact tie rounds half to even
+		{0.5, 0, "0%"},
+		{1.5, 0, "2%"},
+		{99.999, 2, "100.00%"},
+		{120, 0, "120%"},
+		{-7.456, 2, "-7.46%"},
+		{-0.04, 1, "0.0%"},
+		{math.Copysign(0, -1), 2, "0.00%"}, // the literal -0.0 is +0 in Go
+		{42.7, -3, "43%"},
+		{math.NaN(), 1, "NaN%"},
+	}
+	for _, c := range cases {
+		if got := FormatPercent(c.v, c.decimals); got != c.want {
+			t.Errorf("FormatPercent(%v, %d) = %q, want %q", c.v, c.decimals, got, c.want)
+		}
+	}
+}
+
+func TestRelSpreadPercent(t *testing.T) {
+	x := []float64{-2, -4, -6}
+	got, err := RelSpreadPercent(x, false)
+	if err != nil {
+		t.Fatalf("RelSpreadPercent: %v", err)
+	}
+	if !floatEquals(got, 50, 1e-9) {
+		t.Errorf("RelSpreadPercent = %v, want 50", got)
+	}
+
+	_, err = RelSpreadPercent([]float64{-1, 1}, false)
+	assertViolation(t, err, Domain, SubjectX)
+}
+
+func TestRatioPercent(t *testing.T) {
+	got, err := RatioPercent([]float64{12, 12, 12}, []float64{10, 10, 10}, false)
+	if err != nil {
+		t.Fatalf("RatioPercent: %v", err)
+	}
+	if !floatEquals(got, 120, 1e-9) {
+		t.Errorf("RatioPercent = %v, want 120", got)
+	}
+	if s := FormatPercent(got, 0); s != "120%" {
+		t.Errorf("FormatPercent(RatioPercent) = %q, want 120%%", s)
+	}
+
+	_, err = RatioPercent([]float64{1, 2}, []float64{-1, 2}, false)
+	assertViolation(t, err, Positivity, SubjectY)
+}
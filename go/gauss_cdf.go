@@ -38,3 +38,11 @@ func gaussCdf(x float64) float64 {
 	}
 	return (1.0 - z) / 2
 }
+
+// gaussQuantile is the inverse of the standard normal CDF. It goes through
+// math.Erfcinv rather than inverting gaussCdf, whose ~1e-7 accuracy is fine
+// for margin approximations but not for round-tripping quantiles. Returns
+// -Inf at p = 0 and +Inf at p = 1.
+func gaussQuantile(p float64) float64 {
+	return -math.Sqrt2 * math.Erfcinv(2*p)
+}
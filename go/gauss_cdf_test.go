@@ -0,0 +1,44 @@
+package pragmastat
+
+import (
+	"math"
+	"testing"
+)
+
+// TestGaussCdf pins gaussCdf (the single definition shared by the margin
+// approximations) against reference values of the standard normal CDF.
+// ACM Algorithm 209 is accurate to better than 1e-8 absolute.
+func TestGaussCdf(t *testing.T) {
+	cases := []struct {
+		x, want float64
+	}{
+		{0, 0.5},
+		{0.5, 0.6914624612740131},
+		{1, 0.8413447460685429},
+		{1.96, 0.9750021048517795},
+		{2.5, 0.9937903346742238},
+		{3, 0.9986501019683699},
+		{-1, 0.15865525393145707},
+		{-1.96, 0.024997895148220435},
+		{-3, 0.0013498980316300946},
+		{-6, 9.865876450376946e-10},
+	}
+	for _, c := range cases {
+		if got := gaussCdf(c.x); !floatEquals(got, c.want, 1e-8) {
+			t.Errorf("gaussCdf(%v) = %v, want %v", c.x, got, c.want)
+		}
+	}
+
+	// Symmetry and saturation.
+	for _, x := range []float64{0.1, 0.7, 1.3, 2.2, 4.5} {
+		if sum := gaussCdf(x) + gaussCdf(-x); !floatEquals(sum, 1, 1e-15) {
+			t.Errorf("gaussCdf(%v) + gaussCdf(%v) = %v, want 1", x, -x, sum)
+		}
+	}
+	if gaussCdf(7) != 1 || gaussCdf(-7) != 0 {
+		t.Errorf("gaussCdf(±7) = %v, %v; want 1, 0", gaussCdf(7), gaussCdf(-7))
+	}
+	if gaussCdf(math.Inf(1)) != 1 || gaussCdf(math.Inf(-1)) != 0 {
+		t.Error("gaussCdf must saturate at ±Inf")
+	}
+}
@@ -0,0 +1,103 @@
+package pragmastat
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// maxAutoBins caps the number of bins chosen by HistogramAuto so that a few
+// extreme outliers cannot blow up the allocation; it never exceeds len(x).
+const maxAutoBins = 1 << 16
+
+// Histogram counts x into bins equal-width bins spanning [min(x), max(x)].
+// It returns the per-bin counts and the bins+1 bin edges. Bins are closed on
+// the left and open on the right, except the last bin, which also includes
+// max(x); the counts therefore always sum to len(x). If all values are
+// equal, the range [v-0.5, v+0.5] is used instead.
+//
+// Returns a Validity error for empty or non-finite x and a plain error if
+// bins < 1.
+func Histogram(x []float64, bins int) ([]int, []float64, error) {
+	if err := checkValidity(x, SubjectX); err != nil {
+		return nil, nil, err
+	}
+	if bins < 1 {
+		return nil, nil, fmt.Errorf("bins must be positive, got %d", bins)
+	}
+	lo, hi := x[0], x[0]
+	for _, v := range x[1:] {
+		if v < lo {
+			lo = v
+		}
+		if v > hi {
+			hi = v
+		}
+	}
+	counts, edges := histogramImpl(x, lo, hi, bins)
+	return counts, edges, nil
+}
+
+// HistogramAuto is Histogram with the number of bins chosen by a robust
+// Freedman–Diaconis rule: the bin width is 2*Spread(x)/n^(1/3), with Spread
+// in place of the classical interquartile range. The number of bins is
+// ceil((max-min)/width), clamped to [1, min(len(x), 65536)].
+//
+// Returns a Validity error for empty or non-finite x and a Sparity error if
+// Spread(x) is zero (tie-dominant x), where the rule is undefined.
+func HistogramAuto(x []float64) ([]int, []float64, error) {
+	if err := checkValidity(x, SubjectX); err != nil {
+		return nil, nil, err
+	}
+	sorted := sortedOne(x, false)
+	spreadVal, err := spreadImpl(sorted, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	if spreadVal <= 0 {
+		return nil, nil, NewSparityError(SubjectX)
+	}
+	n := len(sorted)
+	lo, hi := sorted[0], sorted[n-1]
+	width := 2 * spreadVal / math.Cbrt(float64(n))
+
+	maxBins := n
+	if maxBins > maxAutoBins {
+		maxBins = maxAutoBins
+	}
+	bins := maxBins
+	if raw := math.Ceil((hi - lo) / width); raw < float64(maxBins) {
+		bins = int(raw)
+	}
+	if bins < 1 {
+		bins = 1
+	}
+	counts, edges := histogramImpl(x, lo, hi, bins)
+	return counts, edges, nil
+}
+
+// histogramImpl bins x into equal-width bins over [lo, hi]. Each value is
+// located by binary search over the edges, so a value lying exactly on an
+// edge is always counted in the bin that edge opens.
+func histogramImpl(x []float64, lo, hi float64, bins int) ([]int, []float64) {
+	if lo == hi {
+		lo, hi = lo-0.5, hi+0.5
+	}
+	edges := make([]float64, bins+1)
+	for i := range edges {
+		t := float64(i) / float64(bins)
+		edges[i] = (1-t)*lo + t*hi
+	}
+	edges[0], edges[bins] = lo, hi
+
+	counts := make([]int, bins)
+	for _, v := range x {
+		// First edge strictly greater than v, minus one, is v's bin.
+		i := sort.Search(len(edges), func(j int) bool { return edges[j] > v }) - 1
+		if i >= bins {
+			i = bins - 1
+		}
+		counts[i]++
+	}
+	return counts, edges
+}
@@ -0,0 +1,199 @@
+package pragmastat
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// HistogramDist is the piecewise-uniform distribution described by binned
+// data, e.g. a monitoring latency histogram: a bin is chosen with
+// probability proportional to its count and the value is uniform within it.
+type HistogramDist struct {
+	edges []float64 // bin edges, strictly increasing
+	probs []float64 // normalized counts, one per bin
+	cum   []float64 // running sums of probs
+	alias *AliasTable
+}
+
+// NewHistogramDistribution creates a distribution from len(counts)+1 bin
+// edges and the per-bin counts. Bins with zero count have zero density.
+// Returns a *ParameterError if counts is empty, the lengths do not match,
+// the edges are not finite and strictly increasing, or the counts are not
+// finite and non-negative with a positive sum.
+func NewHistogramDistribution(edges []float64, counts []float64) (*HistogramDist, error) {
+	if len(counts) == 0 {
+		return nil, newParameterError("HistogramDist", "counts", "counts cannot be empty")
+	}
+	if len(edges) != len(counts)+1 {
+		return nil, newParameterError("HistogramDist", "edges", "edges length must be counts length + 1")
+	}
+	for i, e := range edges {
+		if math.IsNaN(e) || math.IsInf(e, 0) {
+			return nil, newParameterError("HistogramDist", "edges", fmt.Sprintf("edges[%d] must be finite, got %v", i, e))
+		}
+		if i > 0 && !(e > edges[i-1]) {
+			return nil, newParameterError("HistogramDist", "edges", "edges must be strictly increasing")
+		}
+	}
+	total := 0.0
+	for i, c := range counts {
+		if math.IsNaN(c) || math.IsInf(c, 0) || c < 0 {
+			return nil, newParameterError("HistogramDist", "counts", fmt.Sprintf("counts[%d] must be finite and non-negative, got %v", i, c))
+		}
+		total += c
+	}
+	if !(total > 0) || math.IsInf(total, 0) {
+		return nil, newParameterError("HistogramDist", "counts", "counts must have a positive, finite sum")
+	}
+
+	h := &HistogramDist{
+		edges: append([]float64(nil), edges...),
+		probs: make([]float64, len(counts)),
+		cum:   make([]float64, len(counts)),
+	}
+	sum := 0.0
+	for i, c := range counts {
+		h.probs[i] = c / total
+		sum += h.probs[i]
+		h.cum[i] = sum
+	}
+	alias, err := NewAliasTable(h.probs)
+	if err != nil {
+		return nil, newParameterError("HistogramDist", "counts", err.Error())
+	}
+	h.alias = alias
+	return h, nil
+}
+
+// Edges returns a copy of the bin edges.
+func (h *HistogramDist) Edges() []float64 {
+	return append([]float64(nil), h.edges...)
+}
+
+// Probs returns a copy of the normalized bin probabilities.
+func (h *HistogramDist) Probs() []float64 {
+	return append([]float64(nil), h.probs...)
+}
+
+// Sample picks a bin from the alias table and draws uniformly within it,
+// consuming a uniform index, a uniform float64 for the alias coin, and one
+// more uniform float64 for the position.
+func (h *HistogramDist) Sample(rng *Rng) float64 {
+	k := h.alias.Draw(rng)
+	lo, hi := h.edges[k], h.edges[k+1]
+	return lo + rng.UniformFloat64()*(hi-lo)
+}
+
+// Samples generates multiple samples from the histogram distribution.
+func (h *HistogramDist) Samples(rng *Rng, count int) []float64 {
+	return sampleN(h, rng, count)
+}
+
+// SamplesInto fills dst with samples from the histogram distribution without allocating.
+func (h *HistogramDist) SamplesInto(rng *Rng, dst []float64) {
+	fillSamples(h, rng, dst)
+}
+
+// DrawsPerSample returns 3: the two alias-table draws and the position
+// within the bin.
+func (h *HistogramDist) DrawsPerSample() int {
+	return 3
+}
+
+// bin returns the index of the bin containing x, for edges[0] <= x < the
+// last edge.
+func (h *HistogramDist) bin(x float64) int {
+	return sort.Search(len(h.edges), func(i int) bool { return h.edges[i] > x }) - 1
+}
+
+// Pdf returns the bin probability divided by the bin width, and 0 outside
+// the edges.
+func (h *HistogramDist) Pdf(x float64) float64 {
+	if !(x >= h.edges[0] && x < h.edges[len(h.edges)-1]) {
+		return 0
+	}
+	k := h.bin(x)
+	return h.probs[k] / (h.edges[k+1] - h.edges[k])
+}
+
+// Cdf returns P(X <= x), linear within each bin.
+func (h *HistogramDist) Cdf(x float64) float64 {
+	switch {
+	case math.IsNaN(x):
+		return math.NaN()
+	case x <= h.edges[0]:
+		return 0
+	case x >= h.edges[len(h.edges)-1]:
+		return 1
+	}
+	k := h.bin(x)
+	below := 0.0
+	if k > 0 {
+		below = h.cum[k-1]
+	}
+	return math.Min(below+h.probs[k]*(x-h.edges[k])/(h.edges[k+1]-h.edges[k]), 1)
+}
+
+// Quantile inverts the piecewise-linear Cdf, interpolating within the bin
+// where it crosses p; zero-count bins are skipped. Quantile(0) is the lower
+// edge of the first non-empty bin and Quantile(1) the upper edge of the last
+// one. p outside [0, 1] or NaN gives NaN.
+func (h *HistogramDist) Quantile(p float64) float64 {
+	if invalidProbability(p) {
+		return math.NaN()
+	}
+	k := sort.Search(len(h.cum), func(i int) bool { return h.cum[i] > p })
+	if k == len(h.cum) {
+		last := len(h.probs) - 1
+		for h.probs[last] == 0 {
+			last--
+		}
+		return h.edges[last+1]
+	}
+	below := 0.0
+	if k > 0 {
+		below = h.cum[k-1]
+	}
+	frac := math.Max(0, math.Min((p-below)/h.probs[k], 1))
+	return h.edges[k] + frac*(h.edges[k+1]-h.edges[k])
+}
+
+// TrueCenter returns the population Center, computed numerically from Cdf
+// and Quantile.
+func (h *HistogramDist) TrueCenter() float64 {
+	return numericTrueCenter(h)
+}
+
+// TrueSpread returns the population Spread, computed numerically from Cdf
+// and Quantile.
+func (h *HistogramDist) TrueSpread() float64 {
+	return numericTrueSpread(h)
+}
+
+// TrueMean returns the population mean, the probability-weighted sum of the
+// bin midpoints.
+func (h *HistogramDist) TrueMean() float64 {
+	var mean float64
+	for k, p := range h.probs {
+		mean += p * (h.edges[k] + h.edges[k+1]) / 2
+	}
+	return mean
+}
+
+// TrueVariance returns the population variance: the mixture of the bins'
+// second moments (lo² + lo·hi + hi²)/3 minus the squared mean.
+func (h *HistogramDist) TrueVariance() float64 {
+	var second float64
+	for k, p := range h.probs {
+		lo, hi := h.edges[k], h.edges[k+1]
+		second += p * (lo*lo + lo*hi + hi*hi) / 3
+	}
+	mean := h.TrueMean()
+	return math.Max(second-mean*mean, 0)
+}
+
+// TrueStdDev returns the population standard deviation, √TrueVariance.
+func (h *HistogramDist) TrueStdDev() float64 {
+	return math.Sqrt(h.TrueVariance())
+}
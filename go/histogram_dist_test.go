@@ -0,0 +1,127 @@
+package pragmastat
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// newTestHistogram has bins [0, 1), [1, 3), [3, 4), [4, 6) with
+// probabilities {0.25, 0, 0.125, 0.625}.
+func newTestHistogram(t *testing.T) *HistogramDist {
+	t.Helper()
+	h, err := NewHistogramDistribution([]float64{0, 1, 3, 4, 6}, []float64{2, 0, 1, 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+func TestHistogramDistFrequencies(t *testing.T) {
+	h := newTestHistogram(t)
+	const n = 100_000
+	edges := h.Edges()
+	counts := make([]float64, len(edges)-1)
+	for _, x := range h.Samples(NewRngFromString("histogram-frequencies"), n) {
+		k := h.bin(x)
+		if x < edges[0] || x >= edges[len(edges)-1] {
+			t.Fatalf("sample %v outside the edges", x)
+		}
+		counts[k]++
+	}
+	for k, p := range []float64{0.25, 0, 0.125, 0.625} {
+		if got := counts[k] / n; math.Abs(got-p) > 5*math.Sqrt(p*(1-p)/n) {
+			t.Errorf("frequency of bin %d = %v, want %v", k, got, p)
+		}
+	}
+}
+
+func TestHistogramDistCdfQuantile(t *testing.T) {
+	h := newTestHistogram(t)
+	for _, c := range []struct{ x, cdf, pdf float64 }{
+		{-1, 0, 0}, {0, 0, 0.25}, {0.5, 0.125, 0.25}, {2, 0.25, 0}, {3.5, 0.3125, 0.125},
+		{5, 0.6875, 0.3125}, {6, 1, 0}, {7, 1, 0},
+	} {
+		if got := h.Cdf(c.x); !floatEquals(got, c.cdf, 1e-15) {
+			t.Errorf("Cdf(%v) = %v, want %v", c.x, got, c.cdf)
+		}
+		if got := h.Pdf(c.x); !floatEquals(got, c.pdf, 1e-15) {
+			t.Errorf("Pdf(%v) = %v, want %v", c.x, got, c.pdf)
+		}
+	}
+	for _, x := range []float64{0.25, 0.9, 3.2, 4, 5.9} {
+		if got := h.Quantile(h.Cdf(x)); !floatEquals(got, x, 1e-12) {
+			t.Errorf("Quantile(Cdf(%v)) = %v", x, got)
+		}
+	}
+	// The empty bin [1, 3) is skipped: Cdf is flat there.
+	if got := h.Quantile(0.25); got != 3 {
+		t.Errorf("Quantile(0.25) = %v, want 3", got)
+	}
+	if h.Quantile(0) != 0 || h.Quantile(1) != 6 || !math.IsNaN(h.Quantile(-0.1)) {
+		t.Errorf("Quantile(0), Quantile(1), Quantile(-0.1) = %v, %v, %v", h.Quantile(0), h.Quantile(1), h.Quantile(-0.1))
+	}
+}
+
+// TestHistogramDistTrueValues compares Center and Spread of a large sample
+// with the numeric population values, and the moments with a symmetric case.
+func TestHistogramDistTrueValues(t *testing.T) {
+	h := newTestHistogram(t)
+	x := h.Samples(NewRngFromString("histogram-center"), 100_000)
+	if center, _ := Center(x, false); math.Abs(center-h.TrueCenter()) > 0.03 {
+		t.Errorf("Center of a large sample = %v, want %v", center, h.TrueCenter())
+	}
+	if spread, _ := Spread(x, false); math.Abs(spread-h.TrueSpread()) > 0.03 {
+		t.Errorf("Spread of a large sample = %v, want %v", spread, h.TrueSpread())
+	}
+
+	symmetric, err := NewHistogramDistribution([]float64{0, 1, 2, 3}, []float64{1, 2, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := symmetric.TrueCenter(); !floatEquals(got, 1.5, 1e-9) {
+		t.Errorf("symmetric TrueCenter = %v, want 1.5", got)
+	}
+	if got := symmetric.TrueMean(); got != 1.5 {
+		t.Errorf("symmetric TrueMean = %v, want 1.5", got)
+	}
+	// E[X²] = (1/4)(1/3) + (1/2)(7/3) + (1/4)(19/3) = 17/6.
+	if got, want := symmetric.TrueVariance(), 17.0/6-2.25; !floatEquals(got, want, 1e-12) {
+		t.Errorf("symmetric TrueVariance = %v, want %v", got, want)
+	}
+}
+
+func TestHistogramDistDeterministic(t *testing.T) {
+	h := newTestHistogram(t)
+	a := h.Samples(NewRngFromString("histogram-seed"), 100)
+	b := h.Samples(NewRngFromString("histogram-seed"), 100)
+	if !reflect.DeepEqual(a, b) {
+		t.Error("samples differ for the same seed")
+	}
+}
+
+func TestHistogramDistParameterErrors(t *testing.T) {
+	for _, c := range []struct {
+		name      string
+		edges     []float64
+		counts    []float64
+		parameter string
+	}{
+		{"empty", []float64{0}, nil, "counts"},
+		{"length mismatch", []float64{0, 1}, []float64{1, 1}, "edges"},
+		{"NaN edge", []float64{0, math.NaN()}, []float64{1}, "edges"},
+		{"infinite edge", []float64{0, math.Inf(1)}, []float64{1}, "edges"},
+		{"repeated edge", []float64{0, 1, 1}, []float64{1, 1}, "edges"},
+		{"decreasing edges", []float64{0, 2, 1}, []float64{1, 1}, "edges"},
+		{"negative count", []float64{0, 1, 2}, []float64{1, -1}, "counts"},
+		{"NaN count", []float64{0, 1, 2}, []float64{1, math.NaN()}, "counts"},
+		{"zero sum", []float64{0, 1, 2}, []float64{0, 0}, "counts"},
+	} {
+		_, err := NewHistogramDistribution(c.edges, c.counts)
+		var pe *ParameterError
+		if !errors.As(err, &pe) || pe.Distribution != "HistogramDist" || pe.Parameter != c.parameter {
+			t.Errorf("%s: got %v, want HistogramDist.%s error", c.name, err, c.parameter)
+		}
+	}
+}
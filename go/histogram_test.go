@@ -0,0 +1,131 @@
+package pragmastat
+
+import (
+	"math"
+	"testing"
+)
+
+func sumCounts(counts []int) int {
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+	return total
+}
+
+func TestHistogram(t *testing.T) {
+	x := []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 10}
+	counts, edges, err := Histogram(x, 5)
+	if err != nil {
+		t.Fatalf("Histogram: %v", err)
+	}
+	wantCounts := []int{2, 2, 2, 2, 2}
+	wantEdges := []float64{0, 2, 4, 6, 8, 10}
+	for i := range wantCounts {
+		if counts[i] != wantCounts[i] {
+			t.Errorf("counts[%d] = %d, want %d", i, counts[i], wantCounts[i])
+		}
+	}
+	for i := range wantEdges {
+		if !floatEquals(edges[i], wantEdges[i], 1e-12) {
+			t.Errorf("edges[%d] = %v, want %v", i, edges[i], wantEdges[i])
+		}
+	}
+
+	// Values on an interior edge open the next bin; max(x) closes the last one.
+	counts, _, err = Histogram([]float64{0, 1, 1, 2}, 2)
+	if err != nil {
+		t.Fatalf("Histogram: %v", err)
+	}
+	if counts[0] != 1 || counts[1] != 3 {
+		t.Errorf("edge handling: counts = %v, want [1 3]", counts)
+	}
+
+	counts, edges, err = Histogram([]float64{3, 3, 3}, 4)
+	if err != nil {
+		t.Fatalf("Histogram: %v", err)
+	}
+	if edges[0] != 2.5 || edges[4] != 3.5 || sumCounts(counts) != 3 {
+		t.Errorf("constant input: counts = %v, edges = %v", counts, edges)
+	}
+}
+
+func TestHistogramCountsSumToN(t *testing.T) {
+	rng := NewRngFromSeed(invarianceSeed)
+	dist := NewAdditive(0, 1)
+	for _, n := range []int{1, 2, 7, 100, 1000} {
+		x := make([]float64, n)
+		for i := range x {
+			x[i] = dist.Sample(rng)
+		}
+		for _, bins := range []int{1, 3, 10, 64} {
+			counts, edges, err := Histogram(x, bins)
+			if err != nil {
+				t.Fatalf("Histogram(n=%d, bins=%d): %v", n, bins, err)
+			}
+			if len(counts) != bins || len(edges) != bins+1 {
+				t.Fatalf("n=%d bins=%d: got %d counts and %d edges", n, bins, len(counts), len(edges))
+			}
+			if got := sumCounts(counts); got != n {
+				t.Errorf("n=%d bins=%d: counts sum to %d", n, bins, got)
+			}
+		}
+	}
+}
+
+func TestHistogramAuto(t *testing.T) {
+	rng := NewRngFromSeed(invarianceSeed)
+	dist := NewAdditive(0, 1)
+	n := 1000
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = dist.Sample(rng)
+	}
+	counts, edges, err := HistogramAuto(x)
+	if err != nil {
+		t.Fatalf("HistogramAuto: %v", err)
+	}
+	if got := sumCounts(counts); got != n {
+		t.Errorf("counts sum to %d, want %d", got, n)
+	}
+	// Width 2*Spread/n^(1/3) ≈ 0.19 over a range of about 6.5 standard
+	// deviations gives roughly 35 bins.
+	if len(counts) < 20 || len(counts) > 50 {
+		t.Errorf("HistogramAuto chose %d bins for a normal sample of %d", len(counts), n)
+	}
+	spreadVal, _ := Spread(x, false)
+	width := edges[1] - edges[0]
+	if want := 2 * spreadVal / math.Cbrt(float64(n)); width > want || width < want/2 {
+		t.Errorf("bin width = %v, want about %v", width, want)
+	}
+
+	// An extreme outlier cannot produce more bins than observations.
+	x[0] = 1e300
+	counts, _, err = HistogramAuto(x)
+	if err != nil {
+		t.Fatalf("HistogramAuto: %v", err)
+	}
+	if len(counts) > n {
+		t.Errorf("HistogramAuto chose %d bins for %d values", len(counts), n)
+	}
+}
+
+func TestHistogramErrors(t *testing.T) {
+	_, _, err := Histogram(nil, 3)
+	assertViolation(t, err, Validity, SubjectX)
+
+	_, _, err = Histogram([]float64{1, math.Inf(1)}, 3)
+	assertViolation(t, err, Validity, SubjectX)
+
+	if _, _, err = Histogram([]float64{1, 2}, 0); err == nil {
+		t.Error("expected error for bins = 0")
+	} else if _, ok := err.(*AssumptionError); ok {
+		t.Errorf("bins = 0 must be a plain error, got %v", err)
+	}
+
+	_, _, err = HistogramAuto([]float64{})
+	assertViolation(t, err, Validity, SubjectX)
+
+	_, _, err = HistogramAuto([]float64{4, 4, 4, 4, 5})
+	assertViolation(t, err, Sparity, SubjectX)
+}
@@ -0,0 +1,120 @@
+package pragmastat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCenterTestDecision(t *testing.T) {
+	x := []float64{3.1, 1.2, 5.5, 4.0, 2.8, 6.3, 3.9, 4.4, 2.2, 5.0}
+	center, err := Center(x, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := CenterBounds(x, 0.05, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []struct {
+		mu0    float64
+		reject bool
+	}{
+		{center, false},
+		{want.Lower, false},
+		{want.Upper, false},
+		{want.Lower - 1e-9, true},
+		{100, true},
+		{math.Inf(-1), true},
+	} {
+		reject, bounds, err := CenterTest(x, c.mu0, 0.05, false)
+		if err != nil {
+			t.Fatalf("mu0 = %v: %v", c.mu0, err)
+		}
+		if reject != c.reject {
+			t.Errorf("mu0 = %v: reject = %v, want %v", c.mu0, reject, c.reject)
+		}
+		if bounds != want {
+			t.Errorf("mu0 = %v: bounds = %v, want %v", c.mu0, bounds, want)
+		}
+	}
+}
+
+func TestCenterTestErrors(t *testing.T) {
+	x := []float64{1, 2, 3, 4, 5}
+	// With n = 5 the minimum achievable misrate is 2/2^5 = 0.0625.
+	_, _, err := CenterTest(x, 3, 0.01, false)
+	assertViolation(t, err, Domain, SubjectMisrate)
+
+	_, _, err = CenterTest([]float64{1, math.NaN()}, 0, 0.5, false)
+	assertViolation(t, err, Validity, SubjectX)
+
+	if _, _, err := CenterTest(x, math.NaN(), 0.5, false); err == nil {
+		t.Error("NaN mu0: expected an error")
+	}
+}
+
+// TestCenterTestFalseRejections checks that under a symmetric null the
+// rejection rate does not exceed misrate beyond sampling noise.
+func TestCenterTestFalseRejections(t *testing.T) {
+	const (
+		n            = 20
+		misrate      = 0.1
+		replications = 2000
+	)
+	d := NewAdditive(5, 2)
+	rng := NewRngFromString("center-test-null")
+	rejections := 0
+	for r := 0; r < replications; r++ {
+		reject, _, err := CenterTest(d.Samples(rng, n), 5, misrate, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reject {
+			rejections++
+		}
+	}
+	rate := float64(rejections) / replications
+	if limit := misrate + 3*math.Sqrt(misrate*(1-misrate)/replications); rate > limit {
+		t.Errorf("false rejection rate %v, want <= %v", rate, limit)
+	}
+}
+
+func TestShiftTestDecision(t *testing.T) {
+	rng := NewRngFromString("shift-test")
+	x := NewAdditive(10, 1).Samples(rng, 30)
+	shifted := NewAdditive(0, 1).Samples(rng, 30)
+	overlapping := NewAdditive(10, 1).Samples(rng, 30)
+
+	reject, bounds, err := ShiftTest(x, shifted, 0, 0.01, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reject || bounds.Lower <= 0 {
+		t.Errorf("clearly shifted: reject = %v, bounds %v", reject, bounds)
+	}
+	if reject, _, _ := ShiftTest(x, shifted, 10, 0.01, false); reject {
+		t.Errorf("clearly shifted, delta0 = 10: rejected, bounds %v", bounds)
+	}
+
+	reject, bounds, err = ShiftTest(x, overlapping, 0, 0.01, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := ShiftBounds(x, overlapping, 0.01, false)
+	if reject || bounds != want {
+		t.Errorf("overlapping: reject = %v, bounds %v, want false, %v", reject, bounds, want)
+	}
+}
+
+func TestShiftTestErrors(t *testing.T) {
+	x, y := []float64{1, 2, 3}, []float64{4, 5, 6}
+	_, _, err := ShiftTest(x, y, 0, 1e-6, false)
+	assertViolation(t, err, Domain, SubjectMisrate)
+
+	_, _, err = ShiftTest(x, nil, 0, 0.5, false)
+	assertViolation(t, err, Validity, SubjectY)
+
+	if _, _, err := ShiftTest(x, y, math.NaN(), 0.5, false); err == nil {
+		t.Error("NaN delta0: expected an error")
+	}
+}
@@ -1,25 +1,10 @@
 package pragmastat
 
 import (
-	"math"
 	"sort"
 	"testing"
 )
 
-// floatEquals checks if two float64 values are approximately equal
-func floatEquals(a, b, epsilon float64) bool {
-	if math.IsInf(a, 1) && math.IsInf(b, 1) {
-		return true
-	}
-	if math.IsInf(a, -1) && math.IsInf(b, -1) {
-		return true
-	}
-	if math.IsNaN(a) && math.IsNaN(b) {
-		return true
-	}
-	return math.Abs(a-b) < epsilon
-}
-
 const invarianceSeed int64 = 1729
 const invarianceTolerance float64 = 1e-9
 
@@ -0,0 +1,73 @@
+package pragmastat
+
+import (
+	"fmt"
+	"sort"
+)
+
+// KFold partitions the indices 0..n-1 into k disjoint folds for
+// cross-validation-style resampling. The indices are shuffled with
+// Perm(rng, n) and cut into k contiguous blocks, so fold sizes differ by at
+// most one; each fold is returned in ascending order. The same seed always
+// yields the same folds.
+//
+// Returns an error unless 2 <= k <= n.
+func KFold(rng *Rng, n, k int) ([][]int, error) {
+	if err := checkFolds(n, k); err != nil {
+		return nil, err
+	}
+	perm := Perm(rng, n)
+	folds := make([][]int, k)
+	for i := range folds {
+		fold := perm[i*n/k : (i+1)*n/k]
+		sort.Ints(fold)
+		folds[i] = fold
+	}
+	return folds, nil
+}
+
+// StratifiedKFold is KFold preserving label proportions: every fold receives
+// each label's indices in numbers that differ by at most one across folds.
+// Labels are visited in ascending order; each label's indices are shuffled
+// and dealt round-robin, continuing from fold to fold across labels, so fold
+// sizes also differ by at most one. Each fold is returned in ascending order.
+//
+// Returns an error unless 2 <= k <= len(labels).
+func StratifiedKFold(rng *Rng, labels []int, k int) ([][]int, error) {
+	n := len(labels)
+	if err := checkFolds(n, k); err != nil {
+		return nil, err
+	}
+	groups := make(map[int][]int)
+	for i, label := range labels {
+		groups[label] = append(groups[label], i)
+	}
+	keys := make([]int, 0, len(groups))
+	for label := range groups {
+		keys = append(keys, label)
+	}
+	sort.Ints(keys)
+
+	folds := make([][]int, k)
+	next := 0
+	for _, label := range keys {
+		group := groups[label]
+		ShuffleInPlace(rng, group)
+		for _, idx := range group {
+			folds[next] = append(folds[next], idx)
+			next = (next + 1) % k
+		}
+	}
+	for _, fold := range folds {
+		sort.Ints(fold)
+	}
+	return folds, nil
+}
+
+// checkFolds validates the fold count shared by KFold and StratifiedKFold.
+func checkFolds(n, k int) error {
+	if k < 2 || k > n {
+		return fmt.Errorf("k must satisfy 2 <= k <= n (n = %d), got %d", n, k)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package pragmastat
+
+import (
+	"reflect"
+	"testing"
+)
+
+// checkPartition verifies that folds are disjoint, cover 0..n-1, and have
+// sizes differing by at most one.
+func checkPartition(t *testing.T, folds [][]int, n, k int) {
+	t.Helper()
+	if len(folds) != k {
+		t.Fatalf("got %d folds, want %d", len(folds), k)
+	}
+	seen := make([]bool, n)
+	minSize, maxSize := n, 0
+	for _, fold := range folds {
+		if len(fold) < minSize {
+			minSize = len(fold)
+		}
+		if len(fold) > maxSize {
+			maxSize = len(fold)
+		}
+		for _, idx := range fold {
+			if idx < 0 || idx >= n || seen[idx] {
+				t.Fatalf("index %d is out of range or appears twice", idx)
+			}
+			seen[idx] = true
+		}
+	}
+	for idx, ok := range seen {
+		if !ok {
+			t.Fatalf("index %d is missing", idx)
+		}
+	}
+	if maxSize-minSize > 1 {
+		t.Errorf("fold sizes range from %d to %d", minSize, maxSize)
+	}
+}
+
+func TestKFold(t *testing.T) {
+	for _, c := range []struct{ n, k int }{{2, 2}, {10, 2}, {10, 3}, {17, 5}, {100, 10}, {7, 7}} {
+		folds, err := KFold(NewRngFromString("kfold"), c.n, c.k)
+		if err != nil {
+			t.Fatalf("n=%d, k=%d: %v", c.n, c.k, err)
+		}
+		checkPartition(t, folds, c.n, c.k)
+		again, _ := KFold(NewRngFromString("kfold"), c.n, c.k)
+		if !reflect.DeepEqual(folds, again) {
+			t.Errorf("n=%d, k=%d: folds are not deterministic", c.n, c.k)
+		}
+	}
+	for _, c := range []struct{ n, k int }{{10, 1}, {10, 0}, {3, 4}, {0, 2}} {
+		if _, err := KFold(NewRngFromSeed(1), c.n, c.k); err == nil {
+			t.Errorf("n=%d, k=%d: expected an error", c.n, c.k)
+		}
+	}
+}
+
+func TestStratifiedKFold(t *testing.T) {
+	// 30 zeros, 12 ones, 5 twos, interleaved.
+	var labels []int
+	for i := 0; i < 30; i++ {
+		labels = append(labels, 0)
+		if i < 12 {
+			labels = append(labels, 1)
+		}
+		if i < 5 {
+			labels = append(labels, 2)
+		}
+	}
+	const k = 4
+	folds, err := StratifiedKFold(NewRngFromString("stratified-kfold"), labels, k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkPartition(t, folds, len(labels), k)
+
+	for label, total := range map[int]int{0: 30, 1: 12, 2: 5} {
+		lo, hi := total/k, (total+k-1)/k
+		for f, fold := range folds {
+			count := 0
+			for _, idx := range fold {
+				if labels[idx] == label {
+					count++
+				}
+			}
+			if count < lo || count > hi {
+				t.Errorf("fold %d has %d of label %d, want %d..%d", f, count, label, lo, hi)
+			}
+		}
+	}
+
+	again, _ := StratifiedKFold(NewRngFromString("stratified-kfold"), labels, k)
+	if !reflect.DeepEqual(folds, again) {
+		t.Error("folds are not deterministic")
+	}
+	if _, err := StratifiedKFold(NewRngFromSeed(1), []int{1, 2}, 3); err == nil {
+		t.Error("expected an error for k > n")
+	}
+}
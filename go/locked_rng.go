@@ -0,0 +1,300 @@
+package pragmastat
+
+import "sync"
+
+// LockedRng wraps an Rng with a mutex so that one generator can be shared by
+// several goroutines. Every method holds the lock for the duration of the
+// underlying Rng call, so concurrent draws never race on the state.
+//
+// Locking serializes all callers, and the interleaving of draws between
+// goroutines is not reproducible even though each draw is. When parallel
+// workers need both speed and reproducibility, prefer one generator per
+// worker from NewRngPerG.
+type LockedRng struct {
+	mu    sync.Mutex
+	inner *Rng
+}
+
+// NewLockedRng wraps inner. The caller must not use inner directly afterwards.
+// Panics if inner is nil.
+func NewLockedRng(inner *Rng) *LockedRng {
+	if inner == nil {
+		panic("locked rng: inner must not be nil")
+	}
+	return &LockedRng{inner: inner}
+}
+
+// Do calls f with the wrapped generator while holding the lock, for compound
+// operations such as RngShuffle or Perm that take a *Rng. f must not retain r.
+func (l *LockedRng) Do(f func(r *Rng)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	f(l.inner)
+}
+
+// UniformFloat64 calls Rng.UniformFloat64 under the lock.
+func (l *LockedRng) UniformFloat64() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inner.UniformFloat64()
+}
+
+// UniformFloat64Range calls Rng.UniformFloat64Range under the lock.
+func (l *LockedRng) UniformFloat64Range(min, max float64) float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inner.UniformFloat64Range(min, max)
+}
+
+// UniformAntithetic calls Rng.UniformAntithetic under the lock.
+func (l *LockedRng) UniformAntithetic() (float64, float64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inner.UniformAntithetic()
+}
+
+// UniformFloat32 calls Rng.UniformFloat32 under the lock.
+func (l *LockedRng) UniformFloat32() float32 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inner.UniformFloat32()
+}
+
+// UniformFloat32Range calls Rng.UniformFloat32Range under the lock.
+func (l *LockedRng) UniformFloat32Range(min, max float32) float32 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inner.UniformFloat32Range(min, max)
+}
+
+// UniformInt64 calls Rng.UniformInt64 under the lock.
+func (l *LockedRng) UniformInt64(min, max int64) int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inner.UniformInt64(min, max)
+}
+
+// UniformInt64Unbiased calls Rng.UniformInt64Unbiased under the lock.
+func (l *LockedRng) UniformInt64Unbiased(min, max int64) int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inner.UniformInt64Unbiased(min, max)
+}
+
+// UniformInt32 calls Rng.UniformInt32 under the lock.
+func (l *LockedRng) UniformInt32(min, max int32) int32 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inner.UniformInt32(min, max)
+}
+
+// UniformInt16 calls Rng.UniformInt16 under the lock.
+func (l *LockedRng) UniformInt16(min, max int16) int16 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inner.UniformInt16(min, max)
+}
+
+// UniformInt8 calls Rng.UniformInt8 under the lock.
+func (l *LockedRng) UniformInt8(min, max int8) int8 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inner.UniformInt8(min, max)
+}
+
+// UniformIntN calls Rng.UniformIntN under the lock.
+func (l *LockedRng) UniformIntN(min, max int) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inner.UniformIntN(min, max)
+}
+
+// UniformUint64 calls Rng.UniformUint64 under the lock.
+func (l *LockedRng) UniformUint64(min, max uint64) uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inner.UniformUint64(min, max)
+}
+
+// UniformUint32 calls Rng.UniformUint32 under the lock.
+func (l *LockedRng) UniformUint32(min, max uint32) uint32 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inner.UniformUint32(min, max)
+}
+
+// UniformUint16 calls Rng.UniformUint16 under the lock.
+func (l *LockedRng) UniformUint16(min, max uint16) uint16 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inner.UniformUint16(min, max)
+}
+
+// UniformUint8 calls Rng.UniformUint8 under the lock.
+func (l *LockedRng) UniformUint8(min, max uint8) uint8 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inner.UniformUint8(min, max)
+}
+
+// UniformUintN calls Rng.UniformUintN under the lock.
+func (l *LockedRng) UniformUintN(min, max uint) uint {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inner.UniformUintN(min, max)
+}
+
+// UniformBool calls Rng.UniformBool under the lock.
+func (l *LockedRng) UniformBool() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inner.UniformBool()
+}
+
+// Fill calls Rng.Fill under the lock.
+func (l *LockedRng) Fill(p []byte) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inner.Fill(p)
+}
+
+// Normal calls Rng.Normal under the lock.
+func (l *LockedRng) Normal(mean, stdDev float64) float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inner.Normal(mean, stdDev)
+}
+
+// Exponential calls Rng.Exponential under the lock.
+func (l *LockedRng) Exponential(rate float64) float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inner.Exponential(rate)
+}
+
+// Bernoulli calls Rng.Bernoulli under the lock.
+func (l *LockedRng) Bernoulli(p float64) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inner.Bernoulli(p)
+}
+
+// Binomial calls Rng.Binomial under the lock.
+func (l *LockedRng) Binomial(n int, p float64) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inner.Binomial(n, p)
+}
+
+// Poisson calls Rng.Poisson under the lock.
+func (l *LockedRng) Poisson(lambda float64) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inner.Poisson(lambda)
+}
+
+// SampleSlice calls Rng.SampleSlice under the lock.
+func (l *LockedRng) SampleSlice(x []float64, k int) []float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inner.SampleSlice(x, k)
+}
+
+// ResampleSlice calls Rng.ResampleSlice under the lock.
+func (l *LockedRng) ResampleSlice(x []float64, k int) []float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inner.ResampleSlice(x, k)
+}
+
+// ShuffleSlice calls Rng.ShuffleSlice under the lock.
+func (l *LockedRng) ShuffleSlice(x []float64) []float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inner.ShuffleSlice(x)
+}
+
+// Jump calls Rng.Jump under the lock.
+func (l *LockedRng) Jump() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inner.Jump()
+}
+
+// LongJump calls Rng.LongJump under the lock.
+func (l *LockedRng) LongJump() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inner.LongJump()
+}
+
+// Split calls Rng.Split under the lock.
+func (l *LockedRng) Split(label string) *Rng {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inner.Split(label)
+}
+
+// NewJumped calls Rng.NewJumped under the lock.
+func (l *LockedRng) NewJumped(k int) *Rng {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inner.NewJumped(k)
+}
+
+// NewRngPerG returns workers generators for parallel use, one per goroutine,
+// derived from seed. Generator i is NewRngFromString(seed) advanced by i
+// jumps (i·2^128 steps), so the streams never overlap and each worker's
+// output depends only on seed and its index, not on scheduling.
+// Panics if workers is negative.
+func NewRngPerG(seed string, workers int) []*Rng {
+	if workers < 0 {
+		panic("workers must be non-negative")
+	}
+	result := make([]*Rng, workers)
+	base := NewRngFromString(seed)
+	for i := range result {
+		result[i] = base.NewJumped(0)
+		base.Jump()
+	}
+	return result
+}
+
+// globalRng backs SeedGlobal and the Global* functions. It starts from
+// system entropy, like NewRng.
+var globalRng = NewLockedRng(NewRng())
+
+// SeedGlobal reseeds the generator behind GlobalShuffle, GlobalSample, and
+// GlobalResample so that subsequent calls replay NewRngFromSeed(seed).
+// It is safe to call concurrently with the Global* functions, but the
+// sequence is only reproducible when the calls themselves are sequential.
+func SeedGlobal(seed int64) {
+	globalRng.mu.Lock()
+	defer globalRng.mu.Unlock()
+	globalRng.inner = NewRngFromSeed(seed)
+}
+
+// GlobalShuffle is RngShuffle on the package-level generator, for quick
+// scripts that do not want to construct an Rng. Safe for concurrent use;
+// panics like RngShuffle.
+func GlobalShuffle[T any](x []T) []T {
+	var result []T
+	globalRng.Do(func(r *Rng) { result = RngShuffle(r, x) })
+	return result
+}
+
+// GlobalSample is RngSample on the package-level generator. Safe for
+// concurrent use; panics like RngSample.
+func GlobalSample[T any](x []T, k int) []T {
+	var result []T
+	globalRng.Do(func(r *Rng) { result = RngSample(r, x, k) })
+	return result
+}
+
+// GlobalResample is RngResample on the package-level generator. Safe for
+// concurrent use; panics like RngResample.
+func GlobalResample[T any](x []T, k int) []T {
+	var result []T
+	globalRng.Do(func(r *Rng) { result = RngResample(r, x, k) })
+	return result
+}
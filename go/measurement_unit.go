@@ -9,6 +9,50 @@ type MeasurementUnit struct {
 	Abbreviation string
 	FullName     string
 	BaseUnits    int64
+	// Offset is the position of this unit's zero in base units, for affine
+	// families such as temperature; a value v is v·BaseUnits + Offset base
+	// units. Zero for purely multiplicative units.
+	Offset float64
+}
+
+// NewUnit creates a unit and registers it in registry (skipped when registry
+// is nil). It rejects an empty id or family and non-positive baseUnits, which
+// would make ConversionFactor divide by zero, and returns the registry's error
+// for a duplicate id.
+func NewUnit(registry *UnitRegistry, id, family, abbreviation, fullName string, baseUnits int64) (*MeasurementUnit, error) {
+	return NewAffineUnit(registry, id, family, abbreviation, fullName, baseUnits, 0)
+}
+
+// NewAffineUnit is NewUnit for affine families such as temperature: offset is
+// the position of the unit's zero in base units (see MeasurementUnit.Offset).
+// It additionally rejects a non-finite offset.
+func NewAffineUnit(registry *UnitRegistry, id, family, abbreviation, fullName string, baseUnits int64, offset float64) (*MeasurementUnit, error) {
+	if id == "" {
+		return nil, fmt.Errorf("unit id cannot be empty")
+	}
+	if family == "" {
+		return nil, fmt.Errorf("unit family cannot be empty")
+	}
+	if baseUnits <= 0 {
+		return nil, fmt.Errorf("unit base units must be positive, got %d", baseUnits)
+	}
+	if !isFinite(offset) {
+		return nil, fmt.Errorf("unit offset must be finite, got %v", offset)
+	}
+	unit := &MeasurementUnit{
+		ID:           id,
+		Family:       family,
+		Abbreviation: abbreviation,
+		FullName:     fullName,
+		BaseUnits:    baseUnits,
+		Offset:       offset,
+	}
+	if registry != nil {
+		if err := registry.Register(unit); err != nil {
+			return nil, err
+		}
+	}
+	return unit, nil
 }
 
 // IsCompatible returns true if both units belong to the same family.
@@ -17,6 +61,7 @@ func (u *MeasurementUnit) IsCompatible(other *MeasurementUnit) bool {
 }
 
 // Finer returns the unit with smaller BaseUnits (higher precision).
+// Like ConversionFactor, it assumes positive BaseUnits.
 func Finer(a, b *MeasurementUnit) *MeasurementUnit {
 	if a.BaseUnits <= b.BaseUnits {
 		return a
@@ -25,10 +70,35 @@ func Finer(a, b *MeasurementUnit) *MeasurementUnit {
 }
 
 // ConversionFactor returns the multiplier to convert from one unit to another.
+// Both units must have positive BaseUnits (as NewUnit guarantees); otherwise
+// the result is a silent 0, ±Inf, or NaN. Use ConversionFactorChecked for
+// units built by hand.
 func ConversionFactor(from, to *MeasurementUnit) float64 {
 	return float64(from.BaseUnits) / float64(to.BaseUnits)
 }
 
+// ConversionOffset returns the term added after scaling by ConversionFactor
+// when converting a value between affine units: (from.Offset - to.Offset) /
+// to.BaseUnits. It is zero for purely multiplicative units. Differences
+// between values (spreads, shifts) convert with the factor alone.
+func ConversionOffset(from, to *MeasurementUnit) float64 {
+	return (from.Offset - to.Offset) / float64(to.BaseUnits)
+}
+
+// ConversionFactorChecked is ConversionFactor returning an error for
+// incompatible units or non-positive BaseUnits.
+func ConversionFactorChecked(from, to *MeasurementUnit) (float64, error) {
+	if !from.IsCompatible(to) {
+		return 0, &UnitMismatchError{Unit1: from, Unit2: to}
+	}
+	for _, u := range []*MeasurementUnit{from, to} {
+		if u.BaseUnits <= 0 {
+			return 0, fmt.Errorf("unit '%s' has non-positive base units %d", u.ID, u.BaseUnits)
+		}
+	}
+	return ConversionFactor(from, to), nil
+}
+
 func (u *MeasurementUnit) String() string {
 	return u.Abbreviation
 }
@@ -0,0 +1,126 @@
+package pragmastat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewUnitValidation(t *testing.T) {
+	cases := []struct {
+		name       string
+		id, family string
+		baseUnits  int64
+	}{
+		{"empty id", "", "Time", 1},
+		{"empty family", "ms", "", 1},
+		{"zero base units", "ms", "Time", 0},
+		{"negative base units", "ms", "Time", -1000},
+	}
+	for _, c := range cases {
+		registry := NewUnitRegistry()
+		if unit, err := NewUnit(registry, c.id, c.family, "ms", "Millisecond", c.baseUnits); err == nil {
+			t.Errorf("%s: NewUnit = %v, want an error", c.name, unit)
+		}
+		if _, err := registry.Resolve(c.id); err == nil {
+			t.Errorf("%s: a rejected unit was registered", c.name)
+		}
+	}
+}
+
+func TestNewUnitRegistryRoundTrip(t *testing.T) {
+	registry := NewUnitRegistry()
+	ms, err := NewUnit(registry, "ms", "Time", "ms", "Millisecond", 1000000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ns, err := NewUnit(registry, "ns", "Time", "ns", "Nanosecond", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resolved, err := registry.Resolve("ms")
+	if err != nil || resolved != ms {
+		t.Fatalf("Resolve(ms) = %v, %v, want the constructed unit", resolved, err)
+	}
+	if f := ConversionFactor(resolved, ns); f != 1e6 {
+		t.Errorf("ConversionFactor(ms, ns) = %v, want 1e6", f)
+	}
+
+	if _, err := NewUnit(registry, "ms", "Time", "ms", "Millisecond", 1000000); err == nil {
+		t.Error("expected a duplicate id to be rejected")
+	}
+
+	if u, err := NewUnit(nil, "s", "Time", "s", "Second", 1000000000); err != nil || u.BaseUnits != 1000000000 {
+		t.Errorf("NewUnit without registry = %v, %v", u, err)
+	}
+}
+
+func TestConversionFactorChecked(t *testing.T) {
+	registry := NewUnitRegistry()
+	if _, err := NewUnit(registry, "broken", "Time", "?", "Broken", 0); err == nil {
+		t.Fatal("expected a zero-base unit to be rejected at construction")
+	}
+
+	ms, _ := NewUnit(registry, "ms", "Time", "ms", "Millisecond", 1000000)
+	us, _ := NewUnit(registry, "us", "Time", "us", "Microsecond", 1000)
+	if f, err := ConversionFactorChecked(ms, us); err != nil || f != 1000 {
+		t.Errorf("ConversionFactorChecked(ms, us) = %v, %v, want 1000", f, err)
+	}
+
+	zero := &MeasurementUnit{ID: "zero", Family: "Time", BaseUnits: 0}
+	if f, err := ConversionFactorChecked(ms, zero); err == nil {
+		t.Errorf("ConversionFactorChecked(ms, zero) = %v, want an error", f)
+	}
+	if f, err := ConversionFactorChecked(zero, ms); err == nil {
+		t.Errorf("ConversionFactorChecked(zero, ms) = %v, want an error", f)
+	}
+	if _, err := ConversionFactorChecked(ms, NumberUnit); err == nil {
+		t.Error("expected incompatible families to be rejected")
+	} else if _, ok := err.(*UnitMismatchError); !ok {
+		t.Errorf("incompatible units gave %T, want *UnitMismatchError", err)
+	}
+}
+
+func TestAffineTemperatureConversion(t *testing.T) {
+	// One base unit is 1/9 °C with its zero at 0 °C, so a Celsius degree is
+	// 9 base units and a Fahrenheit degree 5, and 0 °F sits at -160.
+	celsius, err := NewAffineUnit(nil, "celsius", "Temperature", "°C", "Celsius", 9, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fahrenheit, err := NewAffineUnit(nil, "fahrenheit", "Temperature", "°F", "Fahrenheit", 5, -160)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewSampleWithUnit([]float64{100, 0, -40}, celsius)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := s.ConvertTo(fahrenheit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []float64{212, 32, -40}
+	for i, v := range f.Values() {
+		if !floatEquals(v, want[i], 1e-12) {
+			t.Errorf("value %d: got %v °F, want %v", i, v, want[i])
+		}
+	}
+
+	back, _ := f.ConvertTo(celsius)
+	for i, v := range back.Values() {
+		if !floatEquals(v, s.Values()[i], 1e-12) {
+			t.Errorf("round trip %d: got %v °C, want %v", i, v, s.Values()[i])
+		}
+	}
+
+	if _, err := NewAffineUnit(nil, "bad", "Temperature", "", "", 1, math.Inf(1)); err == nil {
+		t.Error("expected a non-finite offset to be rejected")
+	}
+
+	// Multiplicative units are unaffected.
+	if off := ConversionOffset(NumberUnit, NumberUnit); off != 0 {
+		t.Errorf("ConversionOffset for multiplicative units = %v", off)
+	}
+}
@@ -0,0 +1,77 @@
+package pragmastat
+
+// Median returns the sample median of x: the middle element for an odd count,
+// the average of the two middle elements for an even count.
+//
+// Uses quickselect (O(n) expected) on a copy of x, so x is never mutated.
+// Pivots are drawn from an Rng seeded with the FNV-1a hash of the input, so the
+// computation is deterministic.
+func Median(x []float64) (float64, error) {
+	if err := checkValidity(x, SubjectX); err != nil {
+		return 0, err
+	}
+	return medianImpl(x), nil
+}
+
+// medianImpl computes the median of a non-empty slice without mutating it.
+func medianImpl(values []float64) float64 {
+	n := len(values)
+	if n == 1 {
+		return values[0]
+	}
+	a := make([]float64, n)
+	copy(a, values)
+
+	k := (n - 1) / 2
+	quickselect(a, k, NewRngFromSeed(deriveSeed(values)))
+	lower := a[k]
+	if n%2 == 1 {
+		return lower
+	}
+
+	// After selection every element right of k is >= a[k]; the upper middle
+	// is the smallest of them.
+	upper := a[k+1]
+	for _, v := range a[k+2:] {
+		if v < upper {
+			upper = v
+		}
+	}
+	// Overflow-safe midpoint: halve before summing.
+	return 0.5*lower + 0.5*upper
+}
+
+// quickselect partially orders a so that a[k] holds the k-th smallest element
+// (0-based), every element left of k is <= a[k], and every element right of k
+// is >= a[k]. Uses three-way partitioning, so heavily tied input stays linear.
+func quickselect(a []float64, k int, rng *Rng) {
+	lo, hi := 0, len(a)-1
+	for lo < hi {
+		pivot := a[rng.UniformIntN(lo, hi+1)]
+
+		// Dutch national flag partition of a[lo..hi] into <, ==, > pivot.
+		lt, i, gt := lo, lo, hi
+		for i <= gt {
+			switch {
+			case a[i] < pivot:
+				a[lt], a[i] = a[i], a[lt]
+				lt++
+				i++
+			case a[i] > pivot:
+				a[i], a[gt] = a[gt], a[i]
+				gt--
+			default:
+				i++
+			}
+		}
+
+		switch {
+		case k < lt:
+			hi = lt - 1
+		case k > gt:
+			lo = gt + 1
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package pragmastat
+
+import (
+	"sort"
+	"testing"
+)
+
+// sortMedian is the sort-based reference implementation.
+func sortMedian(x []float64) float64 {
+	sorted := append([]float64(nil), x...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return 0.5*sorted[n/2-1] + 0.5*sorted[n/2]
+}
+
+func TestMedianMatchesSort(t *testing.T) {
+	rng := NewRngFromSeed(invarianceSeed)
+	for iter := 0; iter < 200; iter++ {
+		n := rng.UniformIntN(1, 100)
+		var x []float64
+		if iter%2 == 0 {
+			x = uniformVec(rng, n)
+		} else {
+			x = tiedVec(rng, n, 4, 1)
+		}
+		snapshot := append([]float64(nil), x...)
+
+		got, err := Median(x)
+		if err != nil {
+			t.Fatalf("Median: %v", err)
+		}
+		if want := sortMedian(x); got != want {
+			t.Fatalf("n=%d: Median = %v, sort-based = %v", n, got, want)
+		}
+		assertBitsUnchanged(t, "Median", x, snapshot)
+	}
+}
+
+func TestMedianEvenAveraging(t *testing.T) {
+	got, err := Median([]float64{4, 1, 3, 2})
+	if err != nil {
+		t.Fatalf("Median: %v", err)
+	}
+	if got != 2.5 {
+		t.Errorf("Median({4,1,3,2}) = %v, want 2.5", got)
+	}
+}
+
+func TestMedianEmpty(t *testing.T) {
+	_, err := Median(nil)
+	assertViolation(t, err, Validity, SubjectX)
+}
+
+func BenchmarkMedianQuickselect(b *testing.B) {
+	x := uniformVec(NewRngFromSeed(invarianceSeed), 1_000_000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := Median(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMedianSort(b *testing.B) {
+	x := uniformVec(NewRngFromSeed(invarianceSeed), 1_000_000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sortMedian(x)
+	}
+}
@@ -21,11 +21,50 @@ func minAchievableMisrateTwoSample(n, m int) (float64, error) {
 	if m <= 0 {
 		return 0, NewDomainError(SubjectY)
 	}
-	var binom float64
-	if n+m < maxAcceptableBinomN {
-		binom = float64(binomialCoefficient(n+m, n))
-	} else {
-		binom = binomialCoefficientFloat(float64(n+m), float64(n))
+	return 2.0 / binomialTotal(n+m, n), nil
+}
+
+// MinSampleSizeOneSample returns the smallest sample size for which
+// CenterBounds accepts targetMisrate: the smallest n >= 2 whose minimum
+// achievable one-sample misrate 2^(1-n) is at most targetMisrate, i.e.
+// max(2, ceil(1 - log2(targetMisrate))). The lower limit of 2 only matters
+// for targetMisrate = 1, which n = 1 would already reach. Reports
+// domain(misrate) unless targetMisrate is in (0, 1].
+func MinSampleSizeOneSample(targetMisrate float64) (int, error) {
+	if !(targetMisrate > 0 && targetMisrate <= 1) {
+		return 0, NewDomainError(SubjectMisrate)
+	}
+	n := int(math.Ceil(1 - math.Log2(targetMisrate)))
+	// Guard the rounding of Log2 against the exact definition.
+	for n > 1 && math.Pow(2, float64(2-n)) <= targetMisrate {
+		n--
+	}
+	for math.Pow(2, float64(1-n)) > targetMisrate {
+		n++
+	}
+	if n < 2 {
+		n = 2 // CenterBounds needs at least two values
+	}
+	return n, nil
+}
+
+// MinSampleSizeTwoSample returns the smallest balanced design n = m whose
+// minimum achievable two-sample misrate 2/C(2n, n) is at most targetMisrate,
+// so that ShiftBounds accepts that misrate. Reports domain(misrate) unless
+// targetMisrate is in (0, 1].
+func MinSampleSizeTwoSample(targetMisrate float64) (n, m int, err error) {
+	if !(targetMisrate > 0 && targetMisrate <= 1) {
+		return 0, 0, NewDomainError(SubjectMisrate)
+	}
+	n = 1
+	for {
+		minMisrate, err := minAchievableMisrateTwoSample(n, n)
+		if err != nil {
+			return 0, 0, err
+		}
+		if minMisrate <= targetMisrate {
+			return n, n, nil
+		}
+		n++
 	}
-	return 2.0 / binom, nil
 }
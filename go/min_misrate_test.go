@@ -0,0 +1,90 @@
+package pragmastat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMinSampleSizeOneSample(t *testing.T) {
+	for _, c := range []struct {
+		misrate float64
+		n       int
+	}{
+		{1, 2}, // 2^0 = 1 is reached at n = 1, but CenterBounds needs n >= 2
+		{0.5, 2},
+		{0.49, 3},
+		{0.25, 3},
+		{0.1, 5},
+		{0.05, 6},
+		{0.01, 8},
+		{1e-3, 11},
+		{math.Pow(2, -20), 21},
+		{5e-324, 1075},
+	} {
+		n, err := MinSampleSizeOneSample(c.misrate)
+		if err != nil {
+			t.Fatalf("misrate %v: %v", c.misrate, err)
+		}
+		if n != c.n {
+			t.Errorf("misrate %v: n = %d, want %d", c.misrate, n, c.n)
+		}
+		// n is the boundary: CenterBounds accepts the misrate at n but not
+		// at n-1 (checked for small n only).
+		if n > 30 {
+			continue
+		}
+		x := make([]float64, n)
+		for i := range x {
+			x[i] = float64(i)
+		}
+		if _, err := CenterBounds(x, c.misrate, true); err != nil {
+			t.Errorf("misrate %v: CenterBounds with n = %d: %v", c.misrate, n, err)
+		}
+		if n >= 3 {
+			_, err := CenterBounds(x[:n-1], c.misrate, true)
+			assertViolation(t, err, Domain, SubjectMisrate)
+		}
+	}
+}
+
+func TestMinSampleSizeTwoSample(t *testing.T) {
+	for _, c := range []struct {
+		misrate float64
+		n       int
+	}{
+		{1, 1},
+		{0.5, 2},  // 2/C(4, 2) = 1/3
+		{0.05, 4}, // 2/C(6, 3) = 0.1, 2/C(8, 4) ≈ 0.0286
+		{0.01, 5}, // 2/C(10, 5) ≈ 0.0079
+		{1e-6, 12},
+	} {
+		n, m, err := MinSampleSizeTwoSample(c.misrate)
+		if err != nil {
+			t.Fatalf("misrate %v: %v", c.misrate, err)
+		}
+		if n != c.n || m != c.n {
+			t.Errorf("misrate %v: n, m = %d, %d, want %d, %d", c.misrate, n, m, c.n, c.n)
+		}
+		x := make([]float64, n)
+		y := make([]float64, m)
+		for i := range x {
+			x[i], y[i] = float64(i), float64(i)+0.5
+		}
+		if _, err := ShiftBounds(x, y, c.misrate, true); err != nil {
+			t.Errorf("misrate %v: ShiftBounds with n = m = %d: %v", c.misrate, n, err)
+		}
+		if n > 1 {
+			_, err := ShiftBounds(x[:n-1], y[:n-1], c.misrate, true)
+			assertViolation(t, err, Domain, SubjectMisrate)
+		}
+	}
+}
+
+func TestMinSampleSizeDomain(t *testing.T) {
+	for _, misrate := range []float64{0, -0.1, 1.5, math.NaN(), math.Inf(1)} {
+		_, err := MinSampleSizeOneSample(misrate)
+		assertViolation(t, err, Domain, SubjectMisrate)
+		_, _, err = MinSampleSizeTwoSample(misrate)
+		assertViolation(t, err, Domain, SubjectMisrate)
+	}
+}
@@ -0,0 +1,147 @@
+package pragmastat
+
+import (
+	"math"
+)
+
+// Mixture draws from one of several component distributions chosen at
+// random with probabilities proportional to the weights, e.g. a
+// contamination model of 95% Additive(0, 1) and 5% Additive(0, 10).
+// Components may themselves be mixtures.
+type Mixture struct {
+	components []Distribution
+	probs      []float64 // normalized weights
+	cum        []float64 // running sums of the weights, for categoricalScan
+	full       bool      // every component is a FullDistribution
+}
+
+// NewMixture creates a mixture of the given components. Weights must be
+// finite and non-negative with a positive sum and are divided by that sum,
+// so {95, 5} and {0.95, 0.05} are equivalent. Returns a *ParameterError if
+// there are no components, a component is nil, the lengths differ, or the
+// weights are invalid.
+func NewMixture(components []Distribution, weights []float64) (*Mixture, error) {
+	if len(components) == 0 {
+		return nil, newParameterError("Mixture", "components", "components cannot be empty")
+	}
+	if len(weights) != len(components) {
+		return nil, newParameterError("Mixture", "weights", "weights length must match components length")
+	}
+	full := true
+	for _, c := range components {
+		if c == nil {
+			return nil, newParameterError("Mixture", "components", "components cannot be nil")
+		}
+		if _, ok := c.(FullDistribution); !ok {
+			full = false
+		}
+	}
+	cum, err := categoricalCumulative(weights, []CategoricalOption{WithRenormalize()})
+	if err != nil {
+		return nil, newParameterError("Mixture", "weights", err.Error())
+	}
+	total := cum[len(cum)-1]
+	probs := make([]float64, len(weights))
+	for i, w := range weights {
+		probs[i] = w / total
+	}
+	return &Mixture{
+		components: append([]Distribution(nil), components...),
+		probs:      probs,
+		cum:        cum,
+		full:       full,
+	}, nil
+}
+
+// Components returns a copy of the component distributions.
+func (m *Mixture) Components() []Distribution {
+	return append([]Distribution(nil), m.components...)
+}
+
+// Weights returns a copy of the normalized component weights.
+func (m *Mixture) Weights() []float64 {
+	return append([]float64(nil), m.probs...)
+}
+
+// Sample picks a component with one UniformFloat64 (exactly as Categorical
+// would) and then returns one sample from it, so a draw consumes one uniform
+// plus whatever the chosen component consumes.
+func (m *Mixture) Sample(rng *Rng) float64 {
+	return m.components[categoricalScan(rng, m.cum)].Sample(rng)
+}
+
+// Samples generates multiple samples from the mixture.
+func (m *Mixture) Samples(rng *Rng, count int) []float64 {
+	return sampleN(m, rng, count)
+}
+
+// SamplesInto fills dst with samples from the mixture without allocating.
+func (m *Mixture) SamplesInto(rng *Rng, dst []float64) {
+	fillSamples(m, rng, dst)
+}
+
+// DrawsPerSample returns one draw for the component choice plus the
+// components' common count, or VariableDraws if the components differ or
+// any of them varies.
+func (m *Mixture) DrawsPerSample() int {
+	common := DrawsPerSample(m.components[0])
+	for _, c := range m.components[1:] {
+		if DrawsPerSample(c) != common {
+			return VariableDraws
+		}
+	}
+	return addDraws(1, common)
+}
+
+// Pdf returns the weighted sum of the component densities, or NaN if some
+// component is not a FullDistribution.
+func (m *Mixture) Pdf(x float64) float64 {
+	if !m.full {
+		return math.NaN()
+	}
+	var sum float64
+	for i, c := range m.components {
+		sum += m.probs[i] * c.(FullDistribution).Pdf(x)
+	}
+	return sum
+}
+
+// Cdf returns the weighted sum of the component CDFs, or NaN if some
+// component is not a FullDistribution.
+func (m *Mixture) Cdf(x float64) float64 {
+	if !m.full {
+		return math.NaN()
+	}
+	var sum float64
+	for i, c := range m.components {
+		sum += m.probs[i] * c.(FullDistribution).Cdf(x)
+	}
+	return math.Min(sum, 1)
+}
+
+// Quantile returns the inverse CDF, or NaN if some component is not a
+// FullDistribution. The root lies between the smallest and largest
+// component quantiles at p (ignoring zero-weight components) and is found
+// by bracketed Newton iteration. Quantile(0) and Quantile(1) are the
+// outermost support endpoints of the weighted components.
+func (m *Mixture) Quantile(p float64) float64 {
+	if !m.full || invalidProbability(p) {
+		return math.NaN()
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for i, c := range m.components {
+		if m.probs[i] == 0 {
+			continue
+		}
+		q := c.(FullDistribution).Quantile(p)
+		lo = math.Min(lo, q)
+		hi = math.Max(hi, q)
+	}
+	if p == 0 {
+		return lo
+	}
+	if p == 1 || lo == hi {
+		return hi
+	}
+	return invertCdfNewton(m.Cdf, m.Pdf, p, lo, hi)
+}
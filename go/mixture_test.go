@@ -0,0 +1,125 @@
+package pragmastat
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// TestMixtureSingleComponent checks that a one-component mixture reproduces
+// the component's stream once the categorical uniform is accounted for.
+func TestMixtureSingleComponent(t *testing.T) {
+	inner := NewAdditive(3, 2)
+	m, err := NewMixture([]Distribution{inner}, []float64{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mixed, direct := NewRngFromString("mixture-single"), NewRngFromString("mixture-single")
+	for i := 0; i < 100; i++ {
+		direct.UniformFloat64()
+		if got, want := m.Sample(mixed), inner.Sample(direct); got != want {
+			t.Fatalf("draw %d: got %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestMixtureFrequencies(t *testing.T) {
+	const n = 100_000
+	weights := []float64{2, 5, 3}
+	m, err := NewMixture([]Distribution{
+		NewUniform(0, 1),
+		NewUniform(10, 11),
+		NewUniform(20, 21),
+	}, weights)
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := make([]float64, len(weights))
+	for _, x := range m.Samples(NewRngFromString("mixture-frequencies"), n) {
+		counts[int(x/10)]++
+	}
+	for i, p := range m.Weights() {
+		if want := weights[i] / 10; p != want {
+			t.Errorf("weight %d = %v, want %v", i, p, want)
+		}
+		if got := counts[i] / n; math.Abs(got-p) > 5*math.Sqrt(p*(1-p)/n) {
+			t.Errorf("component %d frequency = %v, want %v", i, got, p)
+		}
+	}
+}
+
+func TestMixtureFunctions(t *testing.T) {
+	narrow, wide := NewAdditive(0, 1), NewAdditive(0, 10)
+	m, err := NewMixture([]Distribution{narrow, wide}, []float64{0.95, 0.05})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, x := range []float64{-30, -2, -0.5, 0, 1, 4, 25} {
+		if got, want := m.Cdf(x), 0.95*narrow.Cdf(x)+0.05*wide.Cdf(x); !floatEquals(got, want, 1e-15) {
+			t.Errorf("Cdf(%v) = %v, want %v", x, got, want)
+		}
+		if got, want := m.Pdf(x), 0.95*narrow.Pdf(x)+0.05*wide.Pdf(x); !floatEquals(got, want, 1e-15) {
+			t.Errorf("Pdf(%v) = %v, want %v", x, got, want)
+		}
+	}
+	for _, p := range []float64{1e-9, 0.01, 0.3, 0.5, 0.9, 0.999} {
+		if got := m.Cdf(m.Quantile(p)); !floatEquals(got, p, 1e-12) {
+			t.Errorf("Cdf(Quantile(%v)) = %v", p, got)
+		}
+	}
+	if m.Quantile(0) != math.Inf(-1) || m.Quantile(1) != math.Inf(1) || !math.IsNaN(m.Quantile(1.5)) {
+		t.Errorf("Quantile endpoints = %v, %v, %v", m.Quantile(0), m.Quantile(1), m.Quantile(1.5))
+	}
+
+	// Nested mixtures combine their weights: 0.5·(0.5·U(0,1) + 0.5·U(1,2)) + 0.5·U(2,4).
+	inner, err := NewMixture([]Distribution{NewUniform(0, 1), NewUniform(1, 2)}, []float64{1, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	outer, err := NewMixture([]Distribution{inner, NewUniform(2, 4)}, []float64{1, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := outer.Cdf(1.5); !floatEquals(got, 0.375, 1e-15) {
+		t.Errorf("nested Cdf(1.5) = %v, want 0.375", got)
+	}
+	if got := outer.Quantile(0.75); !floatEquals(got, 3, 1e-12) {
+		t.Errorf("nested Quantile(0.75) = %v, want 3", got)
+	}
+
+	discrete, err := NewMixture([]Distribution{NewUniform(0, 1), NewBinomial(5, 0.5)}, []float64{1, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !math.IsNaN(discrete.Cdf(0.5)) || !math.IsNaN(discrete.Pdf(0.5)) || !math.IsNaN(discrete.Quantile(0.5)) {
+		t.Error("mixture with a component lacking Pdf/Cdf/Quantile should return NaN")
+	}
+}
+
+func TestMixtureParameterErrors(t *testing.T) {
+	u := NewUniform(0, 1)
+	cases := []struct {
+		name       string
+		components []Distribution
+		weights    []float64
+		parameter  string
+	}{
+		{"empty", nil, nil, "components"},
+		{"nil component", []Distribution{u, nil}, []float64{1, 1}, "components"},
+		{"length mismatch", []Distribution{u, u}, []float64{1}, "weights"},
+		{"negative weight", []Distribution{u, u}, []float64{1, -1}, "weights"},
+		{"NaN weight", []Distribution{u, u}, []float64{1, math.NaN()}, "weights"},
+		{"zero sum", []Distribution{u, u}, []float64{0, 0}, "weights"},
+	}
+	for _, c := range cases {
+		_, err := NewMixture(c.components, c.weights)
+		var pe *ParameterError
+		if !errors.As(err, &pe) {
+			t.Errorf("%s: got %v, want *ParameterError", c.name, err)
+			continue
+		}
+		if pe.Distribution != "Mixture" || pe.Parameter != c.parameter {
+			t.Errorf("%s: got %s.%s, want Mixture.%s", c.name, pe.Distribution, pe.Parameter, c.parameter)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package pragmastat
+
+import "math"
+
+// unimodalDipThreshold is the dip score above which IsLikelyUnimodal reports
+// multimodality. Unimodal samples (normal, uniform, exponential, log-normal,
+// Cauchy) of 30 to 10^4 values stayed below 4 in calibration runs, while an
+// equal mixture of normals 5σ apart exceeds it from about 10^3 values.
+const unimodalDipThreshold = 4
+
+// IsLikelyUnimodal is a pragmatic multimodality flag to check before
+// trusting a single location estimate. It bins x with twice the
+// Freedman–Diaconis width of HistogramAuto (4·Spread/n^(1/3)) and looks for
+// the deepest valley: for each bin it takes the highest bin on either side,
+// and scores the drop from the lower of the two peaks p to the bin count v
+// as (p - v)/√(p + v), a z-like statistic for two Poisson counts. score is
+// the largest such value (0 if no bin lies below peaks on both sides), and
+// unimodal is score < 4.
+//
+// This is a heuristic, not a dip test: there is no p-value, small samples
+// (below roughly 100 values) rarely have the power to flag anything, modes
+// closer than a few Spreads or carrying under ~20% of the mass are easily
+// missed, and a flat or sparse-tailed density can occasionally produce a
+// spurious valley. Returns a validity error for empty or non-finite x and a
+// sparity error for tie-dominant x, where the bin width is undefined.
+func IsLikelyUnimodal(x []float64) (unimodal bool, score float64, err error) {
+	if err := checkValidity(x, SubjectX); err != nil {
+		return false, 0, err
+	}
+	sorted := sortedOne(x, false)
+	spreadVal, err := spreadImpl(sorted, true)
+	if err != nil {
+		return false, 0, err
+	}
+	if spreadVal <= 0 {
+		return false, 0, NewSparityError(SubjectX)
+	}
+	n := len(sorted)
+	lo, hi := sorted[0], sorted[n-1]
+	width := 4 * spreadVal / math.Cbrt(float64(n))
+	maxBins := n
+	if maxBins > maxAutoBins {
+		maxBins = maxAutoBins
+	}
+	bins := maxBins
+	if raw := math.Ceil((hi - lo) / width); raw < float64(maxBins) {
+		bins = int(raw)
+	}
+	if bins < 1 {
+		bins = 1
+	}
+	counts, _ := histogramImpl(sorted, lo, hi, bins)
+	score = dipScore(counts)
+	return score < unimodalDipThreshold, score, nil
+}
+
+// dipScore returns the largest (p - v)/√(p + v) over bins with count v below
+// p, the lower of the highest counts strictly to its left and right.
+func dipScore(counts []int) float64 {
+	right := make([]int, len(counts)+1)
+	for i := len(counts) - 1; i >= 0; i-- {
+		right[i] = right[i+1]
+		if counts[i] > right[i] {
+			right[i] = counts[i]
+		}
+	}
+	best, left := 0.0, 0
+	for i, v := range counts {
+		peak := left
+		if right[i+1] < peak {
+			peak = right[i+1]
+		}
+		if peak > v {
+			if z := float64(peak-v) / math.Sqrt(float64(peak+v)); z > best {
+				best = z
+			}
+		}
+		if v > left {
+			left = v
+		}
+	}
+	return best
+}
@@ -0,0 +1,60 @@
+package pragmastat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIsLikelyUnimodal(t *testing.T) {
+	bimodal, err := NewMixture([]Distribution{NewAdditive(0, 1), NewAdditive(5, 1)}, []float64{1, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []struct {
+		name     string
+		d        Distribution
+		unimodal bool
+	}{
+		{"normal", NewAdditive(10, 2), true},
+		{"exp", NewExp(1), true},
+		{"uniform", NewUniform(0, 1), true},
+		{"bimodal", bimodal, false},
+	} {
+		for seed := int64(0); seed < 5; seed++ {
+			x := c.d.Samples(NewRngFromSeed(seed), 2000)
+			unimodal, score, err := IsLikelyUnimodal(x)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if unimodal != c.unimodal {
+				t.Errorf("%s, seed %d: unimodal = %v (score %v), want %v", c.name, seed, unimodal, score, c.unimodal)
+			}
+		}
+	}
+}
+
+func TestDipScore(t *testing.T) {
+	for _, c := range []struct {
+		counts []int
+		want   float64
+	}{
+		{[]int{1, 5, 9, 5, 1}, 0},
+		{[]int{9, 5, 1}, 0},
+		{[]int{5, 1, 8}, 4 / math.Sqrt(6)},
+		{[]int{2, 20, 3, 12, 0, 30}, 20 / math.Sqrt(20)}, // the empty bin between peaks 20 and 30
+		{[]int{7}, 0},
+	} {
+		if got := dipScore(c.counts); !floatEquals(got, c.want, 1e-15) {
+			t.Errorf("dipScore(%v) = %v, want %v", c.counts, got, c.want)
+		}
+	}
+}
+
+func TestIsLikelyUnimodalErrors(t *testing.T) {
+	_, _, err := IsLikelyUnimodal(nil)
+	assertViolation(t, err, Validity, SubjectX)
+	_, _, err = IsLikelyUnimodal([]float64{1, math.NaN(), 2})
+	assertViolation(t, err, Validity, SubjectX)
+	_, _, err = IsLikelyUnimodal([]float64{3, 3, 3, 3, 7})
+	assertViolation(t, err, Sparity, SubjectX)
+}
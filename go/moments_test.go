@@ -0,0 +1,88 @@
+package pragmastat
+
+import (
+	"math"
+	"testing"
+)
+
+type withMoments interface {
+	Distribution
+	TrueMean() float64
+	TrueVariance() float64
+	TrueStdDev() float64
+}
+
+// TestMomentsMatchLargeSamples compares the analytic mean and variance with
+// the empirical ones. Every distribution here has a finite fourth moment, so
+// the sample variance is within a few standard errors.
+func TestMomentsMatchLargeSamples(t *testing.T) {
+	const n = 200_000
+	distributions := map[string]withMoments{
+		"Uniform":      NewUniform(-2, 5),
+		"Additive":     NewAdditive(10, 3),
+		"Multiplic":    NewMultiplic(0.5, 0.5),
+		"Exp":          NewExp(0.5),
+		"Power":        NewPower(1, 6),
+		"StudentT":     NewStudentT(8, 1, 2),
+		"Gamma":        NewGamma(2.5, 0.5),
+		"Beta":         NewBeta(0.7, 3),
+		"Bernoulli":    NewBernoulli(0.3),
+		"Binomial":     NewBinomial(20, 0.3),
+		"Contaminated": NewContaminated(1, 2, 0.1, 5),
+	}
+	discrete, err := NewDiscrete([]float64{1, 2, 5}, []float64{0.2, 0.5, 0.3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	distributions["Discrete"] = discrete
+	histogram, err := NewHistogramDistribution([]float64{0, 1, 3, 4, 6}, []float64{2, 0, 1, 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	distributions["HistogramDist"] = histogram
+
+	for name, d := range distributions {
+		x := d.Samples(NewRngFromString("moments-"+name), n)
+		mean := 0.0
+		for _, v := range x {
+			mean += v
+		}
+		mean /= n
+		sampleVariance := variance(x)
+
+		if got, want := mean, d.TrueMean(); math.Abs(got-want) > 5*d.TrueStdDev()/math.Sqrt(n) {
+			t.Errorf("%s: sample mean %v, TrueMean %v", name, got, want)
+		}
+		if got, want := sampleVariance, d.TrueVariance(); math.Abs(got-want) > 0.03*want {
+			t.Errorf("%s: sample variance %v, TrueVariance %v", name, got, want)
+		}
+		if got, want := d.TrueStdDev(), math.Sqrt(d.TrueVariance()); got != want {
+			t.Errorf("%s: TrueStdDev %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestMomentsUndefined(t *testing.T) {
+	inf := math.Inf(1)
+	for _, c := range []struct {
+		name           string
+		d              withMoments
+		mean, variance float64
+	}{
+		{"Cauchy", NewCauchy(0, 1), math.NaN(), math.NaN()},
+		{"StudentT(1)", NewStudentT(1, 0, 1), math.NaN(), math.NaN()},
+		{"StudentT(1.5)", NewStudentT(1.5, 3, 1), 3, inf},
+		{"Power(1, 1)", NewPower(1, 1), inf, inf},
+		{"Power(1, 2)", NewPower(1, 2), 2, inf},
+	} {
+		mean, variance := c.d.TrueMean(), c.d.TrueVariance()
+		if !sameFloat(mean, c.mean) || !sameFloat(variance, c.variance) {
+			t.Errorf("%s: TrueMean %v, TrueVariance %v, want %v, %v", c.name, mean, variance, c.mean, c.variance)
+		}
+	}
+}
+
+// sameFloat reports whether a and b are equal or both NaN.
+func sameFloat(a, b float64) bool {
+	return a == b || (math.IsNaN(a) && math.IsNaN(b))
+}
@@ -11,16 +11,29 @@ type Multiplic struct {
 }
 
 // NewMultiplic creates a new multiplicative (log-normal) distribution.
-// Panics if logStdDev <= 0.
+// Panics if logMean is not finite or logStdDev is not positive and finite.
 func NewMultiplic(logMean, logStdDev float64) *Multiplic {
-	if logStdDev <= 0 {
-		panic("logStdDev must be positive")
+	m, err := TryNewMultiplic(logMean, logStdDev)
+	if err != nil {
+		panic(err.Error())
+	}
+	return m
+}
+
+// TryNewMultiplic is NewMultiplic returning a *ParameterError instead of
+// panicking.
+func TryNewMultiplic(logMean, logStdDev float64) (*Multiplic, error) {
+	if !isFinite(logMean) {
+		return nil, newParameterError("Multiplic", "logMean", "logMean must be finite")
+	}
+	if !(logStdDev > 0) || math.IsInf(logStdDev, 1) {
+		return nil, newParameterError("Multiplic", "logStdDev", "logStdDev must be positive and finite")
 	}
 	return &Multiplic{
 		LogMean:   logMean,
 		LogStdDev: logStdDev,
 		additive:  NewAdditive(logMean, logStdDev),
-	}
+	}, nil
 }
 
 // Sample generates a single sample from the multiplicative distribution.
@@ -28,11 +41,73 @@ func (m *Multiplic) Sample(rng *Rng) float64 {
 	return math.Exp(m.additive.Sample(rng))
 }
 
+func (m *Multiplic) antitheticPair(rng *Rng) (float64, float64) {
+	a, b := m.additive.antitheticPair(rng)
+	return math.Exp(a), math.Exp(b)
+}
+
 // Samples generates multiple samples from the multiplicative distribution.
 func (m *Multiplic) Samples(rng *Rng, count int) []float64 {
-	result := make([]float64, count)
-	for i := 0; i < count; i++ {
-		result[i] = m.Sample(rng)
+	return sampleN(m, rng, count)
+}
+
+// SamplesInto fills dst with samples from the multiplicative distribution without allocating.
+func (m *Multiplic) SamplesInto(rng *Rng, dst []float64) {
+	fillSamples(m, rng, dst)
+}
+
+// DrawsPerSample returns 2, the draws of the underlying Additive.
+func (m *Multiplic) DrawsPerSample() int {
+	return m.additive.DrawsPerSample()
+}
+
+// Pdf returns the probability density at x; it is 0 for x <= 0.
+func (m *Multiplic) Pdf(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	return m.additive.Pdf(math.Log(x)) / x
+}
+
+// Cdf returns P(X <= x); it is 0 for x <= 0.
+func (m *Multiplic) Cdf(x float64) float64 {
+	if x <= 0 {
+		return 0
 	}
-	return result
+	return m.additive.Cdf(math.Log(x))
+}
+
+// Quantile returns the inverse CDF: exp of the underlying Additive quantile.
+// Quantile(0) is 0, Quantile(1) is +Inf, and p outside [0, 1] gives NaN.
+func (m *Multiplic) Quantile(p float64) float64 {
+	return math.Exp(m.additive.Quantile(p))
+}
+
+// TrueCenter returns the population Center, computed numerically from Cdf
+// and Quantile (there is no closed form).
+func (m *Multiplic) TrueCenter() float64 {
+	return numericTrueCenter(m)
+}
+
+// TrueSpread returns the population Spread, computed numerically from Cdf
+// and Quantile (there is no closed form).
+func (m *Multiplic) TrueSpread() float64 {
+	return numericTrueSpread(m)
+}
+
+// TrueMean returns the population mean exp(logMean + logStdDev²/2).
+func (m *Multiplic) TrueMean() float64 {
+	return math.Exp(m.LogMean + m.LogStdDev*m.LogStdDev/2)
+}
+
+// TrueVariance returns the population variance
+// (exp(logStdDev²) - 1)·exp(2·logMean + logStdDev²).
+func (m *Multiplic) TrueVariance() float64 {
+	s2 := m.LogStdDev * m.LogStdDev
+	return math.Expm1(s2) * math.Exp(2*m.LogMean+s2)
+}
+
+// TrueStdDev returns the population standard deviation, √TrueVariance.
+func (m *Multiplic) TrueStdDev() float64 {
+	return math.Sqrt(m.TrueVariance())
 }
@@ -0,0 +1,78 @@
+package pragmastat
+
+import (
+	"container/heap"
+	"math"
+)
+
+// OnlineMedian maintains the median of a growing stream without buffering it
+// into a slice: a max-heap holds the lower half and a min-heap the upper
+// half, so Add is O(log n) and Median is O(1).
+//
+// It tracks the plain sample median (as Median computes it), not the
+// Hodges-Lehmann Center. The zero value is an empty, ready-to-use tracker.
+type OnlineMedian struct {
+	lower maxHeap // size equals upper's or exceeds it by one
+	upper minHeap
+}
+
+// Add inserts v. Non-finite values are rejected with a validity(x) error and
+// leave the tracker unchanged.
+func (m *OnlineMedian) Add(v float64) error {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return NewValidityError(SubjectX)
+	}
+	if m.lower.Len() == 0 || v <= m.lower.float64s[0] {
+		heap.Push(&m.lower, v)
+	} else {
+		heap.Push(&m.upper, v)
+	}
+	switch {
+	case m.lower.Len() > m.upper.Len()+1:
+		heap.Push(&m.upper, heap.Pop(&m.lower))
+	case m.upper.Len() > m.lower.Len():
+		heap.Push(&m.lower, heap.Pop(&m.upper))
+	}
+	return nil
+}
+
+// Len returns the number of values added.
+func (m *OnlineMedian) Len() int {
+	return m.lower.Len() + m.upper.Len()
+}
+
+// Median returns the middle value for an odd count and the average of the
+// two middle values for an even count, or NaN when no value has been added.
+func (m *OnlineMedian) Median() float64 {
+	switch {
+	case m.Len() == 0:
+		return math.NaN()
+	case m.lower.Len() > m.upper.Len():
+		return m.lower.float64s[0]
+	}
+	// Overflow-safe midpoint, matching Median bit for bit.
+	return 0.5*m.lower.float64s[0] + 0.5*m.upper.float64s[0]
+}
+
+// float64s is the storage shared by minHeap and maxHeap, which add Less to
+// complete heap.Interface.
+type float64s []float64
+
+func (h float64s) Len() int      { return len(h) }
+func (h float64s) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *float64s) Push(x any)   { *h = append(*h, x.(float64)) }
+
+func (h *float64s) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+type minHeap struct{ float64s }
+
+func (h minHeap) Less(i, j int) bool { return h.float64s[i] < h.float64s[j] }
+
+type maxHeap struct{ float64s }
+
+func (h maxHeap) Less(i, j int) bool { return h.float64s[i] > h.float64s[j] }
@@ -0,0 +1,42 @@
+package pragmastat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestOnlineMedianMatchesBatch(t *testing.T) {
+	rng := NewRngFromString("online-median")
+	stream := make([]float64, 500)
+	for i := range stream {
+		// Rounded values produce plenty of ties.
+		stream[i] = math.Round(rng.UniformFloat64Range(-50, 50))
+	}
+	stream = RngShuffle(rng, stream)
+
+	var m OnlineMedian
+	if !math.IsNaN(m.Median()) || m.Len() != 0 {
+		t.Fatalf("empty tracker: Median = %v, Len = %d", m.Median(), m.Len())
+	}
+	for i, v := range stream {
+		if err := m.Add(v); err != nil {
+			t.Fatal(err)
+		}
+		want, _ := Median(stream[:i+1])
+		if got := m.Median(); got != want || m.Len() != i+1 {
+			t.Fatalf("after %d values: Median = %v, batch %v (Len %d)", i+1, got, want, m.Len())
+		}
+	}
+}
+
+func TestOnlineMedianRejectsNonFinite(t *testing.T) {
+	var m OnlineMedian
+	_ = m.Add(1)
+	_ = m.Add(3)
+	for _, v := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		assertViolation(t, m.Add(v), Validity, SubjectX)
+	}
+	if m.Len() != 2 || m.Median() != 2 {
+		t.Errorf("rejected values changed the tracker: Len %d, Median %v", m.Len(), m.Median())
+	}
+}
@@ -0,0 +1,85 @@
+package pragmastat
+
+import "sort"
+
+// orderStatStackSize is the largest K for which OrderStat.Sample keeps its
+// scratch draws on the stack.
+const orderStatStackSize = 32
+
+// OrderStat is the distribution of the Rank-th smallest of K independent
+// draws from an inner distribution: Rank = K gives the maximum (e.g. the
+// slowest of K parallel calls) and Rank = 1 the minimum.
+type OrderStat struct {
+	Inner Distribution
+	K     int
+	Rank  int
+}
+
+// NewOrderStat creates the distribution of the rank-th order statistic of k
+// inner variates (rank is 1-based). Panics if inner is nil, k < 1, or rank is
+// outside [1, k].
+func NewOrderStat(inner Distribution, k, rank int) *OrderStat {
+	o, err := TryNewOrderStat(inner, k, rank)
+	if err != nil {
+		panic(err.Error())
+	}
+	return o
+}
+
+// TryNewOrderStat is NewOrderStat returning a *ParameterError instead of
+// panicking.
+func TryNewOrderStat(inner Distribution, k, rank int) (*OrderStat, error) {
+	if inner == nil {
+		return nil, newParameterError("OrderStat", "inner", "inner cannot be nil")
+	}
+	if k < 1 {
+		return nil, newParameterError("OrderStat", "k", "k must be at least 1")
+	}
+	if rank < 1 || rank > k {
+		return nil, newParameterError("OrderStat", "rank", "rank must be in [1, k]")
+	}
+	return &OrderStat{Inner: inner, K: k, Rank: rank}, nil
+}
+
+// Sample draws K consecutive inner.Sample(rng) values and returns the
+// Rank-th smallest, so one sample consumes exactly the random numbers of K
+// inner samples. It does not allocate for K <= 32.
+func (o *OrderStat) Sample(rng *Rng) float64 {
+	var stack [orderStatStackSize]float64
+	var draws []float64
+	if o.K <= orderStatStackSize {
+		draws = stack[:o.K]
+	} else {
+		draws = make([]float64, o.K)
+	}
+	for i := range draws {
+		draws[i] = o.Inner.Sample(rng)
+	}
+	if o.K > orderStatStackSize {
+		sort.Float64s(draws)
+		return draws[o.Rank-1]
+	}
+	// Insertion sort: cheap for small K and keeps draws off the heap.
+	for i := 1; i < len(draws); i++ {
+		for j := i; j > 0 && draws[j] < draws[j-1]; j-- {
+			draws[j], draws[j-1] = draws[j-1], draws[j]
+		}
+	}
+	return draws[o.Rank-1]
+}
+
+// Samples generates multiple samples from the order-statistic distribution.
+func (o *OrderStat) Samples(rng *Rng, count int) []float64 {
+	return sampleN(o, rng, count)
+}
+
+// SamplesInto fills dst with samples from the order-statistic distribution;
+// it does not allocate for K <= 32.
+func (o *OrderStat) SamplesInto(rng *Rng, dst []float64) {
+	fillSamples(o, rng, dst)
+}
+
+// DrawsPerSample returns K times the inner distribution's count.
+func (o *OrderStat) DrawsPerSample() int {
+	return multiplyDraws(o.K, DrawsPerSample(o.Inner))
+}
@@ -0,0 +1,44 @@
+package pragmastat
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+// TestOrderStatStream checks that each sample is the rank-th smallest of K
+// consecutive inner draws, on both the stack (K <= 32) and heap paths.
+func TestOrderStatStream(t *testing.T) {
+	inner := NewAdditive(0, 1)
+	for _, c := range []struct{ k, rank int }{{1, 1}, {5, 1}, {5, 3}, {5, 5}, {50, 1}, {50, 50}} {
+		o := NewOrderStat(inner, c.k, c.rank)
+		ref := NewRngFromString("order-stat-stream")
+		rng := NewRngFromString("order-stat-stream")
+		for i := 0; i < 20; i++ {
+			sorted := inner.Samples(ref, c.k)
+			sort.Float64s(sorted)
+			if got, want := o.Sample(rng), sorted[c.rank-1]; got != want {
+				t.Fatalf("k = %d, rank = %d: draw %d = %v, want %v", c.k, c.rank, i, got, want)
+			}
+		}
+	}
+}
+
+// TestOrderStatUniformMax checks that the maximum of k uniforms concentrates
+// near the upper endpoint: its median is 0.5^(1/k).
+func TestOrderStatUniformMax(t *testing.T) {
+	const k = 20
+	x := NewOrderStat(NewUniform(0, 1), k, k).Samples(NewRngFromString("order-stat-max"), 10_000)
+	median, err := Median(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := math.Pow(0.5, 1.0/k); math.Abs(median-want) > 0.005 {
+		t.Errorf("median = %v, want %v", median, want)
+	}
+	for _, v := range x {
+		if v < 0 || v >= 1 {
+			t.Fatalf("sample %v outside [0, 1)", v)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package pragmastat
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// DefaultOutlierK is the fence multiplier used by OutliersDefault.
+const DefaultOutlierK = 3.0
+
+// Outliers returns the indices (ascending) of the values of x that lie
+// strictly outside Center(x) ± k*Spread(x), a robust alternative to the
+// mean ± kσ rule. With k = 0 every value except exact ties with Center is
+// flagged. A tie-dominant x (zero Spread) is not an error: the fence collapses
+// to Center and every other value is flagged.
+//
+// x may hold any Number type; the fences are computed in float64. Returns a
+// Validity error for empty or non-finite x and a plain error if k is negative
+// or NaN.
+func Outliers[T Number](x []T, k float64) ([]int, error) {
+	if err := checkValidity(x, SubjectX); err != nil {
+		return nil, err
+	}
+	if math.IsNaN(k) || k < 0 {
+		return nil, fmt.Errorf("outlier multiplier k must be non-negative, got %v", k)
+	}
+	sorted := sortedOne(x, false)
+	centerVal, err := centerImpl(context.Background(), sorted, true)
+	if err != nil {
+		return nil, err
+	}
+	spreadVal, err := spreadImpl(sorted, true)
+	if err != nil {
+		return nil, err
+	}
+
+	lower, upper := centerVal, centerVal
+	if spreadVal > 0 {
+		lower, upper = centerVal-k*spreadVal, centerVal+k*spreadVal
+	}
+	var indices []int
+	for i, xi := range x {
+		if v := float64(xi); v < lower || v > upper {
+			indices = append(indices, i)
+		}
+	}
+	return indices, nil
+}
+
+// OutliersDefault is Outliers with k = DefaultOutlierK (3).
+func OutliersDefault[T Number](x []T) ([]int, error) {
+	return Outliers(x, DefaultOutlierK)
+}
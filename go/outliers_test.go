@@ -0,0 +1,94 @@
+package pragmastat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestOutliersFlagsInjectedExtreme(t *testing.T) {
+	rng := NewRngFromSeed(invarianceSeed)
+	dist := NewAdditive(10, 1)
+	x := make([]float64, 200)
+	for i := range x {
+		x[i] = dist.Sample(rng)
+	}
+	x[57] = 1000
+	x[133] = -500
+
+	got, err := OutliersDefault(x)
+	if err != nil {
+		t.Fatalf("OutliersDefault: %v", err)
+	}
+	if len(got) != 2 || got[0] != 57 || got[1] != 133 {
+		t.Errorf("OutliersDefault = %v, want [57 133]", got)
+	}
+}
+
+func TestOutliersCleanSample(t *testing.T) {
+	x := []float64{9.8, 10.1, 10, 9.9, 10.3, 10.2, 9.7}
+	got, err := Outliers(x, DefaultOutlierK)
+	if err != nil {
+		t.Fatalf("Outliers: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("Outliers = %v, want none", got)
+	}
+
+	got, err = Outliers(x, math.Inf(1))
+	if err != nil || len(got) != 0 {
+		t.Errorf("Outliers(k=+Inf) = %v, %v; want none", got, err)
+	}
+}
+
+func TestOutliersZeroK(t *testing.T) {
+	// Center of {1, 2, 3, 3, 3, 4, 5} is 3.
+	x := []float64{1, 2, 3, 3, 3, 4, 5}
+	got, err := Outliers(x, 0)
+	if err != nil {
+		t.Fatalf("Outliers: %v", err)
+	}
+	want := []int{0, 1, 5, 6}
+	if len(got) != len(want) {
+		t.Fatalf("Outliers(k=0) = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Outliers(k=0) = %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestOutliersTieDominant(t *testing.T) {
+	got, err := Outliers([]float64{5, 5, 5, 5, 6}, 3)
+	if err != nil {
+		t.Fatalf("Outliers: %v", err)
+	}
+	if len(got) != 1 || got[0] != 4 {
+		t.Errorf("Outliers = %v, want [4]", got)
+	}
+}
+
+func TestOutliersIntegers(t *testing.T) {
+	got, err := OutliersDefault([]int{10, 11, 9, 10, 12, 8, 10, 90})
+	if err != nil {
+		t.Fatalf("OutliersDefault: %v", err)
+	}
+	if len(got) != 1 || got[0] != 7 {
+		t.Errorf("OutliersDefault = %v, want [7]", got)
+	}
+}
+
+func TestOutliersErrors(t *testing.T) {
+	_, err := Outliers([]float64(nil), 3)
+	assertViolation(t, err, Validity, SubjectX)
+
+	_, err = OutliersDefault([]float64{1, math.NaN()})
+	assertViolation(t, err, Validity, SubjectX)
+
+	for _, k := range []float64{-1, math.NaN()} {
+		if _, err := Outliers([]float64{1, 2, 3}, k); err == nil {
+			t.Errorf("expected error for k = %v", k)
+		}
+	}
+}
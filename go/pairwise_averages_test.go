@@ -0,0 +1,65 @@
+package pragmastat
+
+import (
+	"math"
+	"testing"
+)
+
+func bruteCountPairwiseAveragesLE(x []float64, target float64) int64 {
+	var count int64
+	for i := range x {
+		for j := i; j < len(x); j++ {
+			if (x[i]+x[j])/2 <= target {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func TestCountPairwiseAveragesLEBruteForce(t *testing.T) {
+	rng := NewRngFromSeed(invarianceSeed)
+	for n := 1; n <= 30; n++ {
+		x := make([]float64, n)
+		for i := range x {
+			// Small integers produce many ties and exact hits on the target.
+			x[i] = float64(rng.UniformIntN(-5, 6))
+		}
+		for _, target := range []float64{-6, -2.5, -1, 0, 0.5, 3, 6, math.Inf(-1), math.Inf(1)} {
+			got, err := CountPairwiseAveragesLE(x, target, false)
+			if err != nil {
+				t.Fatalf("n=%d: %v", n, err)
+			}
+			if want := bruteCountPairwiseAveragesLE(x, target); got != want {
+				t.Errorf("n=%d, target=%v: got %d, want %d (x=%v)", n, target, got, want, x)
+			}
+		}
+	}
+}
+
+func TestCountPairwiseAveragesLEAtCenter(t *testing.T) {
+	x := []float64{7, 1, 4, 9, 2, 8}
+	center, _ := Center(x, false)
+	count, err := CountPairwiseAveragesLE(x, center, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// At least half of the 21 Walsh averages lie at or below their median.
+	if total := int64(len(x) * (len(x) + 1) / 2); 2*count < total {
+		t.Errorf("count at Center = %d of %d", count, total)
+	}
+	sorted := sortedOne(x, false)
+	if again, _ := CountPairwiseAveragesLE(sorted, center, true); again != count {
+		t.Errorf("assumeSorted count = %d, want %d", again, count)
+	}
+}
+
+func TestCountPairwiseAveragesLEErrors(t *testing.T) {
+	_, err := CountPairwiseAveragesLE(nil, 0, false)
+	assertViolation(t, err, Validity, SubjectX)
+	_, err = CountPairwiseAveragesLE([]float64{1, math.NaN()}, 0, false)
+	assertViolation(t, err, Validity, SubjectX)
+	if _, err := CountPairwiseAveragesLE([]float64{1, 2}, math.NaN(), false); err == nil {
+		t.Error("expected an error for a NaN target")
+	}
+}
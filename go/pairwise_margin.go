@@ -1,8 +1,10 @@
 package pragmastat
 
 import (
+	"context"
 	"errors"
 	"math"
+	"sync"
 )
 
 const (
@@ -16,7 +18,7 @@ const (
 // approximation for larger samples.
 //
 // Returns an error if n <= 0, m <= 0, or misrate is outside [0, 1] or NaN.
-func pairwiseMargin(n, m int, misrate float64) (int, error) {
+func pairwiseMargin(ctx context.Context, n, m int, misrate float64) (int, error) {
 	if n <= 0 {
 		return 0, NewDomainError(SubjectX)
 	}
@@ -37,14 +39,18 @@ func pairwiseMargin(n, m int, misrate float64) (int, error) {
 
 	// Use exact method for small to medium samples
 	if n+m <= maxExactSize {
-		return pairwiseMarginExact(n, m, misrate), nil
+		return pairwiseMarginExact(ctx, n, m, misrate)
 	}
 	return pairwiseMarginApprox(n, m, misrate)
 }
 
 // pairwiseMarginExact uses the exact distribution based on Loeffler's recurrence.
-func pairwiseMarginExact(n, m int, misrate float64) int {
-	return pairwiseMarginExactRaw(n, m, misrate/2) * 2
+func pairwiseMarginExact(ctx context.Context, n, m int, misrate float64) (int, error) {
+	raw, err := pairwiseMarginExactRaw(ctx, n, m, misrate/2)
+	if err != nil {
+		return 0, err
+	}
+	return raw * 2, nil
 }
 
 // pairwiseMarginApprox uses Edgeworth approximation for large samples.
@@ -58,60 +64,125 @@ func pairwiseMarginApprox(n, m int, misrate float64) (int, error) {
 
 // pairwiseMarginExactRaw implements the inversed Loeffler (1982) algorithm.
 // Reference: "Über eine Partition der nat. Zahlen und ihre Anwendung beim U-Test"
-func pairwiseMarginExactRaw(n, m int, p float64) int {
-	var total float64
-	if n+m < maxAcceptableBinomN {
-		total = float64(binomialCoefficient(n+m, m))
-	} else {
-		total = binomialCoefficientFloat(float64(n+m), float64(m))
-	}
-
-	pmf := []float64{1}   // pmf[0] = 1
-	sigma := []float64{0} // sigma[0] is unused
-
-	u := 0
+func pairwiseMarginExactRaw(ctx context.Context, n, m int, p float64) (int, error) {
+	total := binomialTotal(n+m, m)
+	counts := newLoefflerCounts(n, m)
 	cdf := 1.0 / total
 
 	if cdf >= p {
-		return 0
+		return 0, nil
 	}
 
 	for {
-		u++
-		// Ensure sigma has entry for u
-		if len(sigma) <= u {
-			value := 0
-			for d := 1; d <= n; d++ {
-				if u%d == 0 && u >= d {
-					value += d
-				}
-			}
-			for d := m + 1; d <= m+n; d++ {
-				if u%d == 0 && u >= d {
-					value -= d
-				}
-			}
-			sigma = append(sigma, float64(value))
-		}
-
-		// Compute pmf[u] using Loeffler recurrence
-		sum := 0.0
-		for i := 0; i < u; i++ {
-			sum += pmf[i] * sigma[u-i]
+		if err := checkContext(ctx); err != nil {
+			return 0, err
 		}
-		sum /= float64(u)
-		pmf = append(pmf, sum)
-
+		sum := counts.next()
 		cdf += sum / total
 		if cdf >= p {
-			return u
+			return counts.last(), nil
 		}
 		if sum == 0 {
 			break
 		}
 	}
 
-	return len(pmf) - 1
+	return counts.last(), nil
+}
+
+// MannWhitneyEdgeworthCdf returns the Edgeworth-expansion approximation of
+// P(U <= u) for the Mann–Whitney statistic U of sizes n and m, the
+// approximation the pairwise margin uses when n+m > 400. It is exposed for
+// comparing the approximation with the exact distribution. The result lies
+// in [0, 1]; u < 0 gives exactly 0 and u >= n·m gives exactly 1.
+//
+// Returns a domain error if n <= 0 or m <= 0.
+func MannWhitneyEdgeworthCdf(n, m int, u int64) (float64, error) {
+	if n <= 0 {
+		return 0, NewDomainError(SubjectX)
+	}
+	if m <= 0 {
+		return 0, NewDomainError(SubjectY)
+	}
+	switch {
+	case u < 0:
+		return 0, nil
+	case u >= int64(n)*int64(m):
+		return 1, nil
+	}
+	// edgeworthCdf(u) approximates P(U < u).
+	return edgeworthCdf(n, m, u+1), nil
+}
+
+// mannWhitneyCdf returns P(U <= u) for the Mann–Whitney statistic of sizes n
+// and m, exactly for n+m <= 400 and by the Edgeworth expansion otherwise, the
+// same switch pairwiseMargin uses.
+func mannWhitneyCdf(ctx context.Context, n, m int, u int64) (float64, error) {
+	if n+m <= maxExactSize {
+		return mannWhitneyCdfExact(ctx, n, m, u)
+	}
+	return MannWhitneyEdgeworthCdf(n, m, u)
+}
+
+// mannWhitneyCdfExact returns P(U <= u) for the Mann–Whitney statistic of
+// sizes n and m, summing the Loeffler recurrence up to u.
+func mannWhitneyCdfExact(ctx context.Context, n, m int, u int64) (float64, error) {
+	total := binomialTotal(n+m, m)
+	counts := newLoefflerCounts(n, m)
+	cdf := 1.0 / total
+	for k := int64(1); k <= u; k++ {
+		if err := checkContext(ctx); err != nil {
+			return 0, err
+		}
+		cdf += counts.next() / total
+	}
+	return math.Min(cdf, 1), nil
+}
+
+// loefflerCounts extends the Mann–Whitney frequency table one value of U at
+// a time using Loeffler's recurrence.
+type loefflerCounts struct {
+	n, m  int
+	pmf   []float64 // pmf[u] is the number of arrangements with U = u
+	sigma []float64 // sigma[0] is unused
+}
+
+func newLoefflerCounts(n, m int) *loefflerCounts {
+	return &loefflerCounts{n: n, m: m, pmf: []float64{1}, sigma: []float64{0}}
+}
+
+// last returns the largest u computed so far.
+func (l *loefflerCounts) last() int {
+	return len(l.pmf) - 1
+}
+
+// next computes and returns the count for the next value of u.
+func (l *loefflerCounts) next() float64 {
+	u := len(l.pmf)
+	// Ensure sigma has entry for u
+	if len(l.sigma) <= u {
+		value := 0
+		for d := 1; d <= l.n; d++ {
+			if u%d == 0 && u >= d {
+				value += d
+			}
+		}
+		for d := l.m + 1; d <= l.m+l.n; d++ {
+			if u%d == 0 && u >= d {
+				value -= d
+			}
+		}
+		l.sigma = append(l.sigma, float64(value))
+	}
+
+	// Compute pmf[u] using Loeffler recurrence
+	sum := 0.0
+	for i := 0; i < u; i++ {
+		sum += l.pmf[i] * l.sigma[u-i]
+	}
+	sum /= float64(u)
+	l.pmf = append(l.pmf, sum)
+	return sum
 }
 
 // pairwiseMarginApproxRaw uses inverse Edgeworth approximation.
@@ -207,20 +278,64 @@ func edgeworthCdf(n, m int, u int64) float64 {
 	return math.Max(0, math.Min(edgeworth, 1))
 }
 
-// binomialCoefficient computes C(n, k) for small values using Pascal's triangle.
-func binomialCoefficient(n, k int) int64 {
+// binomTableMaxN is the largest n kept in the Pascal-triangle cache.
+// C(64, 32) ≈ 1.8e18 still fits in int64.
+const binomTableMaxN = 64
+
+var (
+	binomTableOnce sync.Once
+	binomTable     [][]int64 // binomTable[n][k] = C(n, k); -1 marks int64 overflow
+)
+
+// buildBinomTable fills the Pascal triangle up to binomTableMaxN. Every entry is
+// a single addition of two smaller entries, so overflow is detected exactly
+// and recorded as -1 (propagating to all entries that depend on it).
+func buildBinomTable() {
+	binomTable = make([][]int64, binomTableMaxN+1)
+	for n := 0; n <= binomTableMaxN; n++ {
+		row := make([]int64, n+1)
+		row[0], row[n] = 1, 1
+		for k := 1; k < n; k++ {
+			a := binomTable[n-1][k-1]
+			b := binomTable[n-1][k]
+			if a < 0 || b < 0 || a > math.MaxInt64-b {
+				row[k] = -1
+			} else {
+				row[k] = a + b
+			}
+		}
+		binomTable[n] = row
+	}
+}
+
+// binomialCoefficient returns C(n, k) from the lazily built Pascal-triangle
+// cache. The second result is false when n exceeds the cache or the value
+// overflows int64; callers then fall back to binomialCoefficientFloat.
+func binomialCoefficient(n, k int) (int64, bool) {
 	if k < 0 || k > n {
-		return 0
+		return 0, true
+	}
+	if n > binomTableMaxN {
+		return 0, false
 	}
-	if k > n-k {
-		k = n - k
+	binomTableOnce.Do(buildBinomTable)
+	value := binomTable[n][k]
+	if value < 0 {
+		return 0, false
 	}
+	return value, true
+}
 
-	result := int64(1)
-	for i := 0; i < k; i++ {
-		result = result * int64(n-i) / int64(i+1)
+// binomialTotal returns C(n, k) as float64: exact from the cache for
+// n < maxAcceptableBinomN (and whenever the cache holds the value), otherwise
+// via the log-space approximation.
+func binomialTotal(n, k int) float64 {
+	if n < maxAcceptableBinomN {
+		if value, ok := binomialCoefficient(n, k); ok {
+			return float64(value)
+		}
 	}
-	return result
+	return binomialCoefficientFloat(float64(n), float64(k))
 }
 
 // binomialCoefficientFloat computes C(n, k) for large values using logarithms.
@@ -0,0 +1,82 @@
+package pragmastat
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestBinomialCoefficientMatchesBig(t *testing.T) {
+	for n := 0; n <= binomTableMaxN; n++ {
+		for k := 0; k <= n; k++ {
+			got, ok := binomialCoefficient(n, k)
+			if !ok {
+				t.Fatalf("C(%d, %d): unexpected overflow", n, k)
+			}
+			want := new(big.Int).Binomial(int64(n), int64(k))
+			if !want.IsInt64() || got != want.Int64() {
+				t.Fatalf("C(%d, %d) = %d, want %s", n, k, got, want)
+			}
+		}
+	}
+}
+
+func TestBinomialCoefficientBoundary(t *testing.T) {
+	// The sizes around maxAcceptableBinomN are where the old multiply-then-divide
+	// loop risked int64 overflow in its intermediate product.
+	for _, n := range []int{maxAcceptableBinomN - 1, maxAcceptableBinomN, binomTableMaxN} {
+		got, ok := binomialCoefficient(n, n/2)
+		want := new(big.Int).Binomial(int64(n), int64(n/2))
+		if !ok || got != want.Int64() {
+			t.Errorf("C(%d, %d) = %d (ok=%v), want %s", n, n/2, got, ok, want)
+		}
+	}
+	if _, ok := binomialCoefficient(binomTableMaxN+1, 1); ok {
+		t.Error("expected sizes beyond the cache to report a fallback")
+	}
+	if got := binomialTotal(100, 50); !floatEquals(got/1.0089134454556419e29, 1, 1e-9) {
+		t.Errorf("binomialTotal(100, 50) = %v, want ≈ 1.0089e29", got)
+	}
+	if got, ok := binomialCoefficient(5, 7); !ok || got != 0 {
+		t.Errorf("C(5, 7) = %d (ok=%v), want 0", got, ok)
+	}
+}
+
+// TestMannWhitneyEdgeworthCdf compares the approximation with the exact
+// distribution on both sides of the n+m = 400 switchover.
+func TestMannWhitneyEdgeworthCdf(t *testing.T) {
+	for _, c := range []struct{ n, m int }{{200, 199}, {200, 200}, {150, 251}} {
+		total := binomialTotal(c.n+c.m, c.m)
+		counts := newLoefflerCounts(c.n, c.m)
+		exact := 1.0 / total
+		for u := int64(0); u <= int64(c.n*c.m/2); u++ {
+			if u > 0 {
+				exact += counts.next() / total
+			}
+			if u%250 != 0 {
+				continue
+			}
+			approx, err := MannWhitneyEdgeworthCdf(c.n, c.m, u)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if approx < 0 || approx > 1 || exact < 0 || exact > 1 {
+				t.Fatalf("%d×%d, u=%d: exact %v, approx %v outside [0, 1]", c.n, c.m, u, exact, approx)
+			}
+			if math.Abs(approx-exact) > 1e-6 {
+				t.Errorf("%d×%d, u=%d: exact %v, approx %v", c.n, c.m, u, exact, approx)
+			}
+		}
+	}
+
+	if got, _ := MannWhitneyEdgeworthCdf(5, 7, -1); got != 0 {
+		t.Errorf("P(U <= -1) = %v, want 0", got)
+	}
+	if got, _ := MannWhitneyEdgeworthCdf(5, 7, 35); got != 1 {
+		t.Errorf("P(U <= nm) = %v, want 1", got)
+	}
+	_, err := MannWhitneyEdgeworthCdf(0, 7, 3)
+	assertViolation(t, err, Domain, SubjectX)
+	_, err = MannWhitneyEdgeworthCdf(5, -1, 3)
+	assertViolation(t, err, Domain, SubjectY)
+}
@@ -0,0 +1,59 @@
+package pragmastat
+
+import "sync"
+
+// parallelChunkSize is the number of consecutive samples ParallelSamples
+// draws from one substream. It is part of the output definition: changing it
+// changes the values for a given seed.
+const parallelChunkSize = 1 << 14
+
+// ParallelSamples generates count samples from d on workers goroutines. The
+// output is split into chunks of 16384 samples, and chunk i is drawn with
+// SamplesInto from rng advanced by i jumps (i·2^128 steps), so the result
+// depends only on rng's state and count, never on workers or scheduling.
+// Afterwards rng is advanced by one jump per chunk, past every substream it
+// handed out, so consecutive calls do not repeat values.
+//
+// d.Sample must be safe for concurrent use, as it is for the built-in
+// distributions. Panics if count is negative or workers < 1.
+func ParallelSamples(rng *Rng, d Distribution, count, workers int) []float64 {
+	if count < 0 {
+		panic("parallel samples: count must be non-negative")
+	}
+	if workers < 1 {
+		panic("parallel samples: workers must be positive")
+	}
+	result := make([]float64, count)
+	chunks := (count + parallelChunkSize - 1) / parallelChunkSize
+	streams := make([]*Rng, chunks)
+	for i := range streams {
+		streams[i] = rng.NewJumped(0)
+		rng.Jump()
+	}
+	if workers > chunks {
+		workers = chunks
+	}
+
+	next := make(chan int, chunks)
+	for i := 0; i < chunks; i++ {
+		next <- i
+	}
+	close(next)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				lo := i * parallelChunkSize
+				hi := lo + parallelChunkSize
+				if hi > count {
+					hi = count
+				}
+				SamplesInto(d, streams[i], result[lo:hi])
+			}
+		}()
+	}
+	wg.Wait()
+	return result
+}
@@ -0,0 +1,79 @@
+package pragmastat
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+)
+
+// TestParallelSamplesIndependentOfWorkers checks that the output and the
+// final generator state do not depend on the number of workers, and that
+// each chunk is the jumped substream.
+func TestParallelSamplesIndependentOfWorkers(t *testing.T) {
+	const count = 3*parallelChunkSize + 123
+	d := NewAdditive(0, 1)
+	rng1 := NewRngFromString("parallel")
+	want := ParallelSamples(rng1, d, count, 1)
+	for _, workers := range []int{2, 8, 100} {
+		rng := NewRngFromString("parallel")
+		got := ParallelSamples(rng, d, count, workers)
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("workers = %d: sample %d = %v, want %v", workers, i, got[i], want[i])
+			}
+		}
+		if rng.State() != rng1.State() {
+			t.Errorf("workers = %d: final state differs", workers)
+		}
+	}
+
+	base := NewRngFromString("parallel")
+	if got := d.Samples(base.NewJumped(2), 10); got[0] != want[2*parallelChunkSize] || got[9] != want[2*parallelChunkSize+9] {
+		t.Errorf("chunk 2 does not start the twice-jumped stream")
+	}
+	if got := base.NewJumped(4).State(); got != rng1.State() {
+		t.Errorf("rng was not advanced by one jump per chunk")
+	}
+	next := ParallelSamples(rng1, d, 10, 4)
+	if next[0] == want[0] {
+		t.Error("a second call repeated the first call's values")
+	}
+	if len(ParallelSamples(rng1, d, 0, 4)) != 0 {
+		t.Error("count = 0 must give an empty slice")
+	}
+}
+
+func TestParallelSamplesPanics(t *testing.T) {
+	for _, c := range []struct{ count, workers int }{{-1, 1}, {10, 0}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("count = %d, workers = %d: expected panic", c.count, c.workers)
+				}
+			}()
+			ParallelSamples(NewRngFromSeed(1), NewExp(1), c.count, c.workers)
+		}()
+	}
+}
+
+// BenchmarkParallelSamples compares one worker with one per CPU (at most 8)
+// on a large request; run it with -bench to see the speedup.
+func BenchmarkParallelSamples(b *testing.B) {
+	workers := runtime.NumCPU()
+	if workers > 8 {
+		workers = 8
+	}
+	const count = 1 << 23
+	d := NewAdditive(0, 1)
+	counts := []int{1}
+	if workers > 1 {
+		counts = append(counts, workers)
+	}
+	for _, w := range counts {
+		b.Run(fmt.Sprintf("workers=%d", w), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ParallelSamples(NewRngFromSeed(1), d, count, w)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package pragmastat
 
 import (
+	"context"
 	"math"
 	"testing"
 	"time"
@@ -15,7 +16,7 @@ func TestCenterPerformance(t *testing.T) {
 	}
 
 	start := time.Now()
-	result, err := centerImpl(x, false)
+	result, err := centerImpl(context.Background(), x, false)
 	elapsed := time.Since(start)
 
 	if err != nil {
@@ -75,7 +76,7 @@ func TestShiftPerformance(t *testing.T) {
 	}
 
 	start := time.Now()
-	quantiles, err := shiftQuantilesImpl(x, y, []float64{0.5}, false)
+	quantiles, err := shiftQuantilesImpl(context.Background(), x, y, []float64{0.5}, false)
 	elapsed := time.Since(start)
 
 	if err != nil {
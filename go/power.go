@@ -7,36 +7,133 @@ import "math"
 type Power struct {
 	Min   float64
 	Shape float64
+	open  bool // draw with UniformOpen (see NewPowerOpen)
 }
 
 // NewPower creates a new power (Pareto) distribution.
-// Panics if min <= 0 or shape <= 0.
+// Panics if min or shape is not positive and finite.
 func NewPower(min, shape float64) *Power {
-	if min <= 0 {
-		panic("min must be positive")
+	p, err := TryNewPower(min, shape)
+	if err != nil {
+		panic(err.Error())
 	}
-	if shape <= 0 {
-		panic("shape must be positive")
+	return p
+}
+
+// TryNewPower is NewPower returning a *ParameterError instead of panicking.
+func TryNewPower(min, shape float64) (*Power, error) {
+	if !(min > 0) || math.IsInf(min, 1) {
+		return nil, newParameterError("Power", "min", "min must be positive and finite")
+	}
+	if !(shape > 0) || math.IsInf(shape, 1) {
+		return nil, newParameterError("Power", "shape", "shape must be positive and finite")
 	}
-	return &Power{Min: min, Shape: shape}
+	return &Power{Min: min, Shape: shape}, nil
+}
+
+// NewPowerOpen is NewPower drawing its uniform with UniformOpen, so a sample
+// is never exactly min. The values match NewPower for the same seed only
+// until the generator first yields a zero uniform: UniformOpen redraws it,
+// and every later value is shifted by the extra draw.
+func NewPowerOpen(min, shape float64) *Power {
+	p := NewPower(min, shape)
+	p.open = true
+	return p
 }
 
 // Sample generates a single sample from the power distribution.
 func (p *Power) Sample(rng *Rng) float64 {
-	// Inverse CDF method: min / (1 - U)^(1/shape)
-	u := rng.UniformFloat64()
-	// Avoid division by zero
-	if u == 1.0 {
-		u = 1.0 - machineEpsilon
-	}
+	return p.quantile(rng.uniformFor(p.open))
+}
+
+// quantile is the inverse CDF: min / (1 - u)^(1/shape), for u in [0, 1).
+func (p *Power) quantile(u float64) float64 {
 	return p.Min / math.Pow(1.0-u, 1.0/p.Shape)
 }
 
+func (p *Power) antitheticPair(rng *Rng) (float64, float64) {
+	u, v := rng.UniformAntithetic()
+	return p.quantile(u), p.quantile(antitheticBelowOne(v))
+}
+
 // Samples generates multiple samples from the power distribution.
 func (p *Power) Samples(rng *Rng, count int) []float64 {
-	result := make([]float64, count)
-	for i := 0; i < count; i++ {
-		result[i] = p.Sample(rng)
+	return sampleN(p, rng, count)
+}
+
+// SamplesInto fills dst with samples from the power distribution without allocating.
+func (p *Power) SamplesInto(rng *Rng, dst []float64) {
+	fillSamples(p, rng, dst)
+}
+
+// DrawsPerSample returns 1, or VariableDraws for NewPowerOpen, which
+// rejects a zero uniform.
+func (p *Power) DrawsPerSample() int {
+	if p.open {
+		return VariableDraws
 	}
-	return result
+	return 1
+}
+
+// Pdf returns the probability density at x: shape·min^shape / x^(shape+1)
+// for x >= min.
+func (p *Power) Pdf(x float64) float64 {
+	if x < p.Min {
+		return 0
+	}
+	return p.Shape / p.Min * math.Pow(p.Min/x, p.Shape+1)
+}
+
+// Cdf returns P(X <= x): 1 - (min/x)^shape for x >= min.
+func (p *Power) Cdf(x float64) float64 {
+	if x <= p.Min {
+		return 0
+	}
+	return 1 - math.Pow(p.Min/x, p.Shape)
+}
+
+// Quantile returns the inverse CDF: min / (1 - q)^(1/shape). Quantile(1) is
+// +Inf, and q outside [0, 1] gives NaN.
+func (p *Power) Quantile(q float64) float64 {
+	if invalidProbability(q) {
+		return math.NaN()
+	}
+	return p.Min / math.Pow(1-q, 1/p.Shape)
+}
+
+// TrueCenter returns the population Center, computed numerically from Cdf
+// and Quantile (there is no closed form).
+func (p *Power) TrueCenter() float64 {
+	return numericTrueCenter(p)
+}
+
+// TrueSpread returns the population Spread, computed numerically from Cdf
+// and Quantile (there is no closed form).
+func (p *Power) TrueSpread() float64 {
+	return numericTrueSpread(p)
+}
+
+// TrueMean returns the population mean shape·min/(shape-1), or +Inf for
+// shape <= 1.
+func (p *Power) TrueMean() float64 {
+	if p.Shape <= 1 {
+		return math.Inf(1)
+	}
+	return p.Shape * p.Min / (p.Shape - 1)
+}
+
+// TrueVariance returns the population variance
+// min²·shape/((shape-1)²·(shape-2)), or +Inf for shape <= 2 (the second
+// moment diverges).
+func (p *Power) TrueVariance() float64 {
+	if p.Shape <= 2 {
+		return math.Inf(1)
+	}
+	d := p.Shape - 1
+	return p.Min * p.Min * p.Shape / (d * d * (p.Shape - 2))
+}
+
+// TrueStdDev returns the population standard deviation, √TrueVariance.
+func (p *Power) TrueStdDev() float64 {
+	return math.Sqrt(p.TrueVariance())
 }
@@ -0,0 +1,235 @@
+package pragmastat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestQuantile(t *testing.T) {
+	x := []float64{4, 1, 3, 2}
+	for _, c := range []struct{ p, want float64 }{
+		{0, 1}, {1.0 / 3, 2}, {0.5, 2.5}, {0.75, 3.25}, {1, 4},
+	} {
+		got, err := Quantile(x, c.p, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !floatEquals(got, c.want, 1e-15) {
+			t.Errorf("Quantile(%v) = %v, want %v", c.p, got, c.want)
+		}
+	}
+
+	y := NewExp(1).Samples(NewRngFromString("quantile-median"), 101)
+	median, _ := Median(y)
+	if got, _ := Quantile(y, 0.5, false); got != median {
+		t.Errorf("Quantile(0.5) = %v, Median = %v", got, median)
+	}
+
+	_, err := Quantile(nil, 0.5, false)
+	assertViolation(t, err, Validity, SubjectX)
+	for _, p := range []float64{-0.1, 1.1, math.NaN()} {
+		if _, err := Quantile(x, p, false); err == nil {
+			t.Errorf("p = %v: expected an error", p)
+		}
+	}
+}
+
+func TestIQR(t *testing.T) {
+	for _, c := range []struct {
+		x    []float64
+		want float64
+	}{
+		// Type-7 quartiles: h = (n-1)·p interpolates sorted[⌊h⌋] to sorted[⌊h⌋+1].
+		{[]float64{4, 1, 3, 2}, 3.25 - 1.75},
+		{[]float64{8, 1, 7, 2, 6, 3, 5, 4}, 6.25 - 2.75},
+		{[]float64{1, 2, 3, 4, 100}, 4 - 2},
+		{[]float64{5}, 0},
+	} {
+		got, err := IQR(c.x, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !floatEquals(got, c.want, 1e-15) {
+			t.Errorf("IQR(%v) = %v, want %v", c.x, got, c.want)
+		}
+	}
+
+	x := NewExp(1).Samples(NewRngFromString("iqr-scale"), 101)
+	base, _ := IQR(x, false)
+	for _, c := range []struct{ scale, offset float64 }{{2, 0}, {0.5, 10}, {-3, 1}} {
+		got, _ := IQR(addScalar(mulScalar(x, c.scale), c.offset), false)
+		if want := math.Abs(c.scale) * base; !floatEquals(got, want, 1e-12) {
+			t.Errorf("IQR(%v·x + %v) = %v, want %v", c.scale, c.offset, got, want)
+		}
+	}
+
+	// Integer data goes through the same float64 computation.
+	if got, err := IQR([]int{4, 1, 3, 2}, false); err != nil || got != 1.5 {
+		t.Errorf("IQR of ints = %v, %v, want 1.5", got, err)
+	}
+
+	_, err := IQR([]float64(nil), false)
+	assertViolation(t, err, Validity, SubjectX)
+	_, err = IQR([]float64{1, math.Inf(1)}, false)
+	assertViolation(t, err, Validity, SubjectX)
+}
+
+func TestSampleQuantile(t *testing.T) {
+	weighted := func(values, weights []float64) *Sample {
+		s, err := NewWeightedSample(values, weights, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	unweighted, _ := NewSample([]float64{4, 1, 3, 2})
+	for _, c := range []struct {
+		s       *Sample
+		p, want float64
+	}{
+		// Weights 1, 2, 1 put 1, 2, 3 at positions 0, 1.5, 3 out of 3.
+		{weighted([]float64{3, 1, 2}, []float64{1, 1, 2}), 0, 1},
+		{weighted([]float64{3, 1, 2}, []float64{1, 1, 2}), 0.75, 2.5},
+		{weighted([]float64{1, 2, 3}, []float64{1, 2, 1}), 0.25, 1.5},
+		{weighted([]float64{1, 2, 3}, []float64{1, 2, 1}), 0.5, 2},
+		// Weights 1, 1, 2 put 1, 2, 3 at positions 0, 1, 2.5 out of 2.5.
+		{weighted([]float64{1, 2, 3}, []float64{1, 1, 2}), 0.4, 2},
+		{weighted([]float64{1, 2, 3}, []float64{1, 1, 2}), 0.5, 2 + 1.0/6},
+		{weighted([]float64{1, 2, 3}, []float64{1, 1, 2}), 1, 3},
+		// Zero weights drop out: 1, 3 with weights 1, 3 span positions 0 to 2.
+		{weighted([]float64{1, 2, 3, 9}, []float64{1, 0, 3, 0}), 0.5, 2},
+		{weighted([]float64{5, 7, 1}, []float64{0, 2, 0}), 0.3, 7},
+		{unweighted, 0.75, 3.25},
+	} {
+		got, err := c.s.Quantile(c.p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !floatEquals(got.Value, c.want, 1e-12) {
+			t.Errorf("Quantile(%v) of %v weighted %v = %v, want %v", c.p, c.s.Values(), c.s.Weights(), got.Value, c.want)
+		}
+	}
+
+	x := NewExp(1).Samples(NewRngFromString("sample-quantile"), 37)
+	weights := make([]float64, len(x))
+	for i := range weights {
+		weights[i] = 0.3
+	}
+	equal := weighted(x, weights)
+	padded := weighted(append(append([]float64{}, x...), -50, 50), append(weights, 0, 0))
+	for _, p := range []float64{0, 0.1, 1.0 / 3, 0.5, 0.77, 1} {
+		want, _ := Quantile(x, p, false)
+		for _, s := range []*Sample{equal, padded} {
+			if got, _ := s.Quantile(p); got.Value != want {
+				t.Errorf("Quantile(%v) = %v, want unweighted %v", p, got.Value, want)
+			}
+		}
+	}
+
+	for _, p := range []float64{-0.1, 1.1, math.NaN()} {
+		if _, err := equal.Quantile(p); err == nil {
+			t.Errorf("Quantile(%v) should fail", p)
+		}
+	}
+	var nilSample *Sample
+	if _, err := nilSample.Quantile(0.5); err == nil {
+		t.Error("nil sample should fail")
+	}
+}
+
+func TestRank(t *testing.T) {
+	x := []int{5, 1, 3, 3, 3, 7}
+	for _, c := range []struct {
+		v            float64
+		lower, upper int
+	}{
+		{0, 1, 1}, {1, 1, 1}, {2, 2, 2}, {3, 2, 4}, {4, 5, 5}, {5, 5, 5},
+		{6.5, 6, 6}, {7, 6, 6}, {8, 7, 7}, {math.NaN(), 0, 0},
+	} {
+		lower, upper := Rank(x, c.v)
+		if lower != c.lower || upper != c.upper {
+			t.Errorf("Rank(%v) = (%d, %d), want (%d, %d)", c.v, lower, upper, c.lower, c.upper)
+		}
+	}
+	if x[0] != 5 || x[5] != 7 {
+		t.Errorf("Rank modified its input: %v", x)
+	}
+	if lower, upper := Rank([]float64{}, 2); lower != 1 || upper != 1 {
+		t.Errorf("empty Rank = (%d, %d), want (1, 1)", lower, upper)
+	}
+}
+
+// TestQQPointsNearDiagonal checks that a large sample from the reference
+// distribution gives points close to the diagonal at the evenly spaced
+// interior probabilities.
+func TestQQPointsNearDiagonal(t *testing.T) {
+	d := NewAdditive(5, 2)
+	x := d.Samples(NewRngFromString("qq-points"), 20000)
+	points, err := QQPoints(x, d, 19)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 19 {
+		t.Fatalf("len = %d, want 19", len(points))
+	}
+	for i, pt := range points {
+		p := (float64(i) + 0.5) / 19
+		if pt.X != d.Quantile(p) {
+			t.Errorf("point %d: X = %v, want Quantile(%v) = %v", i, pt.X, p, d.Quantile(p))
+		}
+		if math.Abs(pt.Y-pt.X) > 0.2 {
+			t.Errorf("point %d: (%v, %v) is far from the diagonal", i, pt.X, pt.Y)
+		}
+	}
+
+	// A sample from another distribution bends away in the tails.
+	heavy := NewCauchy(5, 2).Samples(NewRngFromString("qq-heavy"), 20000)
+	points, err = QQPoints(heavy, d, 19)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first := points[0]; first.Y > first.X-1 {
+		t.Errorf("Cauchy vs Additive: first point %+v should lie well below the diagonal", first)
+	}
+}
+
+func TestQQSamples(t *testing.T) {
+	rng := NewRngFromString("qq-samples")
+	x := NewGamma(3, 1).Samples(rng, 20000)
+	y := NewGamma(3, 1).Samples(rng, 20000)
+	points, err := QQSamples(x, y, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, pt := range points {
+		if math.Abs(pt.Y-pt.X) > 0.15 {
+			t.Errorf("point %d: (%v, %v) is far from the diagonal", i, pt.X, pt.Y)
+		}
+	}
+
+	for i, pt := range points {
+		p := (float64(i) + 0.5) / 10
+		qx, _ := Quantile(x, p, false)
+		qy, _ := Quantile(y, p, false)
+		if pt.X != qx || pt.Y != qy {
+			t.Errorf("point %d: %+v, want (%v, %v)", i, pt, qx, qy)
+		}
+	}
+}
+
+func TestQQErrors(t *testing.T) {
+	x := []float64{1, 2, 3}
+	if _, err := QQPoints(x, NewExp(1), 1); err == nil {
+		t.Error("count 1: expected an error")
+	}
+	if _, err := QQPoints(x, nil, 5); err == nil {
+		t.Error("nil dist: expected an error")
+	}
+	_, err := QQPoints([]float64{}, NewExp(1), 5)
+	assertViolation(t, err, Validity, SubjectX)
+	_, err = QQSamples(x, []float64{math.Inf(1)}, 5)
+	assertViolation(t, err, Validity, SubjectY)
+	if _, err := QQSamples(x, x, 0); err == nil {
+		t.Error("count 0: expected an error")
+	}
+}
@@ -1,6 +1,7 @@
 package pragmastat
 
 import (
+	"context"
 	"encoding/json"
 	"math"
 	"os"
@@ -63,7 +64,7 @@ func TestReferenceData(t *testing.T) {
 	t.Run("pairwise-margin", func(t *testing.T) {
 		forEachFixture(t, "pairwise-margin", func(t *testing.T, td TestData, input PairwiseMarginInput) {
 			if len(td.ExpectedError) > 0 {
-				_, err := pairwiseMargin(input.N, input.M, input.Misrate)
+				_, err := pairwiseMargin(context.Background(), input.N, input.M, input.Misrate)
 				assertErrorMatches(t, td.ExpectedError, err, true)
 				return
 			}
@@ -71,7 +72,7 @@ func TestReferenceData(t *testing.T) {
 			if err := json.Unmarshal(td.Output, &expected); err != nil {
 				t.Fatalf("Failed to parse output data: %v", err)
 			}
-			actual, err := pairwiseMargin(input.N, input.M, input.Misrate)
+			actual, err := pairwiseMargin(context.Background(), input.N, input.M, input.Misrate)
 			if err != nil {
 				t.Fatalf("PairwiseMargin returned unexpected error: %v", err)
 			}
@@ -361,6 +362,20 @@ type PowerDistTestCase struct {
 	Output []float64      `json:"output"`
 }
 
+// StringSeedDistInput represents input for string-seeded distribution tests.
+// Params are the constructor arguments in declaration order.
+type StringSeedDistInput struct {
+	Distribution string    `json:"distribution"`
+	Seed         string    `json:"seed"`
+	Params       []float64 `json:"params"`
+	Count        int       `json:"count"`
+}
+
+type StringSeedDistTestCase struct {
+	Input  StringSeedDistInput `json:"input"`
+	Output []float64           `json:"output"`
+}
+
 func TestRngUniformReference(t *testing.T) {
 	dirPath := "../tests/rng"
 	files, err := os.ReadDir(dirPath)
@@ -989,6 +1004,79 @@ func TestPowerDistributionReference(t *testing.T) {
 	}
 }
 
+// TestStringSeedDistributionReference guards the determinism contract for
+// string-seeded samples (including the values printed by the demo). The
+// fixtures come from the Rust generator. Uniform samples involve only IEEE
+// arithmetic and must match bit for bit; the others go through Log, Exp, or
+// Pow, whose last-bit rounding differs between Go's math package and the
+// platform libm, so they must agree to within a few ulps.
+func TestStringSeedDistributionReference(t *testing.T) {
+	dirPath := filepath.Join("../tests", "distributions", "string-seed")
+	files, err := os.ReadDir(dirPath)
+	if err != nil {
+		t.Fatalf("Failed to read directory: %v", err)
+	}
+
+	seen := map[string]bool{}
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+
+		testName := strings.TrimSuffix(file.Name(), ".json")
+		t.Run(testName, func(t *testing.T) {
+			filePath := filepath.Join(dirPath, file.Name())
+			data, err := os.ReadFile(filePath)
+			if err != nil {
+				t.Fatalf("Failed to read test file: %v", err)
+			}
+
+			var testData StringSeedDistTestCase
+			if err := json.Unmarshal(data, &testData); err != nil {
+				t.Fatalf("Failed to parse test data: %v", err)
+			}
+
+			p := testData.Input.Params
+			var dist Distribution
+			switch testData.Input.Distribution {
+			case "uniform":
+				dist = NewUniform(p[0], p[1])
+			case "additive":
+				dist = NewAdditive(p[0], p[1])
+			case "multiplic":
+				dist = NewMultiplic(p[0], p[1])
+			case "exp":
+				dist = NewExp(p[0])
+			case "power":
+				dist = NewPower(p[0], p[1])
+			default:
+				t.Fatalf("Unknown distribution %q", testData.Input.Distribution)
+			}
+			seen[testData.Input.Distribution] = true
+
+			rng := NewRngFromString(testData.Input.Seed)
+			for i := 0; i < testData.Input.Count; i++ {
+				actual := dist.Sample(rng)
+				expected := testData.Output[i]
+				if testData.Input.Distribution == "uniform" {
+					if math.Float64bits(actual) != math.Float64bits(expected) {
+						t.Errorf("uniform sample at index %d = %v, want %v (bit mismatch)", i, actual, expected)
+					}
+				} else if !floatEquals(actual, expected, 1e-14*math.Max(1, math.Abs(expected))) {
+					t.Errorf("%s sample at index %d = %v, want %v",
+						testData.Input.Distribution, i, actual, expected)
+				}
+			}
+		})
+	}
+
+	for _, name := range []string{"uniform", "additive", "multiplic", "exp", "power"} {
+		if !seen[name] {
+			t.Errorf("No string-seed fixture for the %s distribution", name)
+		}
+	}
+}
+
 func TestSampleNegativeKPanics(t *testing.T) {
 	defer func() {
 		if r := recover(); r == nil {
@@ -1423,7 +1511,7 @@ func TestUnitPropagation(t *testing.T) {
 				if err != nil {
 					t.Fatalf("Failed to resolve y_unit: %v", err)
 				}
-				sy, err := newSample(input.Y, nil, yUnit)
+				sy, err := NewSampleForSubject(input.Y, nil, yUnit, SubjectY)
 				if err != nil {
 					t.Fatalf("Failed to create sample Y: %v", err)
 				}
@@ -1440,7 +1528,7 @@ func TestUnitPropagation(t *testing.T) {
 				if err != nil {
 					t.Fatalf("Failed to resolve y_unit: %v", err)
 				}
-				sy, err := newSample(input.Y, nil, yUnit)
+				sy, err := NewSampleForSubject(input.Y, nil, yUnit, SubjectY)
 				if err != nil {
 					t.Fatalf("Failed to create sample Y: %v", err)
 				}
@@ -1457,7 +1545,7 @@ func TestUnitPropagation(t *testing.T) {
 				if err != nil {
 					t.Fatalf("Failed to resolve y_unit: %v", err)
 				}
-				sy, err := newSample(input.Y, nil, yUnit)
+				sy, err := NewSampleForSubject(input.Y, nil, yUnit, SubjectY)
 				if err != nil {
 					t.Fatalf("Failed to create sample Y: %v", err)
 				}
@@ -1694,7 +1782,7 @@ func TestCompare2Reference(t *testing.T) {
 				if sxErr != nil {
 					return
 				}
-				sy, syErr := newSample(input.Y, nil, nil)
+				sy, syErr := NewSampleForSubject(input.Y, nil, nil, SubjectY)
 				if syErr != nil {
 					return
 				}
@@ -1735,7 +1823,7 @@ func TestCompare2Reference(t *testing.T) {
 			}
 
 			sx := mustSample(t, input.X)
-			sy, err := newSample(input.Y, nil, nil)
+			sy, err := NewSampleForSubject(input.Y, nil, nil, SubjectY)
 			if err != nil {
 				t.Fatalf("Failed to create sample Y: %v", err)
 			}
@@ -0,0 +1,158 @@
+package pragmastat
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// ShiftReport computes Shift(x, y) and returns it together with a
+// direction-aware description for reports, such as
+// "x is typically 2.00 units larger than y". A negative shift is described
+// from y's side ("y is typically 2.00 units larger than x"), and a shift that
+// rounds to 0.00 is described as "x and y are typically about equal".
+//
+// Errors are those of Shift. If assumeSorted is true, both x and y are
+// assumed already sorted ascending.
+func ShiftReport(x, y []float64, assumeSorted bool) (float64, string, error) {
+	shift, err := Shift(x, y, assumeSorted)
+	if err != nil {
+		return 0, "", err
+	}
+	return shift, describeShift(shift), nil
+}
+
+// describeShift renders the sentence used by ShiftReport.
+func describeShift(shift float64) string {
+	magnitude := fmt.Sprintf("%.2f", math.Abs(shift))
+	switch {
+	case magnitude == "0.00":
+		return "x and y are typically about equal"
+	case shift > 0:
+		return fmt.Sprintf("x is typically %s units larger than y", magnitude)
+	default:
+		return fmt.Sprintf("y is typically %s units larger than x", magnitude)
+	}
+}
+
+// JSONFloat is a float64 that marshals NaN and ±Inf as the strings "NaN",
+// "Infinity", and "-Infinity" (the convention of the JSON test fixtures)
+// instead of failing, and accepts them back when unmarshaling.
+type JSONFloat float64
+
+// MarshalJSON implements json.Marshaler.
+func (f JSONFloat) MarshalJSON() ([]byte, error) {
+	v := float64(f)
+	switch {
+	case math.IsNaN(v):
+		return []byte(`"NaN"`), nil
+	case math.IsInf(v, 1):
+		return []byte(`"Infinity"`), nil
+	case math.IsInf(v, -1):
+		return []byte(`"-Infinity"`), nil
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (f *JSONFloat) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		switch s {
+		case "NaN":
+			*f = JSONFloat(math.NaN())
+		case "Infinity":
+			*f = JSONFloat(math.Inf(1))
+		case "-Infinity":
+			*f = JSONFloat(math.Inf(-1))
+		default:
+			return fmt.Errorf("invalid float string %s", strconv.Quote(s))
+		}
+		return nil
+	}
+	var v float64
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*f = JSONFloat(v)
+	return nil
+}
+
+// EstimatorSummary is the document produced by EstimatorReport. An estimator
+// whose assumptions are violated is null, with the reason under Errors.
+type EstimatorSummary struct {
+	N         int               `json:"n"`
+	Center    *JSONFloat        `json:"center"`
+	Spread    *JSONFloat        `json:"spread"`
+	RelSpread *JSONFloat        `json:"rel_spread"`
+	Median    *JSONFloat        `json:"median"`
+	Errors    map[string]string `json:"errors,omitempty"`
+}
+
+// TwoSampleSummary is the document produced by TwoSampleReport. An estimator
+// whose assumptions are violated is null, with the reason under Errors.
+type TwoSampleSummary struct {
+	N         int               `json:"n"`
+	M         int               `json:"m"`
+	Shift     *JSONFloat        `json:"shift"`
+	Ratio     *JSONFloat        `json:"ratio"`
+	AvgSpread *JSONFloat        `json:"avg_spread"`
+	Disparity *JSONFloat        `json:"disparity"`
+	Errors    map[string]string `json:"errors,omitempty"`
+}
+
+// EstimatorReport returns a JSON EstimatorSummary of x (n, Center, Spread,
+// RelSpread, Median) for machine-readable output in CI pipelines. Estimators
+// that fail on x, such as Spread on tie-dominant data, are reported as null
+// with the error message under "errors"; the call itself fails only if x is
+// empty or non-finite (Validity error).
+func EstimatorReport(x []float64) ([]byte, error) {
+	if err := checkValidity(x, SubjectX); err != nil {
+		return nil, err
+	}
+	sorted := sortedOne(x, false)
+	summary := EstimatorSummary{N: len(x)}
+	errs := make(map[string]string)
+	summary.Center = reportField(errs, "center", func() (float64, error) { return Center(sorted, true) })
+	summary.Spread = reportField(errs, "spread", func() (float64, error) { return Spread(sorted, true) })
+	summary.RelSpread = reportField(errs, "rel_spread", func() (float64, error) { return RelSpread(sorted, true) })
+	summary.Median = reportField(errs, "median", func() (float64, error) { return Median(sorted) })
+	if len(errs) > 0 {
+		summary.Errors = errs
+	}
+	return json.Marshal(summary)
+}
+
+// TwoSampleReport returns a JSON TwoSampleSummary of x and y (n, m, Shift,
+// Ratio, AvgSpread, Disparity). As in EstimatorReport, failing estimators
+// are null with the reason under "errors"; the call fails only on a
+// Validity error for x or y.
+func TwoSampleReport(x, y []float64) ([]byte, error) {
+	if err := checkTwoSample(x, y, false); err != nil {
+		return nil, err
+	}
+	sortedX, sortedY := sortedOne(x, false), sortedOne(y, false)
+	summary := TwoSampleSummary{N: len(x), M: len(y)}
+	errs := make(map[string]string)
+	summary.Shift = reportField(errs, "shift", func() (float64, error) { return Shift(sortedX, sortedY, true) })
+	summary.Ratio = reportField(errs, "ratio", func() (float64, error) { return Ratio(sortedX, sortedY, true) })
+	summary.AvgSpread = reportField(errs, "avg_spread", func() (float64, error) { return avgSpread(sortedX, sortedY, true) })
+	summary.Disparity = reportField(errs, "disparity", func() (float64, error) { return Disparity(sortedX, sortedY, true) })
+	if len(errs) > 0 {
+		summary.Errors = errs
+	}
+	return json.Marshal(summary)
+}
+
+// reportField evaluates one estimator for a summary, recording its error
+// under name and returning nil (JSON null) on failure.
+func reportField(errs map[string]string, name string, estimate func() (float64, error)) *JSONFloat {
+	v, err := estimate()
+	if err != nil {
+		errs[name] = err.Error()
+		return nil
+	}
+	f := JSONFloat(v)
+	return &f
+}
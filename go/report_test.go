@@ -0,0 +1,154 @@
+package pragmastat
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestShiftReport(t *testing.T) {
+	cases := []struct {
+		name      string
+		x, y      []float64
+		wantShift float64
+		want      string
+	}{
+		{"positive", []float64{3, 4, 5}, []float64{1, 2, 3}, 2, "x is typically 2.00 units larger than y"},
+		{"negative", []float64{1, 2, 3}, []float64{3.5, 4.5, 5.5}, -2.5, "y is typically 2.50 units larger than x"},
+		{"zero", []float64{1, 2, 3}, []float64{1, 2, 3}, 0, "x and y are typically about equal"},
+		{"near zero positive", []float64{1.001, 2.001, 3.001}, []float64{1, 2, 3}, 0.001, "x and y are typically about equal"},
+		{"near zero negative", []float64{1, 2, 3}, []float64{1.004, 2.004, 3.004}, -0.004, "x and y are typically about equal"},
+		{"rounds up to a cent", []float64{1.006, 2.006, 3.006}, []float64{1, 2, 3}, 0.006, "x is typically 0.01 units larger than y"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			shift, desc, err := ShiftReport(c.x, c.y, false)
+			if err != nil {
+				t.Fatalf("ShiftReport: %v", err)
+			}
+			if !floatEquals(shift, c.wantShift, 1e-9) {
+				t.Errorf("shift = %v, want %v", shift, c.wantShift)
+			}
+			if desc != c.want {
+				t.Errorf("description = %q, want %q", desc, c.want)
+			}
+		})
+	}
+}
+
+func TestShiftReportViolation(t *testing.T) {
+	_, desc, err := ShiftReport([]float64{1, 2}, nil, false)
+	assertViolation(t, err, Validity, SubjectY)
+	if desc != "" {
+		t.Errorf("description on error = %q, want empty", desc)
+	}
+}
+
+func TestEstimatorReport(t *testing.T) {
+	x := []float64{3, 1, 4, 1, 5, 9, 2, 6}
+	data, err := EstimatorReport(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var summary EstimatorSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatalf("round trip: %v (%s)", err, data)
+	}
+	if summary.N != len(x) || summary.Errors != nil {
+		t.Fatalf("unexpected summary %s", data)
+	}
+	center, _ := Center(x, false)
+	spread, _ := Spread(x, false)
+	relSpread, _ := RelSpread(x, false)
+	median, _ := Median(x)
+	for name, c := range map[string]struct {
+		got  *JSONFloat
+		want float64
+	}{
+		"center":     {summary.Center, center},
+		"spread":     {summary.Spread, spread},
+		"rel_spread": {summary.RelSpread, relSpread},
+		"median":     {summary.Median, median},
+	} {
+		if c.got == nil || float64(*c.got) != c.want {
+			t.Errorf("%s = %v, want %v", name, c.got, c.want)
+		}
+	}
+
+	// Tie-dominant data: Spread is null with a reason; RelSpread is 0.
+	data, err = EstimatorReport([]float64{2, 2, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	summary = EstimatorSummary{}
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatal(err)
+	}
+	if summary.Spread != nil || summary.RelSpread == nil || *summary.RelSpread != 0 || summary.Center == nil {
+		t.Errorf("constant input: %s", data)
+	}
+	if _, ok := summary.Errors["spread"]; !ok {
+		t.Errorf("constant input: missing spread error in %s", data)
+	}
+
+	_, err = EstimatorReport(nil)
+	assertViolation(t, err, Validity, SubjectX)
+}
+
+func TestTwoSampleReport(t *testing.T) {
+	x := []float64{10, 12, 11, 15, 13}
+	y := []float64{5, 7, 6, 8}
+	data, err := TwoSampleReport(x, y)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var summary TwoSampleSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatalf("round trip: %v (%s)", err, data)
+	}
+	shift, _ := Shift(x, y, false)
+	ratio, _ := Ratio(x, y, false)
+	avg, _ := avgSpread(x, y, false)
+	disparity, _ := Disparity(x, y, false)
+	if summary.N != 5 || summary.M != 4 || summary.Errors != nil ||
+		float64(*summary.Shift) != shift || float64(*summary.Ratio) != ratio ||
+		float64(*summary.AvgSpread) != avg || float64(*summary.Disparity) != disparity {
+		t.Errorf("unexpected summary %s", data)
+	}
+
+	// Non-positive y: Ratio is null, the rest is still reported.
+	data, _ = TwoSampleReport(x, []float64{-1, 2, 3})
+	summary = TwoSampleSummary{}
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatal(err)
+	}
+	if summary.Ratio != nil || summary.Shift == nil || summary.Errors["ratio"] == "" {
+		t.Errorf("non-positive y: %s", data)
+	}
+
+	_, err = TwoSampleReport(x, nil)
+	assertViolation(t, err, Validity, SubjectY)
+}
+
+func TestJSONFloatSpecialValues(t *testing.T) {
+	for _, v := range []float64{math.NaN(), math.Inf(1), math.Inf(-1), 0, -2.5, 1e300} {
+		data, err := json.Marshal(JSONFloat(v))
+		if err != nil {
+			t.Fatalf("marshal %v: %v", v, err)
+		}
+		var back JSONFloat
+		if err := json.Unmarshal(data, &back); err != nil {
+			t.Fatalf("unmarshal %s: %v", data, err)
+		}
+		if !floatEquals(float64(back), v, 0) {
+			t.Errorf("%v round-tripped as %v via %s", v, back, data)
+		}
+	}
+	if data, _ := json.Marshal(JSONFloat(math.Inf(-1))); string(data) != `"-Infinity"` {
+		t.Errorf("-Inf encoded as %s", data)
+	}
+	var f JSONFloat
+	if err := json.Unmarshal([]byte(`"inf"`), &f); err == nil {
+		t.Error("expected an error for an unknown float string")
+	}
+}
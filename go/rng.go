@@ -1,7 +1,13 @@
 // Package pragmastat provides a deterministic RNG for cross-language reproducibility.
 package pragmastat
 
-import "time"
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
 
 // Rng is a deterministic random number generator.
 //
@@ -11,8 +17,21 @@ import "time"
 // Thread safety: Rng instances are NOT safe for concurrent use. Each goroutine
 // must use its own instance. Sharing an instance across goroutines without
 // external synchronization produces undefined (non-reproducible) output.
+// NewRngPerG derives reproducible per-worker generators; LockedRng shares one
+// generator behind a mutex when throughput and reproducibility do not matter.
 type Rng struct {
 	inner *xoshiro256PlusPlus
+	// origin is the state the generator was created with; Split derives
+	// children from it so that they do not depend on how far r has advanced.
+	origin [4]uint64
+	// unbiased switches index draws in the collection functions from modulo
+	// reduction to uniformBelow (see SetUnbiased).
+	unbiased bool
+}
+
+// newRng wraps inner, recording its current state as the origin.
+func newRng(inner *xoshiro256PlusPlus) *Rng {
+	return &Rng{inner: inner, origin: inner.state}
 }
 
 // NewRng creates a new Rng with system entropy (non-deterministic).
@@ -23,17 +42,156 @@ func NewRng() *Rng {
 // NewRngFromSeed creates a new Rng from an integer seed.
 // The same seed always produces the same sequence of random numbers.
 func NewRngFromSeed(seed int64) *Rng {
-	return &Rng{
-		inner: newXoshiro256PlusPlus(uint64(seed)),
-	}
+	return newRng(newXoshiro256PlusPlus(uint64(seed)))
 }
 
 // NewRngFromString creates a new Rng from a string seed.
 // The string is hashed using FNV-1a to produce a numeric seed.
 func NewRngFromString(seed string) *Rng {
-	return &Rng{
-		inner: newXoshiro256PlusPlus(fnv1aHash(seed)),
+	return newRng(newXoshiro256PlusPlus(fnv1aHash(seed)))
+}
+
+// SeedFromString returns the numeric seed that NewRngFromString derives from
+// s: the FNV-1a 64-bit hash of its UTF-8 bytes, reinterpreted as int64.
+// NewRngFromString(s) and NewRngFromSeed(SeedFromString(s)) produce the same
+// sequence.
+func SeedFromString(s string) int64 {
+	return int64(fnv1aHash(s))
+}
+
+// NewRngFromBytes creates a new Rng from a byte-slice seed.
+// The bytes are hashed using FNV-1a, so NewRngFromBytes([]byte(s)) produces
+// the same sequence as NewRngFromString(s). An empty slice is a valid seed.
+func NewRngFromBytes(seed []byte) *Rng {
+	return newRng(newXoshiro256PlusPlus(fnv1aHash(seed)))
+}
+
+// NewRngFromStringAndIndex creates a new Rng from a string seed and a replica index.
+// The string is hashed using FNV-1a and expanded with SplitMix64 as in
+// NewRngFromString; each of the four state words is then XORed with the
+// successive outputs of a second SplitMix64 seeded with index. Streams for
+// different indices are decorrelated rather than offsets of one sequence.
+func NewRngFromStringAndIndex(seed string, index uint64) *Rng {
+	return newRng(newXoshiro256PlusPlusIndexed(fnv1aHash(seed), index))
+}
+
+// ========================================================================
+// State Persistence
+// ========================================================================
+
+// rngBinaryVersion is the leading byte of the MarshalBinary layout.
+const rngBinaryVersion = 1
+
+// rngBinarySize is the MarshalBinary length: a version byte followed by the
+// four current and the four origin state words, little-endian.
+const rngBinarySize = 1 + 8*8
+
+// NewRngFromState creates an Rng that continues from a state previously
+// obtained with State. The state also becomes the origin for Split. Panics if
+// state is all zeros, which is not a valid xoshiro256++ state.
+func NewRngFromState(state [4]uint64) *Rng {
+	if state == [4]uint64{} {
+		panic("state: all-zero state is invalid")
+	}
+	return newRng(&xoshiro256PlusPlus{state: state})
+}
+
+// State returns the four xoshiro256++ state words. Passing them to
+// NewRngFromState resumes the exact same sequence.
+func (r *Rng) State() [4]uint64 {
+	return r.inner.state
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The layout is a version
+// byte (currently 1) followed by the four current state words and the four
+// origin state words, all little-endian.
+func (r *Rng) MarshalBinary() ([]byte, error) {
+	data := make([]byte, rngBinarySize)
+	data[0] = rngBinaryVersion
+	for i, word := range r.inner.state {
+		binary.LittleEndian.PutUint64(data[1+8*i:], word)
+	}
+	for i, word := range r.origin {
+		binary.LittleEndian.PutUint64(data[33+8*i:], word)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, restoring a state
+// written by MarshalBinary. Malformed input (wrong length, unknown version, or
+// an all-zero state or origin) returns an error and leaves r unchanged.
+func (r *Rng) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("rng state is empty")
 	}
+	if data[0] != rngBinaryVersion {
+		return fmt.Errorf("unsupported rng state version %d", data[0])
+	}
+	if len(data) != rngBinarySize {
+		return fmt.Errorf("rng state must be %d bytes, got %d", rngBinarySize, len(data))
+	}
+	var state, origin [4]uint64
+	for i := range state {
+		state[i] = binary.LittleEndian.Uint64(data[1+8*i:])
+		origin[i] = binary.LittleEndian.Uint64(data[33+8*i:])
+	}
+	if state == [4]uint64{} || origin == [4]uint64{} {
+		return errors.New("rng state must not be all zeros")
+	}
+	r.inner = &xoshiro256PlusPlus{state: state}
+	r.origin = origin
+	return nil
+}
+
+// ========================================================================
+// Substreams
+// ========================================================================
+
+// Jump advances the generator by 2^128 steps, as if UniformFloat64 had been
+// called 2^128 times. Jumping a base generator repeatedly yields 2^128
+// non-overlapping substreams of length 2^128, the canonical way to give each
+// parallel worker its own stream.
+func (r *Rng) Jump() {
+	r.inner.jump()
+}
+
+// LongJump advances the generator by 2^192 steps. It carves 2^64
+// non-overlapping blocks, each of which can be split further with Jump.
+func (r *Rng) LongJump() {
+	r.inner.longJump()
+}
+
+// NewJumped returns an independent copy of r advanced by k jumps (k*2^128
+// steps); r itself is left unchanged. NewJumped(0) is a plain copy.
+// Panics if k is negative.
+func (r *Rng) NewJumped(k int) *Rng {
+	if k < 0 {
+		panic("jump: k must be non-negative")
+	}
+	inner := *r.inner
+	for i := 0; i < k; i++ {
+		inner.jump()
+	}
+	jumped := newRng(&inner)
+	jumped.unbiased = r.unbiased
+	return jumped
+}
+
+// Split derives a child generator from r's origin (the state r was created
+// with) and label, without consuming r's state: the parent's output stream is
+// untouched, the same label always yields the same child, and adding a new
+// label never perturbs the children of existing labels.
+//
+// The child seed is the FNV-1a hash of the four origin words (little-endian
+// bytes) followed by the label bytes, expanded with SplitMix64 as in
+// NewRngFromSeed.
+func (r *Rng) Split(label string) *Rng {
+	var buf [32]byte
+	for i, word := range r.origin {
+		binary.LittleEndian.PutUint64(buf[8*i:], word)
+	}
+	hash := fnv1aUpdate(fnv1aHash(buf[:]), label)
+	return newRng(newXoshiro256PlusPlus(hash))
 }
 
 // ========================================================================
@@ -46,12 +204,51 @@ func (r *Rng) UniformFloat64() float64 {
 	return r.inner.uniformFloat64()
 }
 
+// UniformOpen generates a uniform random float in the open interval (0, 1).
+// UniformFloat64 never returns 1, so only 0 is rejected and redrawn; the
+// expected number of extra draws is 2^-53 per call, i.e. effectively zero.
+// Distributions built with the ...Open constructors use it instead of
+// clamping the endpoint.
+func (r *Rng) UniformOpen() float64 {
+	for {
+		if u := r.inner.uniformFloat64(); u > 0 {
+			return u
+		}
+	}
+}
+
+// UniformClosed generates a uniform random float in the closed interval
+// [0, 1]. It consumes one 64-bit output like UniformFloat64 but divides the
+// upper 53 bits by 2^53 - 1 instead of 2^53, so the all-ones output maps to
+// exactly 1. Its values therefore differ slightly from UniformFloat64 for
+// the same seed.
+func (r *Rng) UniformClosed() float64 {
+	return r.inner.uniformFloat64Closed()
+}
+
+// uniformFor returns UniformOpen if open is set and UniformFloat64 otherwise.
+func (r *Rng) uniformFor(open bool) float64 {
+	if open {
+		return r.UniformOpen()
+	}
+	return r.inner.uniformFloat64()
+}
+
 // UniformFloat64Range generates a uniform random float in [min, max).
 // Returns min if min >= max.
 func (r *Rng) UniformFloat64Range(min, max float64) float64 {
 	return r.inner.uniformFloat64Range(min, max)
 }
 
+// UniformAntithetic generates an antithetic pair (u, 1-u) from a single
+// uniform draw u in [0, 1), so the second value lies in (0, 1]. The pair is
+// perfectly negatively correlated, which reduces the variance of Monte Carlo
+// averages over monotone transforms.
+func (r *Rng) UniformAntithetic() (float64, float64) {
+	u := r.inner.uniformFloat64()
+	return u, 1 - u
+}
+
 // UniformFloat32 generates a uniform random float32 in [0, 1).
 // Uses 24 bits for float32 mantissa precision.
 func (r *Rng) UniformFloat32() float32 {
@@ -78,6 +275,53 @@ func (r *Rng) UniformInt64(min, max int64) int64 {
 	return r.inner.uniformInt64(min, max)
 }
 
+// UniformInt64Unbiased generates a uniform random int64 in [min, max) without
+// modulo bias, using Lemire's nearly-divisionless rejection method.
+// Returns min if min >= max.
+//
+// Unlike UniformInt64 it may consume more than one 64-bit output per call
+// (rejection has probability (2^64 mod range) / 2^64, so it is rare except
+// for ranges close to 2^64), and it maps outputs to values differently, so
+// its sequence differs from UniformInt64 for the same seed.
+func (r *Rng) UniformInt64Unbiased(min, max int64) int64 {
+	return r.inner.uniformInt64Unbiased(min, max)
+}
+
+// SetUnbiased selects how the collection functions draw uniform indices:
+// modulo reduction (the default, shared by all Pragmastat implementations)
+// or, when enabled, the unbiased method of UniformInt64Unbiased. It applies
+// to every such index draw except selection sampling (RngSample and
+// SampleIndices, which have no modulo step): RngResample, ResampleInto,
+// ResampleIndices, StratifiedSample, RngShuffle, ShuffleInto,
+// ShuffleInPlace, ShuffleTogether, ShufflePaired, SplitAfterShuffle, Perm,
+// KFold, StratifiedKFold, and unweighted Empirical sampling. Direct calls
+// such as UniformInt64 and UniformIntN, AliasTable draws, and the estimators'
+// internal pivots are unaffected.
+//
+// Determinism trade-off: enabling it changes the consumed stream, so results
+// are reproducible only against other runs with the same setting. The
+// setting is per generator rather than package-wide because a global toggle
+// would race between goroutines that each own an Rng and would silently
+// change the streams of unrelated code in the same process. Copies made by
+// NewJumped inherit it, while Split children, NewRngFromState, and
+// UnmarshalBinary start with the default.
+func (r *Rng) SetUnbiased(enabled bool) {
+	r.unbiased = enabled
+}
+
+// Unbiased reports whether SetUnbiased(true) is in effect.
+func (r *Rng) Unbiased() bool {
+	return r.unbiased
+}
+
+// index draws a uniform index in [0, n) for the collection functions.
+func (r *Rng) index(n int) int {
+	if r.unbiased {
+		return int(r.inner.uniformBelow(uint64(n)))
+	}
+	return int(r.inner.uniformInt64(0, int64(n)))
+}
+
 // UniformInt32 generates a uniform random int32 in [min, max).
 // Returns min if min >= max.
 func (r *Rng) UniformInt32(min, max int32) int32 {
@@ -145,6 +389,187 @@ func (r *Rng) UniformBool() bool {
 	return r.inner.uniformBool()
 }
 
+// ========================================================================
+// Byte Methods
+// ========================================================================
+
+// Fill fills p with random bytes taken from successive 64-bit outputs in
+// little-endian order. When len(p) is not a multiple of 8, the final output
+// is truncated to its low-order bytes, so Fill consumes ceil(len(p)/8)
+// outputs. Fill is reproducible, not cryptographically secure: use
+// crypto/rand for keys, tokens, or anything an adversary must not predict.
+func (r *Rng) Fill(p []byte) {
+	for len(p) >= 8 {
+		binary.LittleEndian.PutUint64(p, r.inner.nextU64())
+		p = p[8:]
+	}
+	if len(p) > 0 {
+		var buf [8]byte
+		binary.LittleEndian.PutUint64(buf[:], r.inner.nextU64())
+		copy(p, buf[:])
+	}
+}
+
+// ========================================================================
+// Distribution Methods
+// ========================================================================
+
+// Normal draws one value from the normal distribution with the given mean
+// and standard deviation. It consumes exactly the same uniform draws as
+// NewAdditive(mean, stdDev).Sample(r), so the sequences are identical.
+// Panics on the parameters NewAdditive rejects.
+func (r *Rng) Normal(mean, stdDev float64) float64 {
+	return NewAdditive(mean, stdDev).Sample(r)
+}
+
+// Exponential draws one value from the exponential distribution with the
+// given rate. It consumes exactly the same uniform draws as
+// NewExp(rate).Sample(r), so the sequences are identical.
+// Panics on the parameters NewExp rejects.
+func (r *Rng) Exponential(rate float64) float64 {
+	return NewExp(rate).Sample(r)
+}
+
+// Bernoulli returns true with probability p, consuming one uniform draw.
+// Panics if p is outside [0, 1].
+func (r *Rng) Bernoulli(p float64) bool {
+	if !(p >= 0 && p <= 1) {
+		panic("p must be in [0, 1]")
+	}
+	return r.UniformFloat64() < p
+}
+
+// Binomial draws the number of successes in n independent trials with
+// success probability p. For n·min(p, 1-p) < 10 it inverts the CDF with a
+// single uniform draw; otherwise it uses Hörmann's BTRS transformed
+// rejection, which is exact and O(1) in expectation.
+// Panics if n < 0 or p is outside [0, 1].
+func (r *Rng) Binomial(n int, p float64) int {
+	if n < 0 {
+		panic("n must be non-negative")
+	}
+	if !(p >= 0 && p <= 1) {
+		panic("p must be in [0, 1]")
+	}
+	if p > 0.5 {
+		return n - r.Binomial(n, 1-p)
+	}
+	if n == 0 || p == 0 {
+		return 0
+	}
+	if float64(n)*p < 10 {
+		return r.binomialInversion(n, p)
+	}
+	return r.binomialBtrs(n, p)
+}
+
+// binomialInversion walks the pmf from 0 using the recurrence
+// P(k) = P(k-1) · (n-k+1)/k · p/q.
+func (r *Rng) binomialInversion(n int, p float64) int {
+	q := 1 - p
+	s := p / q
+	a := float64(n+1) * s
+	prob := math.Pow(q, float64(n))
+	u := r.UniformFloat64()
+	k := 0
+	for u >= prob && k < n {
+		u -= prob
+		k++
+		prob *= a/float64(k) - s
+	}
+	return k
+}
+
+// binomialBtrs implements BTRS for n·p >= 10, p <= 0.5.
+// Reference: W. Hörmann, "The generation of binomial random variates",
+// J. Stat. Comput. Simul. 46, 1993.
+func (r *Rng) binomialBtrs(n int, p float64) int {
+	nf := float64(n)
+	q := 1 - p
+	spq := math.Sqrt(nf * p * q)
+	b := 1.15 + 2.53*spq
+	a := -0.0873 + 0.0248*b + 0.01*p
+	c := nf*p + 0.5
+	vr := 0.92 - 4.2/b
+	alpha := (2.83 + 5.1/b) * spq
+	lpq := math.Log(p / q)
+	m := math.Floor((nf + 1) * p)
+	h := lgamma(m+1) + lgamma(nf-m+1)
+	for {
+		u := r.UniformFloat64() - 0.5
+		v := r.UniformFloat64()
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2*a/us+b)*u + c)
+		if k < 0 || k > nf {
+			continue
+		}
+		if us >= 0.07 && v <= vr {
+			return int(k)
+		}
+		v = math.Log(v * alpha / (a/(us*us) + b))
+		if v <= h-lgamma(k+1)-lgamma(nf-k+1)+(k-m)*lpq {
+			return int(k)
+		}
+	}
+}
+
+// Poisson draws a Poisson-distributed count with mean lambda. For
+// lambda < 10 it uses Knuth's multiplication method; otherwise Hörmann's
+// PTRS transformed rejection.
+// Panics if lambda is negative or not finite.
+func (r *Rng) Poisson(lambda float64) int {
+	if !(lambda >= 0) || math.IsInf(lambda, 1) {
+		panic("lambda must be non-negative and finite")
+	}
+	if lambda == 0 {
+		return 0
+	}
+	if lambda < 10 {
+		limit := math.Exp(-lambda)
+		k := 0
+		prod := r.UniformFloat64()
+		for prod > limit {
+			k++
+			prod *= r.UniformFloat64()
+		}
+		return k
+	}
+	return r.poissonPtrs(lambda)
+}
+
+// poissonPtrs implements PTRS for lambda >= 10.
+// Reference: W. Hörmann, "The transformed rejection method for generating
+// Poisson random variables", Insurance: Mathematics and Economics 12, 1993.
+func (r *Rng) poissonPtrs(lambda float64) int {
+	slam := math.Sqrt(lambda)
+	logLam := math.Log(lambda)
+	b := 0.931 + 2.53*slam
+	a := -0.059 + 0.02483*b
+	invAlpha := 1.1239 + 1.1328/(b-3.4)
+	vr := 0.9277 - 3.6224/(b-2)
+	for {
+		u := r.UniformFloat64() - 0.5
+		v := r.UniformFloat64()
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2*a/us+b)*u + lambda + 0.43)
+		if us >= 0.07 && v <= vr {
+			return int(k)
+		}
+		if k < 0 || (us < 0.013 && v > us) {
+			continue
+		}
+		if math.Log(v)+math.Log(invAlpha)-math.Log(a/(us*us)+b) <= -lambda+k*logLam-lgamma(k+1) {
+			return int(k)
+		}
+	}
+}
+
+// lgamma returns log(Γ(x)) for x > 0.
+func lgamma(x float64) float64 {
+	v, _ := math.Lgamma(x)
+	return v
+}
+
 // ========================================================================
 // Collection Methods
 // ========================================================================
@@ -152,7 +577,8 @@ func (r *Rng) UniformBool() bool {
 // RngSample returns k elements from the input slice without replacement.
 // Uses selection sampling to maintain order of first appearance.
 // Returns all elements if k >= len(x).
-// Panics if k is not positive (programmer error, not recoverable).
+// Panics if k is not positive or x is empty; TryRngSample returns an error
+// instead.
 func RngSample[T any](rng *Rng, x []T, k int) []T {
 	if k <= 0 {
 		panic("sample: k must be positive")
@@ -160,26 +586,66 @@ func RngSample[T any](rng *Rng, x []T, k int) []T {
 	if len(x) == 0 {
 		panic("sample: cannot sample from empty slice")
 	}
-	n := len(x)
-	if k >= n {
-		result := make([]T, n)
+	if k >= len(x) {
+		result := make([]T, len(x))
 		copy(result, x)
 		return result
 	}
 
 	result := make([]T, 0, k)
-	remaining := k
+	selectionSample(rng, len(x), k, func(i int) {
+		result = append(result, x[i])
+	})
+	return result
+}
+
+// TryRngSample is RngSample returning an error instead of panicking:
+// validity(x) for an empty slice and a plain error if k is not positive.
+func TryRngSample[T any](rng *Rng, x []T, k int) ([]T, error) {
+	if len(x) == 0 {
+		return nil, NewValidityError(SubjectX)
+	}
+	if k <= 0 {
+		return nil, fmt.Errorf("k must be positive, got %d", k)
+	}
+	return RngSample(rng, x, k), nil
+}
+
+// SampleIndices returns k distinct indices from 0..n-1 in ascending order.
+// It consumes random numbers exactly like RngSample on a slice of length n,
+// so for the same seed RngSample(rng, x, k) equals x at these indices.
+// Returns all indices if k >= n.
+// Panics if n or k is not positive (programmer errors, not recoverable).
+func SampleIndices(rng *Rng, n, k int) []int {
+	if k <= 0 {
+		panic("sample: k must be positive")
+	}
+	if n <= 0 {
+		panic("sample: cannot sample from empty slice")
+	}
+	if k >= n {
+		return identityPerm(n)
+	}
+
+	result := make([]int, 0, k)
+	selectionSample(rng, n, k, func(i int) {
+		result = append(result, i)
+	})
+	return result
+}
 
+// selectionSample visits k of the indices 0..n-1 (k < n) in ascending order
+// using selection sampling, one uniform draw per examined index.
+func selectionSample(rng *Rng, n, k int, visit func(i int)) {
+	remaining := k
 	for i := 0; i < n && remaining > 0; i++ {
 		available := n - i
 		// Probability of selecting this item: remaining / available
 		if rng.UniformFloat64()*float64(available) < float64(remaining) {
-			result = append(result, x[i])
+			visit(i)
 			remaining--
 		}
 	}
-
-	return result
 }
 
 // SampleSlice returns k float64 elements from the slice without replacement.
@@ -187,10 +653,36 @@ func (r *Rng) SampleSlice(x []float64, k int) []float64 {
 	return RngSample(r, x, k)
 }
 
+// StratifiedSample sorts x, splits it into k contiguous strata of as equal
+// size as possible (stratum i holds sorted positions [i*n/k, (i+1)*n/k)),
+// and draws one element uniformly from each. The result is in ascending
+// order and covers the whole range of x more evenly than RngSample.
+// Consumes one index draw per stratum. The input is not modified.
+//
+// Returns a Validity error for empty or non-finite x and a plain error if k
+// is not in [1, len(x)].
+func StratifiedSample(rng *Rng, x []float64, k int) ([]float64, error) {
+	if err := checkValidity(x, SubjectX); err != nil {
+		return nil, err
+	}
+	n := len(x)
+	if k < 1 || k > n {
+		return nil, fmt.Errorf("k must be in [1, %d], got %d", n, k)
+	}
+	sorted := sortedOne(x, false)
+	result := make([]float64, k)
+	for i := range result {
+		lo, hi := i*n/k, (i+1)*n/k
+		result[i] = sorted[lo+rng.index(hi-lo)]
+	}
+	return result, nil
+}
+
 // RngResample returns k elements from the input slice with replacement (bootstrap sampling).
 // Each element is independently selected with equal probability.
 // The original slice is not modified.
-// Panics if k is not positive or if x is empty (programmer errors, not recoverable).
+// Panics if k is not positive or if x is empty; TryRngResample returns an
+// error instead.
 func RngResample[T any](rng *Rng, x []T, k int) []T {
 	if k <= 0 {
 		panic("resample: k must be positive")
@@ -200,13 +692,65 @@ func RngResample[T any](rng *Rng, x []T, k int) []T {
 	}
 
 	result := make([]T, k)
-	n := len(x)
-	for i := 0; i < k; i++ {
-		result[i] = x[int(rng.UniformInt64(0, int64(n)))]
+	resampleFill(rng, x, result)
+	return result
+}
+
+// TryRngResample is RngResample returning an error instead of panicking:
+// validity(x) for an empty slice and a plain error if k is not positive.
+func TryRngResample[T any](rng *Rng, x []T, k int) ([]T, error) {
+	if len(x) == 0 {
+		return nil, NewValidityError(SubjectX)
+	}
+	if k <= 0 {
+		return nil, fmt.Errorf("k must be positive, got %d", k)
+	}
+	return RngResample(rng, x, k), nil
+}
+
+// ResampleIndices returns k indices drawn from 0..n-1 with replacement. It
+// consumes random numbers exactly like RngResample on a slice of length n,
+// so for the same seed RngResample(rng, x, k) equals x at these indices;
+// use it to bootstrap several parallel columns at once.
+// Panics if n or k is not positive (programmer errors, not recoverable).
+func ResampleIndices(rng *Rng, n, k int) []int {
+	if k <= 0 {
+		panic("resample: k must be positive")
+	}
+	if n <= 0 {
+		panic("resample: cannot resample from empty slice")
+	}
+	result := make([]int, k)
+	for i := range result {
+		result[i] = rng.index(n)
 	}
 	return result
 }
 
+// ResampleInto draws len(x) elements from x with replacement into dst and
+// returns dst[:len(x)]. It consumes random numbers exactly like
+// RngResample(rng, x, len(x)), so the same seed yields the same bootstrap
+// sample without allocating.
+// Panics if x is empty or if cap(dst) < len(x) (programmer errors, not recoverable).
+func ResampleInto[T any](rng *Rng, x []T, dst []T) []T {
+	if len(x) == 0 {
+		panic("resample: cannot resample from empty slice")
+	}
+	if cap(dst) < len(x) {
+		panic("resample: destination capacity is smaller than the input")
+	}
+	dst = dst[:len(x)]
+	resampleFill(rng, x, dst)
+	return dst
+}
+
+// resampleFill fills dst with elements drawn from x with replacement.
+func resampleFill[T any](rng *Rng, x []T, dst []T) {
+	for i := range dst {
+		dst[i] = x[rng.index(len(x))]
+	}
+}
+
 // ResampleSlice returns k float64 elements from the slice with replacement.
 func (r *Rng) ResampleSlice(x []float64, k int) []float64 {
 	return RngResample(r, x, k)
@@ -215,21 +759,119 @@ func (r *Rng) ResampleSlice(x []float64, k int) []float64 {
 // RngShuffle returns a shuffled copy of the input slice.
 // Uses the Fisher-Yates shuffle algorithm for uniform distribution.
 // The original slice is not modified.
+// Panics if x is empty; TryRngShuffle returns an error instead.
 func RngShuffle[T any](rng *Rng, x []T) []T {
 	if len(x) == 0 {
 		panic("shuffle: cannot shuffle empty slice")
 	}
 	result := make([]T, len(x))
 	copy(result, x)
-	n := len(result)
+	ShuffleInPlace(rng, result)
+	return result
+}
 
+// TryRngShuffle is RngShuffle returning validity(x) for an empty slice
+// instead of panicking.
+func TryRngShuffle[T any](rng *Rng, x []T) ([]T, error) {
+	if len(x) == 0 {
+		return nil, NewValidityError(SubjectX)
+	}
+	return RngShuffle(rng, x), nil
+}
+
+// ShuffleInto copies x into dst, shuffles it, and returns dst[:len(x)].
+// It consumes random numbers exactly like RngShuffle, without allocating.
+// Panics if x is empty or if cap(dst) < len(x) (programmer errors, not recoverable).
+func ShuffleInto[T any](rng *Rng, x []T, dst []T) []T {
+	if len(x) == 0 {
+		panic("shuffle: cannot shuffle empty slice")
+	}
+	if cap(dst) < len(x) {
+		panic("shuffle: destination capacity is smaller than the input")
+	}
+	dst = dst[:len(x)]
+	copy(dst, x)
+	ShuffleInPlace(rng, dst)
+	return dst
+}
+
+// ShuffleInPlace permutes x in place using the Fisher-Yates shuffle.
+// It consumes random numbers exactly like RngShuffle. An empty slice is left
+// unchanged.
+func ShuffleInPlace[T any](rng *Rng, x []T) {
 	// Fisher-Yates shuffle (backwards)
+	for i := len(x) - 1; i > 0; i-- {
+		j := rng.index(i + 1)
+		x[i], x[j] = x[j], x[i]
+	}
+}
+
+// SplitAfterShuffle shuffles pooled in place (exactly like ShuffleInPlace)
+// and returns its first n elements and the remaining len(pooled)-n elements
+// as two subslices, without copying or allocating. It is the re-split step
+// of a permutation test: reuse one pooled buffer of x and y across
+// iterations. The first subslice has its capacity capped at n, so appending
+// to it cannot overwrite the second.
+// Panics if n is outside [0, len(pooled)] (programmer error, not recoverable).
+func SplitAfterShuffle[T any](rng *Rng, pooled []T, n int) ([]T, []T) {
+	if n < 0 || n > len(pooled) {
+		panic("split: n must be in [0, len(pooled)]")
+	}
+	ShuffleInPlace(rng, pooled)
+	return pooled[:n:n], pooled[n:]
+}
+
+// ShufflePaired returns shuffled copies of x and y permuted by the same
+// Fisher-Yates permutation, so pairs (x[i], y[i]) stay together. It consumes
+// random numbers exactly like RngShuffle on a slice of that length, and the
+// result equals applying Perm(rng, len(x)) to both slices. The inputs are not
+// modified. Returns an error if the lengths differ.
+func ShufflePaired[T, U any](rng *Rng, x []T, y []U) ([]T, []U, error) {
+	if len(x) != len(y) {
+		return nil, nil, fmt.Errorf("shuffle: x and y must have the same length, got %d and %d", len(x), len(y))
+	}
+	xs := make([]T, len(x))
+	copy(xs, x)
+	ys := make([]U, len(y))
+	copy(ys, y)
+	ShuffleTogether(rng, len(xs), func(i, j int) {
+		xs[i], xs[j] = xs[j], xs[i]
+		ys[i], ys[j] = ys[j], ys[i]
+	})
+	return xs, ys, nil
+}
+
+// ShuffleTogether runs a Fisher-Yates shuffle over n positions, calling
+// swap(i, j) for each exchange, so any number of parallel structures can be
+// permuted in lockstep. It consumes random numbers exactly like
+// ShuffleInPlace on a slice of length n. A non-positive n is a no-op.
+func ShuffleTogether(rng *Rng, n int, swap func(i, j int)) {
 	for i := n - 1; i > 0; i-- {
-		j := int(rng.UniformInt64(0, int64(i+1)))
-		result[i], result[j] = result[j], result[i]
+		swap(i, rng.index(i+1))
 	}
+}
 
-	return result
+// Perm returns a uniform random permutation of 0..n-1. It consumes random
+// numbers exactly like RngShuffle on a slice of length n, so for the same
+// seed RngShuffle(rng, x)[i] == x[Perm(rng, len(x))[i]]; applying one
+// permutation to several slices co-shuffles them.
+// Panics if n is not positive (programmer error, not recoverable).
+func Perm(rng *Rng, n int) []int {
+	if n <= 0 {
+		panic("perm: n must be positive")
+	}
+	perm := identityPerm(n)
+	ShuffleInPlace(rng, perm)
+	return perm
+}
+
+// identityPerm returns 0, 1, ..., n-1.
+func identityPerm(n int) []int {
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	return perm
 }
 
 // ShuffleSlice returns a shuffled copy of the float64 slice.
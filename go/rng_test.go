@@ -0,0 +1,1097 @@
+package pragmastat
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// Determinism vectors for the seed constructors: the first three raw 64-bit
+// outputs. Other language implementations must reproduce these exactly.
+
+func TestRngFromBytesVectors(t *testing.T) {
+	cases := []struct {
+		seed []byte
+		want [3]uint64
+	}{
+		{[]byte{}, [3]uint64{14396179586316300983, 2000862814777268914, 2694200105906199477}},
+		{[]byte{0x00}, [3]uint64{4974037438062263003, 583626088388263552, 9801257198739717453}},
+		{[]byte{0xde, 0xad, 0xbe, 0xef}, [3]uint64{2169469496438227999, 13277543901159148582, 12281327273746215754}},
+		{[]byte("experiment"), [3]uint64{10532736336657392760, 166928476017873759, 4480887405776670257}},
+	}
+	for _, c := range cases {
+		rng := NewRngFromBytes(c.seed)
+		for i, want := range c.want {
+			if got := rng.inner.nextU64(); got != want {
+				t.Errorf("NewRngFromBytes(%x) output %d = %d, want %d", c.seed, i, got, want)
+			}
+		}
+	}
+}
+
+func TestRngFromBytesMatchesString(t *testing.T) {
+	for _, seed := range []string{"", "demo", "experiment-42"} {
+		a := NewRngFromString(seed)
+		b := NewRngFromBytes([]byte(seed))
+		for i := 0; i < 10; i++ {
+			if a.UniformFloat64() != b.UniformFloat64() {
+				t.Fatalf("seed %q: byte and string seeding diverge at draw %d", seed, i)
+			}
+		}
+	}
+	if NewRngFromBytes(nil).UniformFloat64() != NewRngFromBytes([]byte{}).UniformFloat64() {
+		t.Error("nil and empty byte seeds must be equivalent")
+	}
+}
+
+func TestRngFromStringAndIndexVectors(t *testing.T) {
+	cases := []struct {
+		index uint64
+		want  [3]uint64
+	}{
+		{0, [3]uint64{337638837727661885, 7414247166080029258, 5288146920187216187}},
+		{1, [3]uint64{13730936854610283500, 2347009488963118129, 2314671744730208180}},
+		{2, [3]uint64{469054214859544417, 1110058093344758659, 303285997533110036}},
+		{1 << 63, [3]uint64{15586333190416512976, 11352674028304245974, 17845353036718068725}},
+	}
+	for _, c := range cases {
+		rng := NewRngFromStringAndIndex("experiment", c.index)
+		for i, want := range c.want {
+			if got := rng.inner.nextU64(); got != want {
+				t.Errorf("NewRngFromStringAndIndex(experiment, %d) output %d = %d, want %d", c.index, i, got, want)
+			}
+		}
+	}
+}
+
+func TestRngFromStringAndIndexNotOffset(t *testing.T) {
+	// Replica streams must not be shifted copies of each other.
+	a := NewRngFromStringAndIndex("experiment", 0)
+	first := make([]uint64, 64)
+	for i := range first {
+		first[i] = a.inner.nextU64()
+	}
+	b := NewRngFromStringAndIndex("experiment", 1)
+	head := b.inner.nextU64()
+	for i, v := range first {
+		if v == head {
+			t.Errorf("index 1 stream starts at offset %d of index 0 stream", i)
+		}
+	}
+}
+
+func TestResampleIntoMatchesResample(t *testing.T) {
+	x := []float64{1, 2, 3, 4, 5, 6, 7}
+	want := RngResample(NewRngFromString("into"), x, len(x))
+	dst := make([]float64, 0, 16)
+	got := ResampleInto(NewRngFromString("into"), x, dst)
+	if len(got) != len(x) {
+		t.Fatalf("ResampleInto returned %d elements, want %d", len(got), len(x))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ResampleInto[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestShuffleIntoAndInPlaceMatchShuffle(t *testing.T) {
+	x := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	want := RngShuffle(NewRngFromString("into"), x)
+
+	got := ShuffleInto(NewRngFromString("into"), x, make([]int, len(x)))
+	inPlace := append([]int(nil), x...)
+	ShuffleInPlace(NewRngFromString("into"), inPlace)
+	for i := range want {
+		if got[i] != want[i] || inPlace[i] != want[i] {
+			t.Fatalf("index %d: ShuffleInto=%d ShuffleInPlace=%d RngShuffle=%d", i, got[i], inPlace[i], want[i])
+		}
+	}
+	if x[0] != 1 || x[8] != 9 {
+		t.Error("ShuffleInto mutated its input")
+	}
+}
+
+func TestIntoVariantsDoNotAllocate(t *testing.T) {
+	rng := NewRngFromSeed(1729)
+	x := []float64{1, 2, 3, 4, 5, 6, 7, 8}
+	dst := make([]float64, len(x))
+	allocs := testing.AllocsPerRun(100, func() {
+		ResampleInto(rng, x, dst)
+		ShuffleInto(rng, x, dst)
+		ShuffleInPlace(rng, dst)
+	})
+	if allocs != 0 {
+		t.Errorf("expected zero allocations, got %v", allocs)
+	}
+}
+
+func TestSplitAfterShuffle(t *testing.T) {
+	pooled := []float64{1, 2, 3, 4, 5, 6, 7}
+	ref := RngShuffle(NewRngFromString("split"), pooled)
+
+	buf := append([]float64(nil), pooled...)
+	x, y := SplitAfterShuffle(NewRngFromString("split"), buf, 3)
+	if len(x) != 3 || len(y) != 4 || cap(x) != 3 {
+		t.Fatalf("len(x)=%d cap(x)=%d len(y)=%d", len(x), cap(x), len(y))
+	}
+	for i := range ref {
+		if buf[i] != ref[i] {
+			t.Fatalf("buffer differs from RngShuffle at %d", i)
+		}
+	}
+	if &x[0] != &buf[0] || &y[0] != &buf[3] {
+		t.Error("subslices must alias the pooled buffer")
+	}
+	_ = append(x, 99)
+	if y[0] != ref[3] {
+		t.Error("appending to x overwrote y")
+	}
+
+	rng := NewRngFromSeed(1729)
+	allocs := testing.AllocsPerRun(100, func() {
+		SplitAfterShuffle(rng, buf, 3)
+	})
+	if allocs != 0 {
+		t.Errorf("expected zero allocations, got %v", allocs)
+	}
+
+	for _, n := range []int{-1, len(buf) + 1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("n=%d: expected panic", n)
+				}
+			}()
+			SplitAfterShuffle(rng, buf, n)
+		}()
+	}
+}
+
+func TestIntoVariantsPanicOnSmallDestination(t *testing.T) {
+	rng := NewRngFromSeed(1729)
+	x := []float64{1, 2, 3}
+	for name, f := range map[string]func(){
+		"ResampleInto": func() { ResampleInto(rng, x, make([]float64, 2)) },
+		"ShuffleInto":  func() { ShuffleInto(rng, x, make([]float64, 0, 2)) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected panic for insufficient capacity", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+// Jump vectors were produced by the reference xoshiro256plusplus.c jump and
+// long_jump routines on the state expanded from seed 1729.
+
+func TestRngJumpVectors(t *testing.T) {
+	cases := []struct {
+		name  string
+		apply func(r *Rng)
+		want  [3]uint64
+	}{
+		{"Jump", func(r *Rng) { r.Jump() }, [3]uint64{10290885188275915551, 15372776359467284671, 16743874170170988071}},
+		{"Jump x3", func(r *Rng) { r.Jump(); r.Jump(); r.Jump() }, [3]uint64{11385170499438983816, 2485147897464000124, 15904644650891781214}},
+		{"LongJump", func(r *Rng) { r.LongJump() }, [3]uint64{4029072759932873860, 11746433753208272519, 11847395456483168277}},
+	}
+	for _, c := range cases {
+		rng := NewRngFromSeed(1729)
+		c.apply(rng)
+		for i, want := range c.want {
+			if got := rng.inner.nextU64(); got != want {
+				t.Errorf("%s output %d = %d, want %d", c.name, i, got, want)
+			}
+		}
+	}
+
+	rng := NewRngFromSeed(1729)
+	rng.Jump()
+	for i, want := range []float64{0.5578700038963823, 0.83335987630341168, 0.90768723755616532} {
+		if got := rng.UniformFloat64(); got != want {
+			t.Errorf("UniformFloat64 after Jump, draw %d = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestRngNewJumped(t *testing.T) {
+	base := NewRngFromSeed(1729)
+	jumped := base.NewJumped(3)
+
+	manual := NewRngFromSeed(1729)
+	manual.Jump()
+	manual.Jump()
+	manual.Jump()
+	for i := 0; i < 10; i++ {
+		if a, b := jumped.UniformFloat64(), manual.UniformFloat64(); a != b {
+			t.Fatalf("NewJumped(3) and three Jump calls diverge at draw %d: %v vs %v", i, a, b)
+		}
+	}
+
+	fresh := NewRngFromSeed(1729)
+	if a, b := base.UniformFloat64(), fresh.UniformFloat64(); a != b {
+		t.Error("NewJumped must not modify the receiver")
+	}
+
+	copied := fresh.NewJumped(0)
+	if a, b := copied.UniformFloat64(), fresh.UniformFloat64(); a != b {
+		t.Error("NewJumped(0) must be a copy of the current state")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for negative k")
+		}
+	}()
+	base.NewJumped(-1)
+}
+
+func TestRngJumpedStreamsDoNotOverlap(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping 10^6-draw overlap test in short mode")
+	}
+	const draws = 1_000_000
+	const probes = 1000
+
+	base := NewRngFromString("parallel")
+	streams := []*Rng{base.NewJumped(0), base.NewJumped(1), base.NewJumped(2)}
+	outputs := make([][]uint64, len(streams))
+	for s, rng := range streams {
+		outputs[s] = make([]uint64, draws)
+		for i := range outputs[s] {
+			outputs[s][i] = rng.inner.nextU64()
+		}
+	}
+
+	// Overlapping streams would share a run of consecutive outputs, so it is
+	// enough to look for the first outputs of each stream inside the others.
+	for s, out := range outputs {
+		sorted := append([]uint64(nil), out...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		for o, other := range outputs {
+			if o == s {
+				continue
+			}
+			for _, v := range other[:probes] {
+				i := sort.Search(len(sorted), func(i int) bool { return sorted[i] >= v })
+				if i < len(sorted) && sorted[i] == v {
+					t.Fatalf("stream %d output %d found in stream %d", o, v, s)
+				}
+			}
+		}
+	}
+
+	// The jumped streams must also look independent: the correlation of
+	// paired uniforms stays within a few standard errors of zero.
+	a := NewRngFromString("parallel")
+	b := a.NewJumped(1)
+	var sum float64
+	for i := 0; i < draws; i++ {
+		sum += (a.UniformFloat64() - 0.5) * (b.UniformFloat64() - 0.5)
+	}
+	corr := sum / draws * 12 // Var(U) = 1/12
+	if math.Abs(corr) > 5/math.Sqrt(draws) {
+		t.Errorf("correlation between jumped streams = %v", corr)
+	}
+}
+
+func TestRngBinaryRoundTrip(t *testing.T) {
+	rng := NewRngFromString("checkpoint")
+	for i := 0; i < 17; i++ {
+		rng.UniformFloat64()
+	}
+	data, err := rng.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+	if len(data) != 65 || data[0] != 1 {
+		t.Fatalf("unexpected layout: len=%d version=%d", len(data), data[0])
+	}
+
+	var resumed Rng
+	if err := resumed.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+	fromState := NewRngFromState(rng.State())
+	for i := 0; i < 100; i++ {
+		want := rng.inner.nextU64()
+		if got := resumed.inner.nextU64(); got != want {
+			t.Fatalf("UnmarshalBinary continuation diverges at draw %d", i)
+		}
+		if got := fromState.inner.nextU64(); got != want {
+			t.Fatalf("NewRngFromState continuation diverges at draw %d", i)
+		}
+	}
+}
+
+func TestRngBinaryLayout(t *testing.T) {
+	rng := NewRngFromState([4]uint64{1, 0x0102030405060708, 0, 1 << 63})
+	data, _ := rng.MarshalBinary()
+	state := []byte{
+		1, 0, 0, 0, 0, 0, 0, 0,
+		8, 7, 6, 5, 4, 3, 2, 1,
+		0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0x80,
+	}
+	// Version byte, current state, origin (equal to the state for a fresh Rng).
+	want := append(append([]byte{1}, state...), state...)
+	if len(data) != len(want) {
+		t.Fatalf("len = %d, want %d", len(data), len(want))
+	}
+	for i := range want {
+		if data[i] != want[i] {
+			t.Fatalf("byte %d = %#x, want %#x", i, data[i], want[i])
+		}
+	}
+}
+
+func TestRngUnmarshalBinaryMalformed(t *testing.T) {
+	valid, _ := NewRngFromSeed(1729).MarshalBinary()
+	badVersion := append([]byte(nil), valid...)
+	badVersion[0] = 2
+	zero := append([]byte(nil), valid...)
+	for i := 1; i < 33; i++ {
+		zero[i] = 0
+	}
+	zeroOrigin := append([]byte(nil), valid...)
+	for i := 33; i < len(zeroOrigin); i++ {
+		zeroOrigin[i] = 0
+	}
+
+	for name, data := range map[string][]byte{
+		"nil":         nil,
+		"short":       valid[:32],
+		"long":        append(append([]byte(nil), valid...), 0),
+		"bad version": badVersion,
+		"zero state":  zero,
+		"zero origin": zeroOrigin,
+		"state only":  valid[:33],
+	} {
+		rng := NewRngFromSeed(42)
+		before := rng.State()
+		if err := rng.UnmarshalBinary(data); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+		if rng.State() != before {
+			t.Errorf("%s: failed UnmarshalBinary modified the receiver", name)
+		}
+	}
+}
+
+func TestRngNormalAndExponentialMatchDistributions(t *testing.T) {
+	normalRng := NewRngFromString("demo-dist-additive")
+	additiveRng := NewRngFromString("demo-dist-additive")
+	additive := NewAdditive(3, 2)
+	for i := 0; i < 1000; i++ {
+		if got, want := normalRng.Normal(3, 2), additive.Sample(additiveRng); got != want {
+			t.Fatalf("Normal draw %d = %v, Additive.Sample = %v", i, got, want)
+		}
+	}
+
+	expRng := NewRngFromString("demo-dist-exp")
+	distRng := NewRngFromString("demo-dist-exp")
+	exp := NewExp(0.5)
+	for i := 0; i < 1000; i++ {
+		if got, want := expRng.Exponential(0.5), exp.Sample(distRng); got != want {
+			t.Fatalf("Exponential draw %d = %v, Exp.Sample = %v", i, got, want)
+		}
+	}
+
+	// The demo values are reproduced through the convenience methods.
+	if got := NewRngFromString("demo-dist-additive").Normal(0, 1); got != 0.1741044867956819 {
+		t.Errorf("Normal(0, 1) = %v, want 0.1741044867956819", got)
+	}
+	if got := NewRngFromString("demo-dist-exp").Exponential(1); got != 0.6589065267276553 {
+		t.Errorf("Exponential(1) = %v, want 0.6589065267276553", got)
+	}
+}
+
+func TestRngNormalAndExponentialPanics(t *testing.T) {
+	rng := NewRngFromSeed(1729)
+	for name, f := range map[string]func(){
+		"Normal stdDev=0":    func() { rng.Normal(0, 0) },
+		"Normal stdDev<0":    func() { rng.Normal(0, -1) },
+		"Exponential rate=0": func() { rng.Exponential(0) },
+		"Exponential rate<0": func() { rng.Exponential(-2) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestRngSplit(t *testing.T) {
+	// Splitting does not touch the parent's stream.
+	parent := NewRngFromString("experiment")
+	reference := NewRngFromString("experiment")
+	parent.Split("a")
+	for i := 0; i < 10; i++ {
+		if parent.UniformFloat64() != reference.UniformFloat64() {
+			t.Fatalf("Split perturbed the parent stream at draw %d", i)
+		}
+		parent.Split(fmt.Sprintf("child-%d", i))
+	}
+
+	// Children depend only on the parent's origin and the label, not on how
+	// far the parent has advanced or which other children exist.
+	fresh := NewRngFromString("experiment")
+	a1 := fresh.Split("a")
+	b := fresh.Split("b")
+	a2 := parent.Split("a")
+	for i := 0; i < 10; i++ {
+		if a1.UniformFloat64() != a2.UniformFloat64() {
+			t.Fatalf("identical labels gave different children at draw %d", i)
+		}
+	}
+
+	// The derivation is part of the cross-language contract.
+	want := [3]uint64{14012552434240120681, 4801850367367969776, 10566384927292754882}
+	child := NewRngFromString("experiment").Split("a")
+	for i, w := range want {
+		if got := child.inner.nextU64(); got != w {
+			t.Errorf("Split(a) output %d = %d, want %d", i, got, w)
+		}
+	}
+
+	// A checkpointed parent splits exactly like the original.
+	data, _ := parent.MarshalBinary()
+	var restored Rng
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+	if restored.Split("b").UniformFloat64() != NewRngFromString("experiment").Split("b").UniformFloat64() {
+		t.Error("restored parent splits differently from the original")
+	}
+
+	// Children with different labels look independent.
+	const draws = 100_000
+	a := NewRngFromString("experiment").Split("a")
+	var sum, meanA, meanB float64
+	for i := 0; i < draws; i++ {
+		u, v := a.UniformFloat64(), b.UniformFloat64()
+		sum += (u - 0.5) * (v - 0.5)
+		meanA += u
+		meanB += v
+	}
+	if corr := sum / draws * 12; math.Abs(corr) > 5/math.Sqrt(draws) {
+		t.Errorf("correlation between children = %v", corr)
+	}
+	if math.Abs(meanA/draws-0.5) > 0.01 || math.Abs(meanB/draws-0.5) > 0.01 {
+		t.Errorf("child means = %v, %v; want about 0.5", meanA/draws, meanB/draws)
+	}
+}
+
+func TestSeedFromString(t *testing.T) {
+	for _, s := range []string{"", "a", "demo", "experiment-42", "héllo", "π", "你好"} {
+		a := NewRngFromString(s)
+		b := NewRngFromSeed(SeedFromString(s))
+		for i := 0; i < 10; i++ {
+			if a.UniformFloat64() != b.UniformFloat64() {
+				t.Fatalf("seed %q: string and numeric seeding diverge at draw %d", s, i)
+			}
+		}
+	}
+	// The empty string hashes to the FNV-1a offset basis.
+	if got := uint64(SeedFromString("")); got != 0xcbf29ce484222325 {
+		t.Errorf("SeedFromString(\"\") = %#x, want FNV-1a offset basis", got)
+	}
+	if got := uint64(SeedFromString("a")); got != 0xaf63dc4c8601ec8c {
+		t.Errorf("SeedFromString(\"a\") = %#x, want 0xaf63dc4c8601ec8c", got)
+	}
+}
+
+func TestPermMatchesShuffle(t *testing.T) {
+	x := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"}
+	for _, seed := range []string{"perm", "demo-shuffle", ""} {
+		want := RngShuffle(NewRngFromString(seed), x)
+		perm := Perm(NewRngFromString(seed), len(x))
+		for i, p := range perm {
+			if x[p] != want[i] {
+				t.Fatalf("seed %q: x[Perm[%d]] = %s, RngShuffle[%d] = %s", seed, i, x[p], i, want[i])
+			}
+		}
+	}
+
+	seen := make([]bool, 100)
+	for _, p := range Perm(NewRngFromSeed(1729), 100) {
+		if seen[p] {
+			t.Fatalf("index %d repeated in permutation", p)
+		}
+		seen[p] = true
+	}
+}
+
+func TestShufflePaired(t *testing.T) {
+	x := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	labels := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i"}
+	for _, seed := range []string{"paired", "demo-shuffle"} {
+		xs, ls, err := ShufflePaired(NewRngFromString(seed), x, labels)
+		if err != nil {
+			t.Fatalf("ShufflePaired: %v", err)
+		}
+		perm := Perm(NewRngFromString(seed), len(x))
+		for i, p := range perm {
+			if xs[i] != x[p] || ls[i] != labels[p] {
+				t.Fatalf("seed %q: position %d is (%v, %s), want (%v, %s)", seed, i, xs[i], ls[i], x[p], labels[p])
+			}
+		}
+		again, _, _ := ShufflePaired(NewRngFromString(seed), x, labels)
+		for i := range xs {
+			if again[i] != xs[i] {
+				t.Fatalf("seed %q: not deterministic", seed)
+			}
+		}
+	}
+	if x[0] != 1 || labels[0] != "a" {
+		t.Error("ShufflePaired modified its inputs")
+	}
+
+	// Stream consumption matches RngShuffle.
+	paired, shuffled := NewRngFromSeed(1729), NewRngFromSeed(1729)
+	ShufflePaired(paired, x, labels)
+	RngShuffle(shuffled, x)
+	if paired.UniformFloat64() != shuffled.UniformFloat64() {
+		t.Error("ShufflePaired consumed a different number of draws than RngShuffle")
+	}
+
+	if _, _, err := ShufflePaired(NewRngFromSeed(1), x, labels[:3]); err == nil {
+		t.Error("expected an error for mismatched lengths")
+	}
+}
+
+func TestShuffleTogether(t *testing.T) {
+	a := []int{0, 1, 2, 3, 4, 5, 6}
+	b := []string{"0", "1", "2", "3", "4", "5", "6"}
+	c := []float64{0, 10, 20, 30, 40, 50, 60}
+	ShuffleTogether(NewRngFromString("together"), len(a), func(i, j int) {
+		a[i], a[j] = a[j], a[i]
+		b[i], b[j] = b[j], b[i]
+		c[i], c[j] = c[j], c[i]
+	})
+	perm := Perm(NewRngFromString("together"), len(a))
+	for i, p := range perm {
+		if a[i] != p || b[i] != fmt.Sprint(p) || c[i] != float64(10*p) {
+			t.Fatalf("position %d: (%d, %s, %v), want index %d", i, a[i], b[i], c[i], p)
+		}
+	}
+
+	calls := 0
+	ShuffleTogether(NewRngFromSeed(1), 1, func(i, j int) { calls++ })
+	ShuffleTogether(NewRngFromSeed(1), 0, func(i, j int) { calls++ })
+	if calls != 0 {
+		t.Errorf("n <= 1 must not swap, got %d calls", calls)
+	}
+}
+
+func TestStratifiedSample(t *testing.T) {
+	// 0..102 shuffled: strata of a sorted 0..n-1 are exactly the integer
+	// ranges [i*n/k, (i+1)*n/k), so membership is easy to check.
+	const n = 103
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = float64(i)
+	}
+	x = RngShuffle(NewRngFromSeed(7), x)
+
+	for _, k := range []int{1, 2, 10, 50, n} {
+		got, err := StratifiedSample(NewRngFromString("strata"), x, k)
+		if err != nil {
+			t.Fatalf("k=%d: %v", k, err)
+		}
+		if len(got) != k {
+			t.Fatalf("k=%d: %d draws", k, len(got))
+		}
+		for i, v := range got {
+			lo, hi := i*n/k, (i+1)*n/k
+			if v < float64(lo) || v >= float64(hi) {
+				t.Errorf("k=%d: draw %d = %v outside stratum [%d, %d)", k, i, v, lo, hi)
+			}
+		}
+		again, _ := StratifiedSample(NewRngFromString("strata"), x, k)
+		for i := range got {
+			if again[i] != got[i] {
+				t.Fatalf("k=%d: not deterministic", k)
+			}
+		}
+	}
+
+	for _, k := range []int{0, -1, n + 1} {
+		if _, err := StratifiedSample(NewRngFromSeed(1), x, k); err == nil {
+			t.Errorf("k=%d: expected an error", k)
+		}
+	}
+	_, err := StratifiedSample(NewRngFromSeed(1), []float64{}, 1)
+	assertViolation(t, err, Validity, SubjectX)
+	_, err = StratifiedSample(NewRngFromSeed(1), []float64{1, math.NaN()}, 1)
+	assertViolation(t, err, Validity, SubjectX)
+}
+
+func TestResampleIndicesMatchesResample(t *testing.T) {
+	x := []float64{10, 11, 12, 13, 14, 15, 16}
+	for _, unbiased := range []bool{false, true} {
+		for _, k := range []int{1, 7, 20} {
+			rng, rngCopy := NewRngFromString("demo-resample"), NewRngFromString("demo-resample")
+			rng.SetUnbiased(unbiased)
+			rngCopy.SetUnbiased(unbiased)
+			want := RngResample(rng, x, k)
+			indices := ResampleIndices(rngCopy, len(x), k)
+			if len(indices) != k {
+				t.Fatalf("k=%d: %d indices", k, len(indices))
+			}
+			for i, idx := range indices {
+				if x[idx] != want[i] {
+					t.Fatalf("unbiased=%v, k=%d: x[%d] = %v, RngResample[%d] = %v", unbiased, k, idx, x[idx], i, want[i])
+				}
+			}
+		}
+	}
+
+	rng := NewRngFromSeed(1729)
+	for name, f := range map[string]func(){
+		"n=0": func() { ResampleIndices(rng, 0, 1) },
+		"k=0": func() { ResampleIndices(rng, 5, 0) },
+		"k<0": func() { ResampleIndices(rng, 5, -1) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestSampleIndicesMatchesSample(t *testing.T) {
+	x := []float64{10, 11, 12, 13, 14, 15, 16, 17, 18, 19}
+	for _, k := range []int{1, 3, 9, 10, 15} {
+		want := RngSample(NewRngFromString("demo-sample"), x, k)
+		indices := SampleIndices(NewRngFromString("demo-sample"), len(x), k)
+		if len(indices) != len(want) {
+			t.Fatalf("k=%d: %d indices, %d samples", k, len(indices), len(want))
+		}
+		for i, idx := range indices {
+			if x[idx] != want[i] {
+				t.Errorf("k=%d: x[%d] = %v, RngSample[%d] = %v", k, idx, x[idx], i, want[i])
+			}
+			if i > 0 && indices[i-1] >= idx {
+				t.Errorf("k=%d: indices not strictly ascending: %v", k, indices)
+			}
+		}
+	}
+}
+
+func TestPermAndSampleIndicesPanics(t *testing.T) {
+	rng := NewRngFromSeed(1729)
+	for name, f := range map[string]func(){
+		"Perm n=0":          func() { Perm(rng, 0) },
+		"SampleIndices n=0": func() { SampleIndices(rng, 0, 1) },
+		"SampleIndices k=0": func() { SampleIndices(rng, 5, 0) },
+		"SampleIndices k<0": func() { SampleIndices(rng, 5, -1) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestUniformInt64UnbiasedRange3(t *testing.T) {
+	const draws = 1_000_000
+	rng := NewRngFromSeed(1729)
+	var counts [3]int
+	for i := 0; i < draws; i++ {
+		v := rng.UniformInt64Unbiased(10, 13)
+		if v < 10 || v >= 13 {
+			t.Fatalf("UniformInt64Unbiased(10, 13) = %d out of range", v)
+		}
+		counts[v-10]++
+	}
+	// Chi-square with 2 degrees of freedom; 13.8 is the 0.999 quantile.
+	chi2 := 0.0
+	for _, c := range counts {
+		d := float64(c) - draws/3.0
+		chi2 += d * d / (draws / 3.0)
+	}
+	if chi2 > 13.8 {
+		t.Errorf("chi-square = %v for counts %v", chi2, counts)
+	}
+}
+
+// lowerFraction returns the fraction of draws from [min, max) that fall below
+// min + split.
+func lowerFraction(draw func(min, max int64) int64, min, max, split int64, draws int) float64 {
+	lower := 0
+	for i := 0; i < draws; i++ {
+		if uint64(draw(min, max))-uint64(min) < uint64(split) {
+			lower++
+		}
+	}
+	return float64(lower) / float64(draws)
+}
+
+func TestUniformInt64UnbiasedLargeRanges(t *testing.T) {
+	const draws = 1_000_000
+	tolerance := 5 * math.Sqrt(0.25/draws)
+
+	// Range 2^63-1: the lower half must hold half of the mass.
+	rng := NewRngFromSeed(1729)
+	if got := lowerFraction(rng.UniformInt64Unbiased, 0, math.MaxInt64, math.MaxInt64/2, draws); math.Abs(got-0.5) > tolerance {
+		t.Errorf("range 2^63-1: lower-half fraction = %v, want 0.5", got)
+	}
+
+	// Range 3*2^62 does not divide 2^64: modulo reduction maps two outputs
+	// onto each value of the lower third, so it lands there half of the
+	// time; the unbiased method must give one third.
+	min, max, third := int64(math.MinInt64), int64(1)<<62, int64(1)<<62
+	rng = NewRngFromSeed(1729)
+	if got := lowerFraction(rng.UniformInt64Unbiased, min, max, third, draws); math.Abs(got-1.0/3) > tolerance {
+		t.Errorf("range 3*2^62: lower-third fraction = %v, want 1/3", got)
+	}
+	rng = NewRngFromSeed(1729)
+	if got := lowerFraction(rng.UniformInt64, min, max, third, draws); math.Abs(got-0.5) > tolerance {
+		t.Errorf("range 3*2^62: modulo lower-third fraction = %v, expected the known 1/2 bias", got)
+	}
+
+	if got := rng.UniformInt64Unbiased(5, 5); got != 5 {
+		t.Errorf("UniformInt64Unbiased(5, 5) = %d, want 5", got)
+	}
+}
+
+func TestSetUnbiasedCollections(t *testing.T) {
+	x := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	// The default stays the cross-language modulo path.
+	a := NewRngFromString("unbiased")
+	b := NewRngFromString("unbiased")
+	b.SetUnbiased(false)
+	want := RngShuffle(a, x)
+	got := RngShuffle(b, x)
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatal("SetUnbiased(false) changed RngShuffle")
+		}
+	}
+
+	rng := NewRngFromString("unbiased")
+	rng.SetUnbiased(true)
+	if !rng.Unbiased() || !rng.NewJumped(1).Unbiased() {
+		t.Error("unbiased setting must be reported and inherited by NewJumped")
+	}
+	if rng.Split("child").Unbiased() {
+		t.Error("Split children must start with the default setting")
+	}
+	shuffled := RngShuffle(rng, x)
+	seen := map[int]bool{}
+	for _, v := range shuffled {
+		seen[v] = true
+	}
+	if len(seen) != len(x) {
+		t.Errorf("unbiased RngShuffle is not a permutation: %v", shuffled)
+	}
+	for _, v := range RngResample(rng, x, 100) {
+		if v < 1 || v > 10 {
+			t.Fatalf("unbiased RngResample produced %d", v)
+		}
+	}
+
+	// Perm and RngShuffle stay in lockstep under the unbiased setting.
+	p := NewRngFromString("perm")
+	p.SetUnbiased(true)
+	s := NewRngFromString("perm")
+	s.SetUnbiased(true)
+	perm := Perm(p, len(x))
+	want = RngShuffle(s, x)
+	for i := range perm {
+		if x[perm[i]] != want[i] {
+			t.Fatal("Perm and RngShuffle diverge under SetUnbiased(true)")
+		}
+	}
+}
+
+func BenchmarkUniformInt64(b *testing.B) {
+	rng := NewRngFromSeed(1729)
+	for i := 0; i < b.N; i++ {
+		rng.UniformInt64(0, 1000)
+	}
+}
+
+func BenchmarkUniformInt64Unbiased(b *testing.B) {
+	rng := NewRngFromSeed(1729)
+	for i := 0; i < b.N; i++ {
+		rng.UniformInt64Unbiased(0, 1000)
+	}
+}
+
+func BenchmarkUniformInt64UnbiasedWideRange(b *testing.B) {
+	// Rejection probability is about 1/4 for this range.
+	rng := NewRngFromSeed(1729)
+	for i := 0; i < b.N; i++ {
+		rng.UniformInt64Unbiased(math.MinInt64, 1<<62)
+	}
+}
+
+// chiSquareDiscrete draws n values and returns the chi-square statistic
+// against pmf(k) and its degrees of freedom. Cells with an expected count
+// below 5 are pooled into their neighbours.
+func chiSquareDiscrete(draw func() int, pmf func(k int) float64, maxK, n int) (float64, int) {
+	counts := make([]int, maxK+2)
+	for i := 0; i < n; i++ {
+		k := draw()
+		if k > maxK {
+			k = maxK + 1
+		}
+		counts[k]++
+	}
+	expected := make([]float64, maxK+2)
+	total := 0.0
+	for k := 0; k <= maxK; k++ {
+		expected[k] = pmf(k) * float64(n)
+		total += expected[k]
+	}
+	expected[maxK+1] = float64(n) - total
+
+	chi2, cells := 0.0, 0
+	var obs, exp float64
+	for k := range counts {
+		obs += float64(counts[k])
+		exp += expected[k]
+		if exp >= 5 || k == len(counts)-1 {
+			if exp > 0 {
+				chi2 += (obs - exp) * (obs - exp) / exp
+				cells++
+			}
+			obs, exp = 0, 0
+		}
+	}
+	return chi2, cells - 1
+}
+
+func binomialPmf(n int, p float64) func(k int) float64 {
+	return func(k int) float64 {
+		if k > n {
+			return 0
+		}
+		logC := lgamma(float64(n+1)) - lgamma(float64(k+1)) - lgamma(float64(n-k+1))
+		return math.Exp(logC + float64(k)*math.Log(p) + float64(n-k)*math.Log1p(-p))
+	}
+}
+
+func poissonPmf(lambda float64) func(k int) float64 {
+	return func(k int) float64 {
+		return math.Exp(-lambda + float64(k)*math.Log(lambda) - lgamma(float64(k+1)))
+	}
+}
+
+func TestRngDiscreteGoodnessOfFit(t *testing.T) {
+	const n = 200_000
+	rng := NewRngFromSeed(1729)
+	cases := []struct {
+		name string
+		draw func() int
+		pmf  func(k int) float64
+		maxK int
+	}{
+		{"Binomial(20, 0.3) inversion", func() int { return rng.Binomial(20, 0.3) }, binomialPmf(20, 0.3), 20},
+		{"Binomial(50, 0.3) BTRS", func() int { return rng.Binomial(50, 0.3) }, binomialPmf(50, 0.3), 50},
+		{"Binomial(40, 0.85) symmetric", func() int { return rng.Binomial(40, 0.85) }, binomialPmf(40, 0.85), 40},
+		{"Poisson(3) Knuth", func() int { return rng.Poisson(3) }, poissonPmf(3), 30},
+		{"Poisson(25) PTRS", func() int { return rng.Poisson(25) }, poissonPmf(25), 80},
+	}
+	for _, c := range cases {
+		chi2, df := chiSquareDiscrete(c.draw, c.pmf, c.maxK, n)
+		// A generous bound (about 5 standard deviations of the statistic).
+		if limit := float64(df) + 5*math.Sqrt(2*float64(df)); chi2 > limit {
+			t.Errorf("%s: chi-square = %.1f with %d df (limit %.1f)", c.name, chi2, df, limit)
+		}
+	}
+}
+
+func TestRngDiscreteMoments(t *testing.T) {
+	const n = 100_000
+	rng := NewRngFromSeed(1729)
+	cases := []struct {
+		name        string
+		draw        func() int
+		mean, sigma float64
+	}{
+		{"Binomial(1000, 0.4)", func() int { return rng.Binomial(1000, 0.4) }, 400, math.Sqrt(240)},
+		{"Binomial(100000, 0.01)", func() int { return rng.Binomial(100000, 0.01) }, 1000, math.Sqrt(990)},
+		{"Poisson(500)", func() int { return rng.Poisson(500) }, 500, math.Sqrt(500)},
+	}
+	for _, c := range cases {
+		x := make([]float64, n)
+		sum := 0.0
+		for i := range x {
+			x[i] = float64(c.draw())
+			sum += x[i]
+		}
+		mean := sum / n
+		if math.Abs(mean-c.mean) > 5*c.sigma/math.Sqrt(n) {
+			t.Errorf("%s: mean = %v, want %v", c.name, mean, c.mean)
+		}
+		// For near-normal data Spread ≈ 0.954σ.
+		spread, err := Spread(x, false)
+		if err != nil {
+			t.Fatalf("%s: Spread: %v", c.name, err)
+		}
+		if want := 0.954 * c.sigma; math.Abs(spread-want) > 0.03*want {
+			t.Errorf("%s: Spread = %v, want about %v", c.name, spread, want)
+		}
+	}
+}
+
+func TestRngBernoulli(t *testing.T) {
+	const n = 200_000
+	rng := NewRngFromSeed(1729)
+	hits := 0
+	for i := 0; i < n; i++ {
+		if rng.Bernoulli(0.3) {
+			hits++
+		}
+	}
+	if got := float64(hits) / n; math.Abs(got-0.3) > 5*math.Sqrt(0.21/n) {
+		t.Errorf("Bernoulli(0.3) frequency = %v", got)
+	}
+	if rng.Bernoulli(0) || !rng.Bernoulli(1) {
+		t.Error("Bernoulli(0) and Bernoulli(1) must be deterministic")
+	}
+}
+
+func TestRngDiscreteEdgeCasesAndDeterminism(t *testing.T) {
+	rng := NewRngFromSeed(1729)
+	if rng.Binomial(0, 0.5) != 0 || rng.Binomial(10, 0) != 0 || rng.Binomial(10, 1) != 10 {
+		t.Error("degenerate Binomial parameters must give deterministic results")
+	}
+	if rng.Poisson(0) != 0 {
+		t.Error("Poisson(0) must be 0")
+	}
+
+	a, b := NewRngFromString("discrete"), NewRngFromString("discrete")
+	for i := 0; i < 100; i++ {
+		if a.Binomial(500, 0.2) != b.Binomial(500, 0.2) || a.Poisson(40) != b.Poisson(40) {
+			t.Fatalf("draw %d differs for the same seed", i)
+		}
+	}
+
+	for name, f := range map[string]func(){
+		"Bernoulli p<0":    func() { rng.Bernoulli(-0.1) },
+		"Bernoulli p>1":    func() { rng.Bernoulli(1.1) },
+		"Bernoulli NaN":    func() { rng.Bernoulli(math.NaN()) },
+		"Binomial n<0":     func() { rng.Binomial(-1, 0.5) },
+		"Binomial p>1":     func() { rng.Binomial(5, 2) },
+		"Poisson lambda<0": func() { rng.Poisson(-1) },
+		"Poisson NaN":      func() { rng.Poisson(math.NaN()) },
+		"Poisson +Inf":     func() { rng.Poisson(math.Inf(1)) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+// TestRngFill pins the byte stream for seed 1729: the first three xoshiro256++
+// outputs written little-endian, truncated to 20 bytes.
+func TestRngFill(t *testing.T) {
+	want := []byte{
+		0x0f, 0x1e, 0xe2, 0xc3, 0x7d, 0x12, 0xf1, 0x64,
+		0xc4, 0xfe, 0xe2, 0x58, 0x3e, 0xfc, 0xb5, 0x92,
+		0x75, 0xfd, 0x7f, 0xf9,
+	}
+	got := make([]byte, len(want))
+	NewRngFromSeed(1729).Fill(got)
+	if !bytes.Equal(got, want) {
+		t.Errorf("Fill = % x, want % x", got, want)
+	}
+
+	// Fill of n bytes consumes ceil(n/8) outputs: the stream continues exactly
+	// where the same number of UniformUint64 draws would leave it.
+	for _, n := range []int{0, 1, 7, 8, 9, 16, 20} {
+		filled := NewRngFromSeed(1729)
+		filled.Fill(make([]byte, n))
+		skipped := NewRngFromSeed(1729)
+		for i := 0; i < (n+7)/8; i++ {
+			skipped.UniformUint64(0, math.MaxUint64)
+		}
+		if a, b := filled.UniformFloat64(), skipped.UniformFloat64(); a != b {
+			t.Errorf("n=%d: after Fill got %v, want %v", n, a, b)
+		}
+	}
+
+	// Whole words match the little-endian encoding of the raw outputs.
+	buf := make([]byte, 8)
+	NewRngFromSeed(42).Fill(buf)
+	raw := NewRngFromSeed(42)
+	if got, want := binary.LittleEndian.Uint64(buf), raw.inner.nextU64(); got != want {
+		t.Errorf("Fill word = %#x, want %#x", got, want)
+	}
+}
+
+func TestTryCollectionMethods(t *testing.T) {
+	x := []float64{1, 2, 3, 4, 5}
+	sampled, err := TryRngSample(NewRngFromSeed(7), x, 3)
+	if err != nil || !reflect.DeepEqual(sampled, RngSample(NewRngFromSeed(7), x, 3)) {
+		t.Errorf("TryRngSample = %v, %v", sampled, err)
+	}
+	resampled, err := TryRngResample(NewRngFromSeed(7), x, 8)
+	if err != nil || !reflect.DeepEqual(resampled, RngResample(NewRngFromSeed(7), x, 8)) {
+		t.Errorf("TryRngResample = %v, %v", resampled, err)
+	}
+	shuffled, err := TryRngShuffle(NewRngFromSeed(7), x)
+	if err != nil || !reflect.DeepEqual(shuffled, RngShuffle(NewRngFromSeed(7), x)) {
+		t.Errorf("TryRngShuffle = %v, %v", shuffled, err)
+	}
+
+	rng := NewRngFromSeed(7)
+	for name, c := range map[string]struct {
+		try       func() error
+		panicking func()
+		validity  bool
+	}{
+		"Sample k=0":     {func() error { _, err := TryRngSample(rng, x, 0); return err }, func() { RngSample(rng, x, 0) }, false},
+		"Sample k<0":     {func() error { _, err := TryRngSample(rng, x, -1); return err }, func() { RngSample(rng, x, -1) }, false},
+		"Sample empty":   {func() error { _, err := TryRngSample(rng, []float64{}, 1); return err }, func() { RngSample(rng, []float64{}, 1) }, true},
+		"Resample k=0":   {func() error { _, err := TryRngResample(rng, x, 0); return err }, func() { RngResample(rng, x, 0) }, false},
+		"Resample k<0":   {func() error { _, err := TryRngResample(rng, x, -2); return err }, func() { RngResample(rng, x, -2) }, false},
+		"Resample empty": {func() error { _, err := TryRngResample(rng, []float64(nil), 2); return err }, func() { RngResample(rng, []float64(nil), 2) }, true},
+		"Shuffle empty":  {func() error { _, err := TryRngShuffle(rng, []float64{}); return err }, func() { RngShuffle(rng, []float64{}) }, true},
+	} {
+		err := c.try()
+		if c.validity {
+			assertViolation(t, err, Validity, SubjectX)
+		} else if err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected panic", name)
+				}
+			}()
+			c.panicking()
+		}()
+	}
+}
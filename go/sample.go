@@ -68,30 +68,40 @@ func NewSample[T Number](values []T) (*Sample, error) {
 
 // NewSampleWithUnit creates an unweighted sample with a specified unit.
 func NewSampleWithUnit[T Number](values []T, unit *MeasurementUnit) (*Sample, error) {
-	return newSample(values, nil, unit)
+	return newSample(values, nil, unit, SubjectX)
 }
 
 // NewWeightedSample creates a weighted sample.
 func NewWeightedSample[T Number](values []T, weights []float64, unit *MeasurementUnit) (*Sample, error) {
-	return newSample(values, weights, unit)
+	return newSample(values, weights, unit, SubjectX)
+}
+
+// NewSampleForSubject creates a sample like NewWeightedSample (weights and
+// unit may be nil) but reports construction validity errors with the given
+// subject. Use SubjectY when building the second operand of a two-sample
+// estimator so that an empty or non-finite y is reported as validity(y).
+// The subject is not stored: estimators still assign subjects positionally.
+func NewSampleForSubject[T Number](values []T, weights []float64, unit *MeasurementUnit, subject Subject) (*Sample, error) {
+	return newSample(values, weights, unit, subject)
 }
 
 // newSample constructs a Sample, validating the values. Construction validity
-// errors (empty / NaN / Inf) are always reported with subject "x": construction
+// errors (empty / NaN / Inf) are reported with the given subject; the public
+// constructors other than NewSampleForSubject pass SubjectX because they
 // cannot know which argument position the sample will occupy.
-func newSample[T Number](values []T, weights []float64, unit *MeasurementUnit) (*Sample, error) {
+func newSample[T Number](values []T, weights []float64, unit *MeasurementUnit, subject Subject) (*Sample, error) {
 	if unit == nil {
 		unit = NumberUnit
 	}
 	if len(values) == 0 {
-		return nil, NewValidityError(SubjectX)
+		return nil, NewValidityError(subject)
 	}
 
 	fValues := make([]float64, len(values))
 	for i, v := range values {
 		fv := float64(v)
 		if math.IsNaN(fv) || math.IsInf(fv, 0) {
-			return nil, NewValidityError(SubjectX)
+			return nil, NewValidityError(subject)
 		}
 		fValues[i] = fv
 	}
@@ -194,7 +204,8 @@ func (s *Sample) computeSorted() {
 	s.sortCache.values = sortedValues
 }
 
-// ConvertTo converts the sample to a different (compatible) unit.
+// ConvertTo converts the sample to a different (compatible) unit, applying
+// the offset of affine units (see ConversionOffset).
 func (s *Sample) ConvertTo(target *MeasurementUnit) (*Sample, error) {
 	if !s.unit.IsCompatible(target) {
 		return nil, &UnitMismatchError{Unit1: s.unit, Unit2: target}
@@ -203,9 +214,10 @@ func (s *Sample) ConvertTo(target *MeasurementUnit) (*Sample, error) {
 		return s, nil
 	}
 	factor := ConversionFactor(s.unit, target)
+	offset := ConversionOffset(s.unit, target)
 	converted := make([]float64, len(s.values))
 	for i, v := range s.values {
-		converted[i] = v * factor
+		converted[i] = v*factor + offset
 	}
 	result := &Sample{
 		values:       converted,
@@ -222,6 +234,80 @@ func (s *Sample) ConvertTo(target *MeasurementUnit) (*Sample, error) {
 	return result, nil
 }
 
+// Filter returns a new sample with the values for which pred returns true,
+// keeping their weights and the unit. Totals and the effective size are
+// recomputed; filtering out every value is a validity(x) error, and
+// filtering out all positive weight is a total-weight error.
+func (s *Sample) Filter(pred func(float64) bool) (*Sample, error) {
+	values := make([]float64, 0, len(s.values))
+	var weights []float64
+	if s.isWeighted {
+		weights = make([]float64, 0, len(s.values))
+	}
+	for i, v := range s.values {
+		if !pred(v) {
+			continue
+		}
+		values = append(values, v)
+		if s.isWeighted {
+			weights = append(weights, s.weights[i])
+		}
+	}
+	return newSample(values, weights, s.unit, SubjectX)
+}
+
+// Map returns a new sample with f applied to every value, keeping the
+// weights. A non-linear f does not preserve unit semantics (the square root
+// of milliseconds is not milliseconds), so the result always has NumberUnit;
+// convert beforehand if the values should be in a particular unit. A NaN or
+// infinite result is a validity(x) error.
+func (s *Sample) Map(f func(float64) float64) (*Sample, error) {
+	values := make([]float64, len(s.values))
+	for i, v := range s.values {
+		values[i] = f(v)
+	}
+	return newSample(values, s.weights, NumberUnit, SubjectX)
+}
+
+// MergeSamples concatenates a and b into a new sample in the finer of their
+// units. If either sample is weighted the result is weighted, with each value
+// of an unweighted operand counting as weight 1; otherwise it is unweighted.
+// Incompatible unit families give a *UnitMismatchError.
+func MergeSamples(a, b *Sample) (*Sample, error) {
+	if a == nil || b == nil {
+		return nil, fmt.Errorf("samples cannot be nil")
+	}
+	if err := checkCompatibleUnits(a, b); err != nil {
+		return nil, err
+	}
+	x, y, err := convertToFiner(a, b)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]float64, 0, len(x.values)+len(y.values))
+	values = append(values, x.values...)
+	values = append(values, y.values...)
+
+	var weights []float64
+	if x.isWeighted || y.isWeighted {
+		weights = make([]float64, 0, len(values))
+		weights = appendWeights(weights, x)
+		weights = appendWeights(weights, y)
+	}
+	return newSample(values, weights, x.unit, SubjectX)
+}
+
+// appendWeights appends the weights of s, or 1 per value if s is unweighted.
+func appendWeights(dst []float64, s *Sample) []float64 {
+	if s.isWeighted {
+		return append(dst, s.weights...)
+	}
+	for range s.values {
+		dst = append(dst, 1)
+	}
+	return dst
+}
+
 // checkNonWeighted returns an error if the sample is weighted.
 func checkNonWeighted(name string, s *Sample) error {
 	if s == nil {
@@ -1,6 +1,7 @@
 package pragmastat
 
 import (
+	"math"
 	"reflect"
 	"testing"
 )
@@ -53,3 +54,187 @@ func TestWeightsMutationSafety(t *testing.T) {
 		t.Error("Weights() returned internal reference instead of copy")
 	}
 }
+
+func TestSampleFilter(t *testing.T) {
+	s, _ := NewWeightedSample([]float64{1, 2, 100, 3, -50}, []float64{1, 2, 3, 4, 5}, testSecond)
+	center, spread := 2.0, 1.0
+	kept, err := s.Filter(func(v float64) bool { return v > center-10*spread && v < center+10*spread })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(kept.Values(), []float64{1, 2, 3}) || !reflect.DeepEqual(kept.Weights(), []float64{1, 2, 4}) {
+		t.Errorf("Filter kept %v with weights %v", kept.Values(), kept.Weights())
+	}
+	if kept.Unit() != testSecond || kept.TotalWeight() != 7 || !floatEquals(kept.WeightedSize(), 49.0/21, 1e-12) {
+		t.Errorf("Filter: unit %v, total %v, size %v", kept.Unit(), kept.TotalWeight(), kept.WeightedSize())
+	}
+	if s.Size() != 5 {
+		t.Errorf("Filter mutated the original sample")
+	}
+
+	if _, err := s.Filter(func(float64) bool { return false }); err == nil {
+		t.Error("expected an error when every value is filtered out")
+	}
+}
+
+func TestSampleMap(t *testing.T) {
+	s, _ := NewSampleWithUnit([]float64{16, 4, 9, 1}, testSecond)
+	_ = s.SortedValues() // warm the cache of the original
+	mapped, err := s.Map(math.Sqrt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(mapped.Values(), []float64{4, 2, 3, 1}) {
+		t.Errorf("Map values = %v", mapped.Values())
+	}
+	if !reflect.DeepEqual(mapped.SortedValues(), []float64{1, 2, 3, 4}) {
+		t.Errorf("Map sorted values = %v", mapped.SortedValues())
+	}
+	if mapped.Unit() != NumberUnit {
+		t.Errorf("Map unit = %v, want NumberUnit", mapped.Unit())
+	}
+
+	w, _ := NewWeightedSample([]float64{1, 2}, []float64{0.25, 0.75}, nil)
+	if m, _ := w.Map(func(v float64) float64 { return v * 10 }); !reflect.DeepEqual(m.Weights(), []float64{0.25, 0.75}) {
+		t.Errorf("Map weights = %v", m.Weights())
+	}
+
+	if _, err := s.Map(func(v float64) float64 { return math.Log(v - 1) }); err == nil {
+		t.Error("expected a validity error for an infinite mapped value")
+	}
+}
+
+func TestMergeSamples(t *testing.T) {
+	a, _ := NewSample([]float64{1, 2})
+	b, _ := NewSample([]float64{3})
+	m, err := MergeSamples(a, b)
+	if err != nil || m.IsWeighted() || !reflect.DeepEqual(m.Values(), []float64{1, 2, 3}) {
+		t.Fatalf("unweighted merge = %v (weighted %v), %v", m.Values(), m.IsWeighted(), err)
+	}
+
+	wa, _ := NewWeightedSample([]float64{1, 2}, []float64{0.5, 1.5}, nil)
+	wb, _ := NewWeightedSample([]float64{3}, []float64{2}, nil)
+	m, _ = MergeSamples(wa, wb)
+	if !reflect.DeepEqual(m.Weights(), []float64{0.5, 1.5, 2}) || m.TotalWeight() != 4 {
+		t.Errorf("weighted merge weights = %v, total %v", m.Weights(), m.TotalWeight())
+	}
+
+	m, _ = MergeSamples(wa, b)
+	if !reflect.DeepEqual(m.Weights(), []float64{0.5, 1.5, 1}) {
+		t.Errorf("mixed merge weights = %v", m.Weights())
+	}
+
+	ms := &MeasurementUnit{ID: "ms", Family: "Time", Abbreviation: "ms", FullName: "Millisecond", BaseUnits: 1000}
+	us := &MeasurementUnit{ID: "us", Family: "Time", Abbreviation: "us", FullName: "Microsecond", BaseUnits: 1}
+	sms, _ := NewSampleWithUnit([]float64{1, 2}, ms)
+	sus, _ := NewSampleWithUnit([]float64{500}, us)
+	m, err = MergeSamples(sms, sus)
+	if err != nil || m.Unit() != us || !reflect.DeepEqual(m.Values(), []float64{1000, 2000, 500}) {
+		t.Errorf("cross-unit merge = %v in %v, %v", m.Values(), m.Unit(), err)
+	}
+
+	if _, err := MergeSamples(sms, a); err == nil {
+		t.Error("expected a unit mismatch for Time and Number")
+	} else if _, ok := err.(*UnitMismatchError); !ok {
+		t.Errorf("mismatch error has type %T", err)
+	}
+}
+
+func TestSampleEstimatorMeasurements(t *testing.T) {
+	ms := &MeasurementUnit{ID: "ms", Family: "Time", Abbreviation: "ms", FullName: "Millisecond", BaseUnits: 1000}
+	us := &MeasurementUnit{ID: "us", Family: "Time", Abbreviation: "us", FullName: "Microsecond", BaseUnits: 1}
+	s, err := NewSampleWithUnit([]float64{12, 11, 14, 13, 12.5}, ms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	center, err := s.Center()
+	if err != nil || center.Unit != ms || center.Value != 12.5 {
+		t.Fatalf("Center = %v, %v, want 12.5 ms", center, err)
+	}
+	if got := center.String(); got != "12.5 ms" {
+		t.Errorf("Center.String() = %q, want \"12.5 ms\"", got)
+	}
+	spread, err := s.Spread()
+	if err != nil || spread.Unit != ms {
+		t.Fatalf("Spread = %v, %v, want milliseconds", spread, err)
+	}
+	relSpread, err := s.RelSpread()
+	if err != nil || relSpread.Unit != NumberUnit || !floatEquals(relSpread.Value, spread.Value/center.Value, 1e-15) {
+		t.Errorf("RelSpread = %v, %v, want %v", relSpread, err, spread.Value/center.Value)
+	}
+
+	// Converting then estimating equals estimating then converting.
+	converted, err := s.ConvertTo(us)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c, _ := converted.Center(); c.Unit != us || !floatEquals(c.Value, 1000*center.Value, 1e-9) {
+		t.Errorf("Center after ConvertTo = %v, want %v us", c, 1000*center.Value)
+	}
+	if sp, _ := converted.Spread(); sp.Unit != us || !floatEquals(sp.Value, 1000*spread.Value, 1e-9) {
+		t.Errorf("Spread after ConvertTo = %v, want %v us", sp, 1000*spread.Value)
+	}
+	if r, _ := converted.RelSpread(); !floatEquals(r.Value, relSpread.Value, 1e-12) {
+		t.Errorf("RelSpread after ConvertTo = %v, want %v", r, relSpread)
+	}
+
+	weighted, _ := NewWeightedSample([]float64{1, 2, 3}, []float64{1, 2, 1}, ms)
+	for name, estimate := range map[string]func() (Measurement, error){
+		"Center": weighted.Center, "Spread": weighted.Spread, "RelSpread": weighted.RelSpread,
+	} {
+		if _, err := estimate(); err == nil {
+			t.Errorf("%s: expected an error for a weighted sample", name)
+		}
+	}
+	zero, _ := NewSample([]float64{-1, 0, 1})
+	_, err = zero.RelSpread()
+	assertViolation(t, err, Domain, SubjectX)
+}
+
+func TestTwoSampleOfFunctions(t *testing.T) {
+	ms := &MeasurementUnit{ID: "ms", Family: "Time", Abbreviation: "ms", FullName: "Millisecond", BaseUnits: 1000}
+	sec := &MeasurementUnit{ID: "s", Family: "Time", Abbreviation: "s", FullName: "Second", BaseUnits: 1000000}
+	bytes := &MeasurementUnit{ID: "B", Family: "Size", Abbreviation: "B", FullName: "Byte", BaseUnits: 1}
+	a, _ := NewSampleWithUnit([]float64{1.1, 1.2, 1.3, 1.4}, sec)
+	b, _ := NewSampleWithUnit([]float64{1000, 1100, 1200, 1300}, ms)
+	aMs, _ := NewSampleWithUnit([]float64{1100, 1200, 1300, 1400}, ms)
+
+	shift, err := ShiftOf(a, b)
+	if err != nil || shift.Unit != ms || !floatEquals(shift.Value, 100, 1e-9) {
+		t.Errorf("ShiftOf(s, ms) = %v, %v, want 100 ms", shift, err)
+	}
+	ratio, err := RatioOf(a, b)
+	want, _ := Ratio(aMs.Values(), b.Values(), false)
+	if err != nil || ratio.Unit != RatioUnit || !floatEquals(ratio.Value, want, 1e-12) {
+		t.Errorf("RatioOf(s, ms) = %v, %v, want %v", ratio, err, want)
+	}
+	disparity, err := DisparityOf(a, b)
+	want, _ = Disparity(aMs.Values(), b.Values(), false)
+	if err != nil || disparity.Unit != DisparityUnit || !floatEquals(disparity.Value, want, 1e-12) {
+		t.Errorf("DisparityOf(s, ms) = %v, %v, want %v", disparity, err, want)
+	}
+
+	size, _ := NewSampleWithUnit([]float64{1, 2, 3}, bytes)
+	weighted, _ := NewWeightedSample([]float64{1, 2, 3}, []float64{1, 2, 1}, ms)
+	constant, _ := NewSampleWithUnit([]float64{5, 5, 5}, ms)
+	negative, _ := NewSampleWithUnit([]float64{-1, 2, 3}, ms)
+	for name, f := range map[string]func(a, b *Sample) (Measurement, error){
+		"ShiftOf": ShiftOf, "RatioOf": RatioOf, "DisparityOf": DisparityOf,
+	} {
+		if _, err := f(a, size); err == nil {
+			t.Errorf("%s: expected a unit mismatch for Time and Size", name)
+		} else if _, ok := err.(*UnitMismatchError); !ok {
+			t.Errorf("%s: mismatch error has type %T", name, err)
+		}
+		if _, err := f(weighted, b); err == nil {
+			t.Errorf("%s: expected an error for a weighted first sample", name)
+		}
+		if _, err := f(b, weighted); err == nil {
+			t.Errorf("%s: expected an error for a weighted second sample", name)
+		}
+	}
+	_, err = DisparityOf(b, constant)
+	assertViolation(t, err, Sparity, SubjectY)
+	_, err = RatioOf(negative, b)
+	assertViolation(t, err, Positivity, SubjectX)
+}
@@ -0,0 +1,34 @@
+package pragmastat
+
+import "context"
+
+// RobustScale standardizes x as (x[i] - Center(x)) / Spread(x), a robust
+// analog of z-scores: a few extreme values barely move the location and
+// scale, so the bulk of the data is not squeezed by outliers. The result has
+// Center 0 and Spread 1 and keeps the order of x. x may hold any Number
+// type; the result is float64.
+//
+// Assumptions:
+//   - sparity(x) - Spread(x) must be positive
+func RobustScale[T Number](x []T) ([]float64, error) {
+	if err := checkValidity(x, SubjectX); err != nil {
+		return nil, err
+	}
+	sorted := sortedOne(x, false)
+	spreadVal, err := spreadImpl(sorted, true)
+	if err != nil {
+		return nil, err
+	}
+	if spreadVal <= 0 {
+		return nil, NewSparityError(SubjectX)
+	}
+	centerVal, err := centerImpl(context.Background(), sorted, true)
+	if err != nil {
+		return nil, err
+	}
+	scaled := make([]float64, len(x))
+	for i, v := range x {
+		scaled[i] = (float64(v) - centerVal) / spreadVal
+	}
+	return scaled, nil
+}
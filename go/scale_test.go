@@ -0,0 +1,88 @@
+package pragmastat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRobustScaleCenterAndSpread(t *testing.T) {
+	rng := NewRngFromSeed(invarianceSeed)
+	for _, n := range []int{2, 3, 10, 101} {
+		x := uniformVec(rng, n)
+		scaled, err := RobustScale(x)
+		if err != nil {
+			t.Fatalf("RobustScale(n=%d): %v", n, err)
+		}
+		c, _ := Center(scaled, false)
+		s, _ := Spread(scaled, false)
+		if !floatEquals(c, 0, 1e-9) || !floatEquals(s, 1, 1e-9) {
+			t.Errorf("n=%d: Center = %v, Spread = %v; want 0 and 1", n, c, s)
+		}
+	}
+}
+
+func TestRobustScaleInvariance(t *testing.T) {
+	rng := NewRngFromSeed(invarianceSeed)
+	x := uniformVec(rng, 30)
+	base, err := RobustScale(x)
+	if err != nil {
+		t.Fatalf("RobustScale: %v", err)
+	}
+	for _, tc := range []struct{ a, b, sign float64 }{
+		{2, 0, 1}, {1, -7, 1}, {0.5, 3, 1}, {-3, 1, -1},
+	} {
+		y := make([]float64, len(x))
+		for i, v := range x {
+			y[i] = tc.a*v + tc.b
+		}
+		got, err := RobustScale(y)
+		if err != nil {
+			t.Fatalf("RobustScale(%v*x%+v): %v", tc.a, tc.b, err)
+		}
+		for i := range got {
+			if !floatEquals(got[i], tc.sign*base[i], 1e-9) {
+				t.Errorf("RobustScale(%v*x%+v)[%d] = %v, want %v", tc.a, tc.b, i, got[i], tc.sign*base[i])
+				break
+			}
+		}
+	}
+}
+
+func TestRobustScaleOutlierResistance(t *testing.T) {
+	x := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 1e6}
+	scaled, err := RobustScale(x)
+	if err != nil {
+		t.Fatalf("RobustScale: %v", err)
+	}
+	// The bulk keeps a unit-order scale despite the extreme value.
+	if math.Abs(scaled[0]) > 3 || math.Abs(scaled[8]) > 3 {
+		t.Errorf("bulk values were squeezed or inflated: %v", scaled[:9])
+	}
+	if scaled[9] < 1e4 {
+		t.Errorf("outlier scaled to %v, want a large value", scaled[9])
+	}
+}
+
+func TestRobustScaleIntegers(t *testing.T) {
+	// Center of {1, 2, 3, 4, 5} is 3 and Spread is 2.
+	got, err := RobustScale([]int32{1, 2, 3, 4, 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []float64{-1, -0.5, 0, 0.5, 1} {
+		if !floatEquals(got[i], want, 1e-15) {
+			t.Errorf("RobustScale(int32)[%d] = %v, want %v", i, got[i], want)
+		}
+	}
+}
+
+func TestRobustScaleErrors(t *testing.T) {
+	_, err := RobustScale([]float64(nil))
+	assertViolation(t, err, Validity, SubjectX)
+
+	_, err = RobustScale([]float64{2, 2, 2})
+	assertViolation(t, err, Sparity, SubjectX)
+
+	_, err = RobustScale([]float64{1})
+	assertViolation(t, err, Sparity, SubjectX)
+}
@@ -0,0 +1,116 @@
+package pragmastat
+
+import (
+	"context"
+	"math"
+	"math/bits"
+	"testing"
+)
+
+// TestShiftBoundsDetailClamp uses n = m = 2 with misrate = 1, where the
+// requested margin (4 of the 4 differences) would exclude everything and is
+// clamped to 2, keeping the two middle differences.
+func TestShiftBoundsDetailClamp(t *testing.T) {
+	x := []float64{1, 5}
+	y := []float64{0, 2}
+	requested, err := pairwiseMargin(context.Background(), 2, 2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requested != 4 {
+		t.Fatalf("pairwiseMargin(2, 2, 1) = %d, want 4", requested)
+	}
+
+	bounds, usedMargin, effective, err := ShiftBoundsDetail(x, y, 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usedMargin != 2 {
+		t.Errorf("usedMargin = %d, want 2", usedMargin)
+	}
+	// U for n = m = 2 takes 0..4 with counts 1, 1, 2, 1, 1 out of 6, so
+	// missing on either side has probability 2·P(U <= 1) = 2/3.
+	if !floatEquals(effective, 2.0/3, 1e-15) {
+		t.Errorf("effectiveMisrate = %v, want 2/3", effective)
+	}
+	// Differences are -1, 1, 3, 5; the middle two remain.
+	if bounds.Lower != 1 || bounds.Upper != 3 {
+		t.Errorf("bounds = %v, want [1;3]", bounds)
+	}
+}
+
+func TestShiftBoundsDetailMatchesShiftBounds(t *testing.T) {
+	rng := NewRngFromString("shift-bounds-detail")
+	for _, c := range []struct {
+		n, m    int
+		misrate float64
+	}{{5, 5, 0.05}, {10, 12, 0.1}, {30, 30, 0.01}, {1, 1, 1}, {250, 250, 0.05}} {
+		x := NewAdditive(0, 1).Samples(rng, c.n)
+		y := NewAdditive(0, 1).Samples(rng, c.m)
+		want, err := ShiftBounds(x, y, c.misrate, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bounds, usedMargin, effective, err := ShiftBoundsDetail(x, y, c.misrate, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bounds != want {
+			t.Errorf("%d×%d: bounds = %v, ShiftBounds = %v", c.n, c.m, bounds, want)
+		}
+		if c.n*c.m > 1 {
+			margin, err := pairwiseMargin(context.Background(), c.n, c.m, c.misrate)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if usedMargin != margin {
+				t.Errorf("%d×%d: usedMargin = %d, want unclamped %d", c.n, c.m, usedMargin, margin)
+			}
+		}
+		// The margin is discrete, so the effective misrate is close to but not
+		// exactly the requested one.
+		if math.Abs(effective-c.misrate) > 0.15*c.misrate {
+			t.Errorf("%d×%d: effectiveMisrate = %v, requested %v", c.n, c.m, effective, c.misrate)
+		}
+	}
+
+	if _, _, _, err := ShiftBoundsDetail([]float64{1, 2}, []float64{3, 4}, 0.01, false); err == nil {
+		t.Error("expected a misrate domain error below the minimum achievable misrate")
+	}
+}
+
+// TestMannWhitneyCdfExactEnumeration checks the Loeffler recurrence against
+// counting U over every arrangement of n x-values and m y-values.
+func TestMannWhitneyCdfExactEnumeration(t *testing.T) {
+	const n, m = 4, 5
+	counts := make([]float64, n*m+1)
+	total := 0.0
+	for mask := 0; mask < 1<<(n+m); mask++ {
+		if bits.OnesCount(uint(mask)) != n {
+			continue
+		}
+		// Bits set mark x positions in ascending order; U counts pairs with
+		// an x before a y.
+		u, xs := 0, 0
+		for i := 0; i < n+m; i++ {
+			if mask&(1<<i) != 0 {
+				xs++
+			} else {
+				u += xs
+			}
+		}
+		counts[u]++
+		total++
+	}
+	cumulative := 0.0
+	for u := range counts {
+		cumulative += counts[u]
+		got, err := mannWhitneyCdfExact(context.Background(), n, m, int64(u))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !floatEquals(got, cumulative/total, 1e-12) {
+			t.Errorf("P(U <= %d) = %v, want %v", u, got, cumulative/total)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package pragmastat
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+)
+
+// TestShiftKernelReturnsErrors pins the selectKthPairwiseDiff contract: an
+// out-of-range rank and NaN input come back as plain errors (NOT panics and
+// NOT AssumptionErrors), so data-dependent failures never crash a caller.
+func TestShiftKernelReturnsErrors(t *testing.T) {
+	x := []float64{1, 2, 3}
+	y := []float64{4, 5}
+	ctx := context.Background()
+
+	for _, k := range []int64{0, -1, 7} {
+		_, err := selectKthPairwiseDiff(ctx, x, y, k)
+		if err == nil {
+			t.Fatalf("k=%d: expected an out-of-range error", k)
+		}
+		if _, isAssumption := err.(*AssumptionError); isAssumption {
+			t.Fatalf("k=%d: expected a plain error, got AssumptionError: %v", k, err)
+		}
+	}
+
+	_, err := selectKthPairwiseDiff(ctx, []float64{math.NaN(), 1}, y, 1)
+	if err == nil || err.Error() != "NaN in input values" {
+		t.Fatalf("expected the NaN error, got %v", err)
+	}
+}
+
+// TestShiftUnsortedAssumeSortedDoesNotPanic feeds the kernel descending input
+// under assumeSorted=true. The result is meaningless, but the public Shift must
+// return (with a value or an error) instead of panicking.
+func TestShiftUnsortedAssumeSortedDoesNotPanic(t *testing.T) {
+	x := make([]float64, 64)
+	y := make([]float64, 48)
+	for i := range x {
+		x[i] = float64(len(x) - i)
+	}
+	for i := range y {
+		y[i] = float64(3 * (len(y) - i))
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("Shift panicked: %v", r)
+		}
+	}()
+	if _, err := Shift(x, y, true); err != nil {
+		t.Logf("Shift(unsorted, assumeSorted=true) returned error: %v", err)
+	}
+	if _, err := Shift([]float64{1, math.NaN()}, y, false); err == nil {
+		t.Fatal("expected a validity error for NaN input")
+	}
+}
+
+// TestShiftWithPrecision checks that any converging iteration budget gives
+// exactly the Shift result, that integer data converges with a small budget,
+// and that an insufficient budget is reported instead of returning a value.
+func TestShiftWithPrecision(t *testing.T) {
+	rng := NewRngFromString("shift-precision")
+	x := NewAdditive(0, 1).Samples(rng, 100)
+	y := NewAdditive(0, 1).Samples(rng, 80)
+	xi := make([]float64, 100)
+	yi := make([]float64, 80)
+	for i := range xi {
+		xi[i] = float64(rng.UniformIntN(0, 10))
+	}
+	for i := range yi {
+		yi[i] = float64(rng.UniformIntN(0, 10))
+	}
+
+	for _, data := range []struct {
+		name string
+		x, y []float64
+	}{{"float", x, y}, {"integer", xi, yi}} {
+		want, err := Shift(data.x, data.y, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, maxIter := range []int{128, 1000, 100000} {
+			got, err := ShiftWithPrecision(data.x, data.y, maxIter, false)
+			if err != nil || got != want {
+				t.Errorf("%s, maxIter=%d: got %v (%v), want %v", data.name, maxIter, got, err, want)
+			}
+		}
+		_, err = ShiftWithPrecision(data.x, data.y, 1, false)
+		if !errors.Is(err, ErrShiftNotConverged) {
+			t.Errorf("%s, maxIter=1: got %v, want ErrShiftNotConverged", data.name, err)
+		}
+	}
+
+	// Ten distinct integer levels isolate the median in a handful of steps.
+	if got, err := ShiftWithPrecision(xi, yi, 8, false); err != nil {
+		t.Errorf("integer data, maxIter=8: %v", err)
+	} else if want, _ := Shift(xi, yi, false); got != want {
+		t.Errorf("integer data, maxIter=8: got %v, want %v", got, want)
+	}
+
+	if _, err := ShiftWithPrecision(x, y, 0, false); err == nil {
+		t.Error("expected an error for maxIter = 0")
+	}
+}
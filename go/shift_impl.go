@@ -1,6 +1,7 @@
 package pragmastat
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math"
@@ -11,8 +12,11 @@ import (
 // Time complexity: O((m + n) * log(precision)) per unique rank
 // Space complexity: O(m + n) for log-transformed arrays
 func ratioQuantilesImpl[T Number](x, y []T, p []float64, assumeSorted bool) ([]float64, error) {
-	if len(x) == 0 || len(y) == 0 {
-		return nil, errEmptyInput
+	if len(x) == 0 {
+		return nil, NewValidityError(SubjectX)
+	}
+	if len(y) == 0 {
+		return nil, NewValidityError(SubjectY)
 	}
 
 	// Log-transform both samples (includes positivity check)
@@ -26,7 +30,7 @@ func ratioQuantilesImpl[T Number](x, y []T, p []float64, assumeSorted bool) ([]f
 	}
 
 	// Delegate to shiftQuantilesImpl in log-space
-	logResult, err := shiftQuantilesImpl(logX, logY, p, assumeSorted)
+	logResult, err := shiftQuantilesImpl(context.Background(), logX, logY, p, assumeSorted)
 	if err != nil {
 		return nil, err
 	}
@@ -43,7 +47,13 @@ func ratioQuantilesImpl[T Number](x, y []T, p []float64, assumeSorted bool) ([]f
 // shiftQuantilesImpl computes quantiles of all pairwise differences {x[i] - y[j]}.
 // Time complexity: O((m + n) * log(precision)) per unique rank
 // Space complexity: O(1) - avoids materializing all m*n differences
-func shiftQuantilesImpl[T Number](x, y []T, p []float64, assumeSorted bool) ([]float64, error) {
+func shiftQuantilesImpl[T Number](ctx context.Context, x, y []T, p []float64, assumeSorted bool) ([]float64, error) {
+	return shiftQuantilesWithIterations(ctx, x, y, p, assumeSorted, defaultShiftMaxIterations)
+}
+
+// shiftQuantilesWithIterations is shiftQuantilesImpl with an explicit
+// iteration budget for each rank selection (see selectKthPairwiseDiffWithIterations).
+func shiftQuantilesWithIterations[T Number](ctx context.Context, x, y []T, p []float64, assumeSorted bool, maxIterations int) ([]float64, error) {
 	m := len(x)
 	n := len(y)
 	if m == 0 || n == 0 {
@@ -103,7 +113,7 @@ func shiftQuantilesImpl[T Number](x, y []T, p []float64, assumeSorted bool) ([]f
 	// Compute values for all required ranks
 	rankValues := make(map[int64]float64)
 	for rank := range requiredRanks {
-		val, err := selectKthPairwiseDiff(xs, ys, rank)
+		val, err := selectKthPairwiseDiffWithIterations(ctx, xs, ys, rank, maxIterations)
 		if err != nil {
 			return nil, err
 		}
@@ -125,9 +135,27 @@ func shiftQuantilesImpl[T Number](x, y []T, p []float64, assumeSorted bool) ([]f
 	return result, nil
 }
 
+// defaultShiftMaxIterations bounds the binary search of selectKthPairwiseDiff.
+// Sufficient for double precision convergence.
+const defaultShiftMaxIterations = 128
+
+// ErrShiftNotConverged is returned when the pairwise-difference search of
+// ShiftWithPrecision (or, on pathological input, Shift) exhausts its
+// iteration budget before isolating the exact difference.
+var ErrShiftNotConverged = errors.New("shift search did not converge")
+
 // selectKthPairwiseDiff finds the k-th smallest pairwise difference (1-based indexing).
 // Uses binary search combined with two-pointer counting to avoid materializing all differences.
-func selectKthPairwiseDiff[T Number](x, y []T, k int64) (float64, error) {
+func selectKthPairwiseDiff[T Number](ctx context.Context, x, y []T, k int64) (float64, error) {
+	return selectKthPairwiseDiffWithIterations(ctx, x, y, k, defaultShiftMaxIterations)
+}
+
+// selectKthPairwiseDiffWithIterations is selectKthPairwiseDiff with at most
+// maxIterations search steps. Every step narrows the bracket to actual
+// differences, so integer or low-precision data converges in a few steps; a
+// budget too small to isolate the k-th difference gives ErrShiftNotConverged
+// rather than an approximate value.
+func selectKthPairwiseDiffWithIterations[T Number](ctx context.Context, x, y []T, k int64, maxIterations int) (float64, error) {
 	m := len(x)
 	n := len(y)
 	total := int64(m) * int64(n)
@@ -143,11 +171,13 @@ func selectKthPairwiseDiff[T Number](x, y []T, k int64) (float64, error) {
 		return 0, errors.New("NaN in input values")
 	}
 
-	const maxIterations = 128 // Sufficient for double precision convergence
 	prevMin := math.Inf(-1)
 	prevMax := math.Inf(1)
 
 	for iter := 0; iter < maxIterations && searchMin != searchMax; iter++ {
+		if err := checkContext(ctx); err != nil {
+			return 0, err
+		}
 		// Overflow-safe, order-symmetric midpoint: 0.5*a + 0.5*b (halve before
 		// summing; never overflows; operand order is irrelevant).
 		mid := 0.5*searchMin + 0.5*searchMax
@@ -176,7 +206,7 @@ func selectKthPairwiseDiff[T Number](x, y []T, k int64) (float64, error) {
 	}
 
 	if searchMin != searchMax {
-		return 0, errors.New("convergence failure (pathological input)")
+		return 0, fmt.Errorf("%w after %d iterations", ErrShiftNotConverged, maxIterations)
 	}
 
 	return searchMin, nil
@@ -42,17 +42,46 @@ func signedRankMarginExact(n int, misrate float64) int {
 	return raw * 2
 }
 
+// signedRankMarginExactRaw returns the smallest w with P(W <= w) >= p.
+//
+// The subset-sum DP for count[w] only reads lower indices, so truncating it
+// at a cap keeps every count[0..cap] exact. The cap is the Edgeworth estimate
+// of the answer plus one standard deviation of slack, doubling in the rare
+// case the exact CDF has not reached p within it. Typical misrates therefore
+// touch only a prefix of the n(n+1)/2+1 counts.
 func signedRankMarginExactRaw(n int, p float64) int {
 	total := uint64(1) << n
 	maxW := int64(n) * int64(n+1) / 2
 
-	count := make([]uint64, maxW+1)
+	sigma := math.Sqrt(float64(n) * float64(n+1) * float64(2*n+1) / 24.0)
+	capW := signedRankMarginApproxRaw(n, p) + int64(math.Ceil(sigma)) + 1
+	if capW > maxW {
+		capW = maxW
+	}
+	for {
+		if w, ok := signedRankCdfSearch(n, capW, total, p); ok {
+			return int(w)
+		}
+		if capW == maxW {
+			return int(maxW)
+		}
+		capW *= 2
+		if capW > maxW {
+			capW = maxW
+		}
+	}
+}
+
+// signedRankCdfSearch runs the signed-rank DP truncated at capW and returns
+// the smallest w <= capW whose CDF reaches p, if any.
+func signedRankCdfSearch(n int, capW int64, total uint64, p float64) (int64, bool) {
+	count := make([]uint64, capW+1)
 	count[0] = 1
 
-	for i := 1; i <= n; i++ {
+	for i := 1; i <= n && int64(i) <= capW; i++ {
 		maxWi := int64(i) * int64(i+1) / 2
-		if maxWi > maxW {
-			maxWi = maxW
+		if maxWi > capW {
+			maxWi = capW
 		}
 		for w := maxWi; w >= int64(i); w-- {
 			count[w] += count[w-int64(i)]
@@ -60,15 +89,14 @@ func signedRankMarginExactRaw(n int, p float64) int {
 	}
 
 	var cumulative uint64
-	for w := int64(0); w <= maxW; w++ {
+	for w := int64(0); w <= capW; w++ {
 		cumulative += count[w]
 		cdf := float64(cumulative) / float64(total)
 		if cdf >= p {
-			return int(w)
+			return w, true
 		}
 	}
-
-	return int(maxW)
+	return 0, false
 }
 
 // signedRankMarginApprox computes one-sided margin using Edgeworth approximation for large n.
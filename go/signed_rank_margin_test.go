@@ -0,0 +1,58 @@
+package pragmastat
+
+import "testing"
+
+// signedRankMarginExactRawFull is the untruncated DP over all n(n+1)/2+1
+// counts, kept as the reference for the truncated implementation.
+func signedRankMarginExactRawFull(n int, p float64) int {
+	total := uint64(1) << n
+	maxW := int64(n) * int64(n+1) / 2
+
+	count := make([]uint64, maxW+1)
+	count[0] = 1
+	for i := 1; i <= n; i++ {
+		maxWi := int64(i) * int64(i+1) / 2
+		if maxWi > maxW {
+			maxWi = maxW
+		}
+		for w := maxWi; w >= int64(i); w-- {
+			count[w] += count[w-int64(i)]
+		}
+	}
+
+	var cumulative uint64
+	for w := int64(0); w <= maxW; w++ {
+		cumulative += count[w]
+		if float64(cumulative)/float64(total) >= p {
+			return int(w)
+		}
+	}
+	return int(maxW)
+}
+
+func TestSignedRankMarginExactRawMatchesFull(t *testing.T) {
+	ps := []float64{0, 1e-12, 1e-6, 1e-3, 0.005, 0.025, 0.05, 0.1, 0.25, 0.5, 0.75, 0.999, 1}
+	for n := 1; n <= signedRankMaxExactSize; n++ {
+		for _, p := range ps {
+			got := signedRankMarginExactRaw(n, p)
+			want := signedRankMarginExactRawFull(n, p)
+			if got != want {
+				t.Fatalf("n=%d p=%v: got %d, want %d", n, p, got, want)
+			}
+		}
+	}
+}
+
+func BenchmarkSignedRankMarginExact(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		signedRankMarginExact(63, 0.05)
+	}
+}
+
+func BenchmarkSignedRankMarginExactFull(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		signedRankMarginExactRawFull(63, 0.025)
+	}
+}
@@ -0,0 +1,73 @@
+package pragmastat
+
+import (
+	"math"
+	"sort"
+)
+
+// signedRatioImpl computes the median of all pairwise ratios {x[i] / y[j]}
+// for y values of either sign. No log-transform is possible, so the ratios are
+// selected directly: for a fixed y[j], x[i] / y[j] is monotone in x[i]
+// (increasing for y[j] > 0, decreasing for y[j] < 0), and IEEE division is
+// monotone in the numerator, so each column is counted by binary search.
+// Time complexity: O((m + n log m) * 64)
+// Space complexity: O(m) for the sorted copy of x
+func signedRatioImpl(x, y []float64) (float64, error) {
+	xs := sortedOne(x, false)
+	m := len(xs)
+
+	searchMin := math.Inf(1)
+	searchMax := math.Inf(-1)
+	for _, yj := range y {
+		a := xs[0] / yj
+		b := xs[m-1] / yj
+		searchMin = math.Min(searchMin, math.Min(a, b))
+		searchMax = math.Max(searchMax, math.Max(a, b))
+	}
+
+	count := func(threshold float64) (int64, float64, float64) {
+		var total int64
+		maxBelow := math.Inf(-1)
+		minAbove := math.Inf(1)
+		for _, yj := range y {
+			if yj > 0 {
+				// Ratios ascend with i: the first idx entries are <= threshold.
+				idx := sort.Search(m, func(i int) bool { return xs[i]/yj > threshold })
+				total += int64(idx)
+				if idx > 0 {
+					maxBelow = math.Max(maxBelow, xs[idx-1]/yj)
+				}
+				if idx < m {
+					minAbove = math.Min(minAbove, xs[idx]/yj)
+				}
+			} else {
+				// Ratios descend with i: entries from idx on are <= threshold.
+				idx := sort.Search(m, func(i int) bool { return xs[i]/yj <= threshold })
+				total += int64(m - idx)
+				if idx < m {
+					maxBelow = math.Max(maxBelow, xs[idx]/yj)
+				}
+				if idx > 0 {
+					minAbove = math.Min(minAbove, xs[idx-1]/yj)
+				}
+			}
+		}
+		return total, maxBelow, minAbove
+	}
+
+	total := int64(m) * int64(len(y))
+	kLow := (total + 1) / 2
+	kHigh := (total + 2) / 2
+	low, err := selectKthOrdered(searchMin, searchMax, kLow, count)
+	if err != nil {
+		return 0, err
+	}
+	if kLow == kHigh {
+		return low, nil
+	}
+	high, err := selectKthOrdered(searchMin, searchMax, kHigh, count)
+	if err != nil {
+		return 0, err
+	}
+	return 0.5*low + 0.5*high, nil
+}
@@ -0,0 +1,54 @@
+package pragmastat
+
+import "testing"
+
+// bruteSignedRatio materializes every ratio and takes the median.
+func bruteSignedRatio(x, y []float64) float64 {
+	ratios := make([]float64, 0, len(x)*len(y))
+	for _, xi := range x {
+		for _, yj := range y {
+			ratios = append(ratios, xi/yj)
+		}
+	}
+	return sortMedian(ratios)
+}
+
+func TestSignedRatioMixedSigns(t *testing.T) {
+	rng := NewRngFromSeed(invarianceSeed)
+	for iter := 0; iter < 100; iter++ {
+		x := addScalar(uniformVec(rng, rng.UniformIntN(1, 20)), -0.3)
+		y := addScalar(uniformVec(rng, rng.UniformIntN(1, 20)), -0.5)
+		got, err := SignedRatio(x, y)
+		if err != nil {
+			t.Fatalf("SignedRatio: %v", err)
+		}
+		if want := bruteSignedRatio(x, y); got != want {
+			t.Fatalf("SignedRatio(%v, %v) = %v, want %v", x, y, got, want)
+		}
+	}
+}
+
+func TestSignedRatioMatchesRatioForPositiveInput(t *testing.T) {
+	// 15 pairs: both estimators pick the same single middle ratio.
+	x := []float64{1, 2, 3, 4, 5}
+	y := []float64{2, 4, 8}
+	got, err := SignedRatio(x, y)
+	if err != nil {
+		t.Fatalf("SignedRatio: %v", err)
+	}
+	want, err := Ratio(x, y, false)
+	if err != nil {
+		t.Fatalf("Ratio: %v", err)
+	}
+	if !floatEquals(got, want, 1e-9) {
+		t.Errorf("SignedRatio = %v, Ratio = %v", got, want)
+	}
+}
+
+func TestSignedRatioZeroY(t *testing.T) {
+	_, err := SignedRatio([]float64{1, 2}, []float64{-1, 0, 1})
+	assertViolation(t, err, Domain, SubjectY)
+
+	_, err = SignedRatio(nil, []float64{0})
+	assertViolation(t, err, Validity, SubjectX)
+}
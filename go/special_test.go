@@ -0,0 +1,46 @@
+package pragmastat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRegIncBeta(t *testing.T) {
+	cases := []struct{ a, b, x, want float64 }{
+		{1, 1, 0.3, 0.3},
+		{2.5, 1, 0.4, 0.10119288512538814}, // x^a
+		{1, 4, 0.2, 0.5904},                // 1 - (1-x)^b
+		{2, 3, 0.5, 0.6875},
+		{0.5, 0.5, 0.5, 0.5},
+		{50, 50, 0.5, 0.5},
+		{3, 2, 0, 0},
+		{3, 2, 1, 1},
+	}
+	for _, c := range cases {
+		if got := regIncBeta(c.a, c.b, c.x); !floatEquals(got, c.want, 1e-12) {
+			t.Errorf("I_%v(%v, %v) = %v, want %v", c.x, c.a, c.b, got, c.want)
+		}
+	}
+	// Symmetry I_x(a, b) = 1 - I_{1-x}(b, a).
+	if a, b := regIncBeta(3.5, 7, 0.2), regIncBeta(7, 3.5, 0.8); !floatEquals(a, 1-b, 1e-14) {
+		t.Errorf("symmetry: %v vs 1 - %v", a, b)
+	}
+}
+
+func TestRegIncGamma(t *testing.T) {
+	for _, x := range []float64{0.01, 0.3, 1, 2.5, 7, 30} {
+		cases := []struct{ a, want float64 }{
+			{1, -math.Expm1(-x)},
+			{0.5, math.Erf(math.Sqrt(x))},
+			{3, 1 - math.Exp(-x)*(1+x+x*x/2)},
+		}
+		for _, c := range cases {
+			if got := regIncGamma(c.a, x); !floatEquals(got, c.want, 1e-13) {
+				t.Errorf("P(%v, %v) = %v, want %v", c.a, x, got, c.want)
+			}
+		}
+	}
+	if regIncGamma(2, 0) != 0 || regIncGamma(2, math.Inf(1)) != 1 {
+		t.Error("P(a, x) endpoints are not 0 and 1")
+	}
+}
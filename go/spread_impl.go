@@ -274,3 +274,16 @@ func spreadImpl[T Number](values []T, assumeSorted bool) (float64, error) {
 		pivot = float64(a[col]) - float64(a[row])
 	}
 }
+
+// weightedSpreadImpl is the weighted Shamos estimator: the weighted median of
+// the pairwise absolute differences |x[i]-x[j]| (i < j), each pair weighted
+// by w[i]·w[j], as computed by weightedPairwiseMedian. Equal weights
+// reproduce spreadImpl, and zero-weight values contribute nothing.
+// Time complexity: O(n log² n) expected
+// Space complexity: O(n)
+func weightedSpreadImpl(sorted, weights []float64) (float64, error) {
+	if len(sorted) == 0 {
+		return 0.0, errEmptyInput
+	}
+	return weightedPairwiseMedian(sorted, weights, false, false), nil
+}
@@ -0,0 +1,167 @@
+package pragmastat
+
+import "math"
+
+// StudentT represents a Student's t distribution with Df degrees of freedom,
+// shifted by Location and stretched by Scale. Its tails are polynomial, so
+// it models heavy-tailed noise between Cauchy (Df = 1) and Additive
+// (Df → ∞).
+type StudentT struct {
+	Df       float64
+	Location float64
+	Scale    float64
+}
+
+// NewStudentT creates a new Student's t distribution.
+// Panics if df <= 0, df is infinite, or scale <= 0.
+func NewStudentT(df, location, scale float64) *StudentT {
+	s, err := TryNewStudentT(df, location, scale)
+	if err != nil {
+		panic(err.Error())
+	}
+	return s
+}
+
+// TryNewStudentT is NewStudentT returning a *ParameterError instead of
+// panicking.
+func TryNewStudentT(df, location, scale float64) (*StudentT, error) {
+	if !(df > 0) || math.IsInf(df, 1) {
+		return nil, newParameterError("StudentT", "df", "df must be positive and finite")
+	}
+	if !(scale > 0) {
+		return nil, newParameterError("StudentT", "scale", "scale must be positive")
+	}
+	return &StudentT{Df: df, Location: location, Scale: scale}, nil
+}
+
+// Sample generates a single sample from the Student's t distribution.
+func (s *StudentT) Sample(rng *Rng) float64 {
+	return s.Location + s.Scale*s.standard(rng)
+}
+
+// standard draws a standard t value with Bailey's polar method: u and v are
+// uniform on (-1, 1), and the pair is redrawn until w = u² + v² lies in
+// (0, 1]. Each attempt consumes two uniforms and succeeds with probability
+// π/4. The value is u·√(df·(w^(-2/df) - 1)/w).
+func (s *StudentT) standard(rng *Rng) float64 {
+	for {
+		u := 2*rng.UniformFloat64() - 1
+		v := 2*rng.UniformFloat64() - 1
+		w := u*u + v*v
+		if w > 0 && w <= 1 {
+			return u * math.Sqrt(s.Df*math.Expm1(-2/s.Df*math.Log(w))/w)
+		}
+	}
+}
+
+// antitheticPair reflects the standard draw: location ± scale·t.
+func (s *StudentT) antitheticPair(rng *Rng) (float64, float64) {
+	t := s.standard(rng)
+	return s.Location + s.Scale*t, s.Location - s.Scale*t
+}
+
+// Samples generates multiple samples from the Student's t distribution.
+func (s *StudentT) Samples(rng *Rng, count int) []float64 {
+	return sampleN(s, rng, count)
+}
+
+// SamplesInto fills dst with samples from the Student's t distribution without allocating.
+func (s *StudentT) SamplesInto(rng *Rng, dst []float64) {
+	fillSamples(s, rng, dst)
+}
+
+// DrawsPerSample returns VariableDraws: the polar method rejects points
+// outside the unit disc.
+func (s *StudentT) DrawsPerSample() int {
+	return VariableDraws
+}
+
+// Pdf returns the probability density at x.
+func (s *StudentT) Pdf(x float64) float64 {
+	z := (x - s.Location) / s.Scale
+	v := s.Df
+	logNorm := lgamma((v+1)/2) - lgamma(v/2) - 0.5*math.Log(v*math.Pi)
+	return math.Exp(logNorm-(v+1)/2*math.Log1p(z*z/v)) / s.Scale
+}
+
+// Cdf returns P(X <= x).
+func (s *StudentT) Cdf(x float64) float64 {
+	z := (x - s.Location) / s.Scale
+	if z > 0 {
+		return 1 - s.tail(z)
+	}
+	return s.tail(-z)
+}
+
+// tail returns P(T > t) for t >= 0: I_x(df/2, 1/2)/2 with x = df/(df+t²).
+// For small t the complement I_{1-x}(1/2, df/2) is used, since 1-x is then
+// accurate while x rounds toward 1.
+func (s *StudentT) tail(t float64) float64 {
+	v := s.Df
+	t2 := t * t
+	if t2 < v {
+		return 0.5 * (1 - regIncBeta(0.5, v/2, t2/(v+t2)))
+	}
+	return 0.5 * regIncBeta(v/2, 0.5, v/(v+t2))
+}
+
+// Quantile returns the inverse CDF, found by bisection on the tail
+// probability. Quantile(0) is -Inf, Quantile(1) is +Inf, and p outside
+// [0, 1] gives NaN.
+func (s *StudentT) Quantile(p float64) float64 {
+	switch {
+	case invalidProbability(p):
+		return math.NaN()
+	case p == 0:
+		return math.Inf(-1)
+	case p == 1:
+		return math.Inf(1)
+	case p == 0.5:
+		return s.Location
+	}
+	q, sign := p, -1.0
+	if p > 0.5 {
+		q, sign = 1-p, 1.0
+	}
+	negTail := func(t float64) float64 { return -s.tail(t) }
+	t := invertIncreasing(negTail, -q, 0, 1, 1)
+	return s.Location + sign*s.Scale*t
+}
+
+// TrueCenter returns the population Center, which equals the location by
+// symmetry.
+func (s *StudentT) TrueCenter() float64 {
+	return s.Location
+}
+
+// TrueSpread returns the population Spread, computed numerically from Cdf
+// and Quantile (there is no closed form).
+func (s *StudentT) TrueSpread() float64 {
+	return numericTrueSpread(s)
+}
+
+// TrueMean returns the population mean, the location for df > 1, or NaN
+// for df <= 1 where the mean is undefined.
+func (s *StudentT) TrueMean() float64 {
+	if s.Df <= 1 {
+		return math.NaN()
+	}
+	return s.Location
+}
+
+// TrueVariance returns the population variance scale²·df/(df-2) for df > 2,
+// +Inf for 1 < df <= 2, and NaN for df <= 1 where the mean is undefined.
+func (s *StudentT) TrueVariance() float64 {
+	switch {
+	case s.Df <= 1:
+		return math.NaN()
+	case s.Df <= 2:
+		return math.Inf(1)
+	}
+	return s.Scale * s.Scale * s.Df / (s.Df - 2)
+}
+
+// TrueStdDev returns the population standard deviation, √TrueVariance.
+func (s *StudentT) TrueStdDev() float64 {
+	return math.Sqrt(s.TrueVariance())
+}
@@ -0,0 +1,79 @@
+package pragmastat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStudentTFunctions(t *testing.T) {
+	// Df = 1 is the Cauchy distribution.
+	st, cauchy := NewStudentT(1, 2, 3), NewCauchy(2, 3)
+	for _, x := range []float64{-50, -3, 0, 1.5, 2, 7, 40} {
+		if !floatEquals(st.Cdf(x), cauchy.Cdf(x), 1e-13) || !floatEquals(st.Pdf(x), cauchy.Pdf(x), 1e-13) {
+			t.Errorf("Df=1 at %v: Cdf %v vs %v, Pdf %v vs %v", x, st.Cdf(x), cauchy.Cdf(x), st.Pdf(x), cauchy.Pdf(x))
+		}
+	}
+
+	// Reference quantiles of the standard t distribution.
+	cases := []struct{ df, p, want float64 }{
+		{3, 0.975, 3.182446305284263},
+		{10, 0.95, 1.812461122811676},
+		{10, 0.05, -1.812461122811676},
+		{30, 0.995, 2.749995653567040},
+	}
+	for _, c := range cases {
+		if got := NewStudentT(c.df, 0, 1).Quantile(c.p); !floatEquals(got, c.want, 1e-10) {
+			t.Errorf("t(%v).Quantile(%v) = %v, want %v", c.df, c.p, got, c.want)
+		}
+	}
+
+	// A very large Df approaches the standard normal.
+	normal, wide := NewAdditive(0, 1), NewStudentT(1e7, 0, 1)
+	for _, x := range []float64{-3, -1, 0.5, 2.5} {
+		if !floatEquals(wide.Cdf(x), normal.Cdf(x), 1e-6) {
+			t.Errorf("Df=1e7 Cdf(%v) = %v, normal %v", x, wide.Cdf(x), normal.Cdf(x))
+		}
+	}
+}
+
+func TestStudentTSampling(t *testing.T) {
+	const n = 100000
+	x := NewStudentT(4, 5, 1).Samples(NewRngFromString("student-t"), n)
+	center, _ := Center(x, false)
+	if math.Abs(center-5) > 0.02 {
+		t.Errorf("Center = %v, want about 5", center)
+	}
+
+	// Same stream, doubled scale: every sample's deviation doubles.
+	narrow := NewStudentT(4, 0, 1).Samples(NewRngFromString("student-t-scale"), 1000)
+	wide := NewStudentT(4, 0, 2).Samples(NewRngFromString("student-t-scale"), 1000)
+	narrowSpread, _ := Spread(narrow, false)
+	wideSpread, _ := Spread(wide, false)
+	if !floatEquals(wideSpread, 2*narrowSpread, 1e-12) {
+		t.Errorf("Spread with scale 2 = %v, want 2·%v", wideSpread, narrowSpread)
+	}
+
+	// Huge Df behaves like Additive(0, 1).
+	y := NewStudentT(1e6, 0, 1).Samples(NewRngFromString("student-t-normal"), n)
+	spread, _ := Spread(y, false)
+	if want := NewAdditive(0, 1).TrueSpread(); math.Abs(spread-want) > 0.02 {
+		t.Errorf("Df=1e6 Spread = %v, normal TrueSpread %v", spread, want)
+	}
+}
+
+func TestStudentTPanics(t *testing.T) {
+	for name, f := range map[string]func(){
+		"zero df":     func() { NewStudentT(0, 0, 1) },
+		"infinite df": func() { NewStudentT(math.Inf(1), 0, 1) },
+		"zero scale":  func() { NewStudentT(3, 0, 0) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected a panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
@@ -1,6 +1,9 @@
 package pragmastat
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 // TestErrorSubjectIsPositional verifies that the error "subject" is determined
 // by ARGUMENT POSITION, not stored on the Sample. The same constant sample
@@ -39,3 +42,37 @@ func TestErrorSubjectIsPositional(t *testing.T) {
 		t.Errorf("constant sample was mutated: Spread reports %v, want sparity(x)", err)
 	}
 }
+
+// TestNewSampleForSubject verifies that construction validity errors carry the
+// requested subject, while the default constructors keep reporting subject x.
+func TestNewSampleForSubject(t *testing.T) {
+	inputs := map[string][]float64{
+		"empty": {},
+		"NaN":   {1, math.NaN(), 3},
+		"+Inf":  {1, math.Inf(1)},
+	}
+	for name, values := range inputs {
+		for _, subject := range []Subject{SubjectX, SubjectY} {
+			_, err := NewSampleForSubject(values, nil, nil, subject)
+			assertViolation(t, err, Validity, subject)
+			_, err = NewSampleForSubject(values, nil, RatioUnit, subject)
+			assertViolation(t, err, Validity, subject)
+		}
+		_, err := NewSample(values)
+		assertViolation(t, err, Validity, SubjectX)
+		_, err = NewWeightedSample(values, nil, nil)
+		assertViolation(t, err, Validity, SubjectX)
+		if t.Failed() {
+			t.Fatalf("input %s", name)
+		}
+	}
+
+	// Valid input builds the same sample regardless of subject.
+	y, err := NewSampleForSubject([]float64{3, 1, 2}, []float64{1, 1, 2}, nil, SubjectY)
+	if err != nil {
+		t.Fatalf("NewSampleForSubject: %v", err)
+	}
+	if !y.IsWeighted() || y.Size() != 3 || y.Unit() != NumberUnit {
+		t.Errorf("unexpected sample: weighted=%v size=%d unit=%v", y.IsWeighted(), y.Size(), y.Unit())
+	}
+}
@@ -0,0 +1,54 @@
+package pragmastat
+
+// SumOf is the distribution of the sum of K independent draws from an inner
+// distribution, e.g. the total latency of K sequential requests.
+type SumOf struct {
+	Inner Distribution
+	K     int
+}
+
+// NewSumOf creates the distribution of the sum of k inner variates.
+// Panics if inner is nil or k < 1.
+func NewSumOf(inner Distribution, k int) *SumOf {
+	s, err := TryNewSumOf(inner, k)
+	if err != nil {
+		panic(err.Error())
+	}
+	return s
+}
+
+// TryNewSumOf is NewSumOf returning a *ParameterError instead of panicking.
+func TryNewSumOf(inner Distribution, k int) (*SumOf, error) {
+	if inner == nil {
+		return nil, newParameterError("SumOf", "inner", "inner cannot be nil")
+	}
+	if k < 1 {
+		return nil, newParameterError("SumOf", "k", "k must be at least 1")
+	}
+	return &SumOf{Inner: inner, K: k}, nil
+}
+
+// Sample returns the sum of K consecutive inner.Sample(rng) draws, so one
+// sample consumes exactly the random numbers of K inner samples.
+func (s *SumOf) Sample(rng *Rng) float64 {
+	sum := 0.0
+	for i := 0; i < s.K; i++ {
+		sum += s.Inner.Sample(rng)
+	}
+	return sum
+}
+
+// Samples generates multiple samples from the sum distribution.
+func (s *SumOf) Samples(rng *Rng, count int) []float64 {
+	return sampleN(s, rng, count)
+}
+
+// SamplesInto fills dst with samples from the sum distribution without allocating.
+func (s *SumOf) SamplesInto(rng *Rng, dst []float64) {
+	fillSamples(s, rng, dst)
+}
+
+// DrawsPerSample returns K times the inner distribution's count.
+func (s *SumOf) DrawsPerSample() int {
+	return multiplyDraws(s.K, DrawsPerSample(s.Inner))
+}
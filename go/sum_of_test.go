@@ -0,0 +1,43 @@
+package pragmastat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSumOfStream(t *testing.T) {
+	inner := NewExp(1)
+	s := NewSumOf(inner, 3)
+	ref := NewRngFromString("sum-of-stream")
+	rng := NewRngFromString("sum-of-stream")
+	for i := 0; i < 100; i++ {
+		want := inner.Sample(ref) + inner.Sample(ref) + inner.Sample(ref)
+		if got := s.Sample(rng); got != want {
+			t.Fatalf("draw %d = %v, want %v", i, got, want)
+		}
+	}
+}
+
+// TestSumOfAdditive checks that the sum of k normal variates scales as
+// expected: Center k·mean and Spread √k times the inner Spread.
+func TestSumOfAdditive(t *testing.T) {
+	const n = 20_000
+	inner := NewAdditive(2, 1)
+	for _, k := range []int{1, 4, 9} {
+		x := NewSumOf(inner, k).Samples(NewRngFromString("sum-of-additive"), n)
+		center, err := Center(x, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := float64(k) * inner.TrueCenter(); math.Abs(center-want) > 0.05*math.Sqrt(float64(k)) {
+			t.Errorf("k = %d: Center = %v, want %v", k, center, want)
+		}
+		spread, err := Spread(x, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := math.Sqrt(float64(k)) * inner.TrueSpread(); math.Abs(spread-want) > 0.03*want {
+			t.Errorf("k = %d: Spread = %v, want %v", k, spread, want)
+		}
+	}
+}
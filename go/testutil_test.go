@@ -0,0 +1,33 @@
+package pragmastat
+
+import (
+	"math"
+	"testing"
+)
+
+// Shared test helpers. Keep helpers used by more than one test file here so
+// that each is declared exactly once in the package.
+
+// floatEquals checks if two float64 values are approximately equal
+func floatEquals(a, b, epsilon float64) bool {
+	return ApproxEqual(a, b, epsilon)
+}
+
+func TestFloatEquals(t *testing.T) {
+	cases := []struct {
+		a, b, epsilon float64
+		want          bool
+	}{
+		{1, 1 + 1e-10, 1e-9, true},
+		{1, 1 + 1e-8, 1e-9, false},
+		{math.Inf(1), math.Inf(1), 1e-9, true},
+		{math.Inf(-1), math.Inf(1), 1e-9, false},
+		{math.NaN(), math.NaN(), 1e-9, true},
+		{math.NaN(), 0, 1e-9, false},
+	}
+	for _, c := range cases {
+		if got := floatEquals(c.a, c.b, c.epsilon); got != c.want {
+			t.Errorf("floatEquals(%v, %v, %v) = %v, want %v", c.a, c.b, c.epsilon, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package pragmastat
+
+import "math"
+
+// trueValueNodes is the number of midpoint nodes used to integrate over the
+// probability scale when a population estimator has no closed form.
+const trueValueNodes = 20000
+
+// numericTrueCenter computes the population Hodges-Lehmann pseudo-median of
+// d: the c solving P((X1+X2)/2 <= c) = 1/2 for independent X1, X2 ~ d. The
+// probability E[F(2c - X)] is integrated over u in (0, 1) with X = Q(u).
+func numericTrueCenter(d FullDistribution) float64 {
+	q := quantileNodes(d)
+	prob := func(c float64) float64 {
+		var sum float64
+		for _, x := range q {
+			sum += d.Cdf(2*c - x)
+		}
+		return sum / float64(len(q))
+	}
+	mid := d.Quantile(0.5)
+	return invertIncreasing(prob, 0.5, mid, mid, d.Quantile(0.75)-d.Quantile(0.25))
+}
+
+// numericTrueSpread computes the population Shamos spread of d: the t solving
+// P(|X1-X2| <= t) = 1/2, integrating F(X+t) - F(X-t) over u with X = Q(u).
+func numericTrueSpread(d FullDistribution) float64 {
+	q := quantileNodes(d)
+	prob := func(t float64) float64 {
+		var sum float64
+		for _, x := range q {
+			sum += d.Cdf(x+t) - d.Cdf(x-t)
+		}
+		return sum / float64(len(q))
+	}
+	iqr := d.Quantile(0.75) - d.Quantile(0.25)
+	return invertIncreasing(prob, 0.5, 0, iqr, iqr)
+}
+
+// quantileNodes returns Q(u) at the midpoints u = (i + 1/2) / trueValueNodes.
+func quantileNodes(d FullDistribution) []float64 {
+	q := make([]float64, trueValueNodes)
+	for i := range q {
+		q[i] = d.Quantile((float64(i) + 0.5) / trueValueNodes)
+	}
+	return q
+}
+
+// spreadOfNormal is the population Shamos spread of a standard normal:
+// X1-X2 ~ N(0, 2), so the median of |X1-X2| is √2·Φ⁻¹(3/4) ≈ 0.9539.
+var spreadOfNormal = math.Sqrt2 * gaussQuantile(0.75)
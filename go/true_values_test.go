@@ -0,0 +1,75 @@
+package pragmastat
+
+import (
+	"math"
+	"testing"
+)
+
+type trueValued interface {
+	FullDistribution
+	TrueCenter() float64
+	TrueSpread() float64
+}
+
+var trueValuedDistributions = map[string]trueValued{
+	"Uniform":      NewUniform(-2, 5),
+	"Additive":     NewAdditive(10, 3),
+	"Multiplic":    NewMultiplic(0.5, 0.8),
+	"Exp":          NewExp(0.5),
+	"Power":        NewPower(1, 2),
+	"Cauchy":       NewCauchy(1, 2),
+	"StudentT":     NewStudentT(3, 1, 2),
+	"Gamma":        NewGamma(2.5, 0.5),
+	"Beta":         NewBeta(0.7, 3),
+	"Contaminated": NewContaminated(1, 2, 0.1, 10),
+}
+
+// TestNumericTrueValuesMatchClosedForms validates the numeric integration on
+// the families that also have closed forms.
+func TestNumericTrueValuesMatchClosedForms(t *testing.T) {
+	for _, name := range []string{"Uniform", "Additive", "Cauchy"} {
+		d := trueValuedDistributions[name]
+		if got, want := numericTrueCenter(d), d.TrueCenter(); math.Abs(got-want) > 1e-6*d.TrueSpread() {
+			t.Errorf("%s: numeric center %v, closed form %v", name, got, want)
+		}
+	}
+	for _, name := range []string{"Uniform", "Additive", "Exp", "Cauchy"} {
+		d := trueValuedDistributions[name]
+		if got, want := numericTrueSpread(d), d.TrueSpread(); !floatEquals(got, want, 1e-6*want) {
+			t.Errorf("%s: numeric spread %v, closed form %v", name, got, want)
+		}
+	}
+	if got := NewExp(1).TrueCenter(); !floatEquals(got, 0.8391734950083, 1e-8) {
+		t.Errorf("Exp(1).TrueCenter() = %v", got)
+	}
+	if got := NewAdditive(0, 1).TrueSpread(); !floatEquals(got, 0.9538725524089, 1e-12) {
+		t.Errorf("Additive(0, 1).TrueSpread() = %v", got)
+	}
+}
+
+func TestTrueValuesMatchLargeSamples(t *testing.T) {
+	if testing.Short() {
+		t.Skip("draws 10^5 values per distribution")
+	}
+	const n = 100000
+	for name, d := range trueValuedDistributions {
+		x := d.Samples(NewRngFromString("true-values-"+name), n)
+		center, err := Center(x, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		spread, err := Spread(x, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Both estimators have standard errors of order spread/√n ≈ 0.003·spread.
+		trueCenter, trueSpread := d.TrueCenter(), d.TrueSpread()
+		tolerance := 0.015 * trueSpread
+		if math.Abs(center-trueCenter) > tolerance {
+			t.Errorf("%s: Center = %v, TrueCenter = %v", name, center, trueCenter)
+		}
+		if math.Abs(spread-trueSpread) > tolerance {
+			t.Errorf("%s: Spread = %v, TrueSpread = %v", name, spread, trueSpread)
+		}
+	}
+}
@@ -1,30 +1,191 @@
 package pragmastat
 
+import "math"
+
 // Uniform represents a uniform distribution on [min, max).
+//
+// NewUniform samples the half-open interval [min, max): it may return min
+// but, barring rounding of min + u·(max-min), never max. NewUniformClosed
+// samples [min, max] and can (with probability about 2^-53) return exactly
+// max; NewUniformOpen samples (min, max) and returns neither endpoint. Pdf,
+// Cdf, and Quantile are the same for all three, since the endpoints have
+// probability zero.
 type Uniform struct {
-	Min float64
-	Max float64
+	Min    float64
+	Max    float64
+	closed bool // draw with UniformClosed (see NewUniformClosed)
+	open   bool // exclude both endpoints (see NewUniformOpen)
 }
 
 // NewUniform creates a new uniform distribution on [min, max).
-// Panics if min >= max.
+// Panics if min or max is not finite or min >= max.
 func NewUniform(min, max float64) *Uniform {
-	if min >= max {
-		panic("min must be less than max")
+	u, err := TryNewUniform(min, max)
+	if err != nil {
+		panic(err.Error())
+	}
+	return u
+}
+
+// TryNewUniform is NewUniform returning a *ParameterError instead of panicking.
+func TryNewUniform(min, max float64) (*Uniform, error) {
+	if !isFinite(min) {
+		return nil, newParameterError("Uniform", "min", "min must be finite")
+	}
+	if !isFinite(max) {
+		return nil, newParameterError("Uniform", "max", "max must be finite")
+	}
+	if !(min < max) {
+		return nil, newParameterError("Uniform", "min", "min must be less than max")
 	}
-	return &Uniform{Min: min, Max: max}
+	return &Uniform{Min: min, Max: max}, nil
+}
+
+// NewUniformClosed is NewUniform sampling the closed interval [min, max]
+// with UniformClosed, so max itself can be drawn.
+func NewUniformClosed(min, max float64) *Uniform {
+	u := NewUniform(min, max)
+	u.closed = true
+	return u
+}
+
+// NewUniformOpen is NewUniform sampling the open interval (min, max). It
+// draws with UniformOpen and redraws the rare value that rounds onto an
+// endpoint, so away from those draws its values equal NewUniform's for the
+// same seed.
+func NewUniformOpen(min, max float64) *Uniform {
+	u := NewUniform(min, max)
+	u.open = true
+	return u
 }
 
 // Sample generates a single sample from the uniform distribution.
 func (u *Uniform) Sample(rng *Rng) float64 {
-	return u.Min + rng.UniformFloat64()*(u.Max-u.Min)
+	switch {
+	case u.closed:
+		return u.closedAt(rng.UniformClosed())
+	case u.open:
+		for {
+			if x := u.at(rng.UniformOpen()); u.inside(x) {
+				return x
+			}
+		}
+	}
+	return u.at(rng.UniformFloat64())
+}
+
+// antitheticPair maps (v, 1-v) for a draw v of the variant's own kind, so
+// the first value equals Sample's. For NewUniformOpen both v and 1-v lie in
+// (0, 1), and a pair in which either value rounds onto an endpoint is
+// redrawn. For NewUniform, v = 0 mirrors to max, which the half-open
+// interval excludes; that second value is moved to the largest float64
+// below max.
+func (u *Uniform) antitheticPair(rng *Rng) (float64, float64) {
+	switch {
+	case u.closed:
+		v := rng.UniformClosed()
+		return u.closedAt(v), u.closedAt(1 - v)
+	case u.open:
+		for {
+			v := rng.UniformOpen()
+			if x, y := u.at(v), u.at(1-v); u.inside(x) && u.inside(y) {
+				return x, y
+			}
+		}
+	}
+	v := rng.UniformFloat64()
+	return u.at(v), math.Min(u.at(1-v), math.Nextafter(u.Max, u.Min))
+}
+
+// at maps a unit draw v onto the interval.
+func (u *Uniform) at(v float64) float64 {
+	return u.Min + v*(u.Max-u.Min)
+}
+
+// closedAt is at for a UniformClosed draw: v = 1 maps to max exactly, and
+// rounding never goes past it.
+func (u *Uniform) closedAt(v float64) float64 {
+	if v == 1 {
+		return u.Max
+	}
+	return math.Min(u.at(v), u.Max)
+}
+
+// inside reports whether x lies strictly between min and max.
+func (u *Uniform) inside(x float64) bool {
+	return x > u.Min && x < u.Max
 }
 
 // Samples generates multiple samples from the uniform distribution.
 func (u *Uniform) Samples(rng *Rng, count int) []float64 {
-	result := make([]float64, count)
-	for i := 0; i < count; i++ {
-		result[i] = u.Sample(rng)
+	return sampleN(u, rng, count)
+}
+
+// SamplesInto fills dst with samples from the uniform distribution without allocating.
+func (u *Uniform) SamplesInto(rng *Rng, dst []float64) {
+	fillSamples(u, rng, dst)
+}
+
+// DrawsPerSample returns 1 (one uniform per inverse-CDF draw), or
+// VariableDraws for NewUniformOpen, which rejects the endpoints.
+func (u *Uniform) DrawsPerSample() int {
+	if u.open {
+		return VariableDraws
+	}
+	return 1
+}
+
+// Pdf returns the probability density at x: 1/(max-min) on [min, max).
+func (u *Uniform) Pdf(x float64) float64 {
+	if x < u.Min || x >= u.Max {
+		return 0
+	}
+	return 1 / (u.Max - u.Min)
+}
+
+// Cdf returns P(X <= x).
+func (u *Uniform) Cdf(x float64) float64 {
+	switch {
+	case x <= u.Min:
+		return 0
+	case x >= u.Max:
+		return 1
+	}
+	return (x - u.Min) / (u.Max - u.Min)
+}
+
+// Quantile returns the inverse CDF: min + p·(max-min).
+// p outside [0, 1] gives NaN.
+func (u *Uniform) Quantile(p float64) float64 {
+	if invalidProbability(p) {
+		return math.NaN()
 	}
-	return result
+	return u.Min + p*(u.Max-u.Min)
+}
+
+// TrueCenter returns the population Center: (min+max)/2 by symmetry.
+func (u *Uniform) TrueCenter() float64 {
+	return (u.Min + u.Max) / 2
+}
+
+// TrueSpread returns the population Spread. |X1-X2| has the triangular CDF
+// 1 - (1 - t/w)² on [0, w] with w = max-min, so the median is w·(1 - 1/√2).
+func (u *Uniform) TrueSpread() float64 {
+	return (u.Max - u.Min) * (1 - 1/math.Sqrt2)
+}
+
+// TrueMean returns the population mean (min+max)/2.
+func (u *Uniform) TrueMean() float64 {
+	return (u.Min + u.Max) / 2
+}
+
+// TrueVariance returns the population variance (max-min)²/12.
+func (u *Uniform) TrueVariance() float64 {
+	w := u.Max - u.Min
+	return w * w / 12
+}
+
+// TrueStdDev returns the population standard deviation, √TrueVariance.
+func (u *Uniform) TrueStdDev() float64 {
+	return math.Sqrt(u.TrueVariance())
 }
@@ -0,0 +1,201 @@
+package pragmastat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrNotConverged is returned by GeometricMedian, together with the last
+// iterate, when the iteration limit is reached before the tolerance is met.
+var ErrNotConverged = errors.New("geometric median did not converge")
+
+// CenterVector computes the componentwise Center of multivariate data given
+// as rows of equal length (for example, p50 and p99 latency measured
+// together): element j of the result is Center of column j.
+//
+// This is a per-column estimate, not a geometric (spatial) median: it is not
+// rotation-equivariant and ignores the dependence between columns. See
+// GeometricMedian for a joint multivariate center.
+//
+// Returns a Validity error if x has no rows, the rows have no columns, or any
+// value is non-finite, and a plain error if the rows differ in length.
+func CenterVector(x [][]float64) ([]float64, error) {
+	if err := checkRows(x); err != nil {
+		return nil, err
+	}
+	columns := len(x[0])
+
+	result := make([]float64, columns)
+	column := make([]float64, len(x))
+	for j := range result {
+		for i, row := range x {
+			column[i] = row[j]
+		}
+		centerVal, err := centerImpl(context.Background(), column, false)
+		if err != nil {
+			return nil, err
+		}
+		result[j] = centerVal
+	}
+	return result, nil
+}
+
+// GeometricMedian computes the geometric (spatial) median of points: the
+// point minimizing the sum of Euclidean distances to all of them. Unlike
+// CenterVector it treats the coordinates jointly and is rotation-equivariant;
+// its breakdown point is 50%.
+//
+// Weiszfeld's algorithm is started at the centroid and iterated until an
+// update moves the estimate by at most tol (Euclidean distance). When the
+// estimate coincides with data points, the Vardi–Zhang modification is
+// applied, so the iteration can leave a data point instead of dividing by
+// zero. Because Weiszfeld approaches an optimal data point only sublinearly,
+// each iteration also tests whether the data point nearest to the estimate
+// satisfies the optimality condition and, if so, returns it exactly.
+//
+// Returns a Validity error if points is empty, the points have no
+// coordinates, or any coordinate is non-finite; a plain error if the points
+// differ in dimension, tol is not positive, or maxIter < 1. If the tolerance
+// is not met within maxIter iterations, the last iterate is returned together
+// with ErrNotConverged.
+func GeometricMedian(points [][]float64, tol float64, maxIter int) ([]float64, error) {
+	if err := checkRows(points); err != nil {
+		return nil, err
+	}
+	if math.IsNaN(tol) || tol <= 0 {
+		return nil, fmt.Errorf("tol must be positive, got %v", tol)
+	}
+	if maxIter < 1 {
+		return nil, fmt.Errorf("maxIter must be at least 1, got %d", maxIter)
+	}
+
+	dim := len(points[0])
+	n := float64(len(points))
+	estimate := make([]float64, dim)
+	for _, p := range points {
+		for j, v := range p {
+			estimate[j] += v / n
+		}
+	}
+	if len(points) == 1 {
+		return estimate, nil
+	}
+
+	next := make([]float64, dim)
+	weighted := make([]float64, dim) // sum of p / ||p - y|| over distinct points
+	pull := make([]float64, dim)     // sum of (p - y) / ||p - y|| over distinct points
+	for iter := 0; iter < maxIter; iter++ {
+		for j := range weighted {
+			weighted[j], pull[j] = 0, 0
+		}
+		invSum := 0.0
+		coincident := 0.0
+		nearest, nearestDist := 0, math.Inf(1)
+		for i, p := range points {
+			d := euclideanDistance(p, estimate)
+			if d < nearestDist {
+				nearest, nearestDist = i, d
+			}
+			if d == 0 {
+				coincident++
+				continue
+			}
+			invSum += 1 / d
+			for j, v := range p {
+				weighted[j] += v / d
+				pull[j] += (v - estimate[j]) / d
+			}
+		}
+
+		if invSum == 0 {
+			// Every point coincides with the estimate.
+			return estimate, nil
+		}
+		if coincident == 0 && dataPointOptimal(points, nearest, next) {
+			copy(estimate, points[nearest])
+			return estimate, nil
+		}
+		// Vardi–Zhang: blend the Weiszfeld step T with the current estimate
+		// according to how strongly the other points pull away from it.
+		tWeight, yWeight := 1.0, 0.0
+		if coincident > 0 {
+			r := math.Sqrt(dot(pull, pull))
+			if r <= coincident {
+				// The estimate is a data point that is already optimal.
+				return estimate, nil
+			}
+			tWeight = 1 - coincident/r
+			yWeight = coincident / r
+		}
+		for j := range next {
+			next[j] = tWeight*(weighted[j]/invSum) + yWeight*estimate[j]
+		}
+
+		step := euclideanDistance(next, estimate)
+		estimate, next = next, estimate
+		if step <= tol {
+			return estimate, nil
+		}
+	}
+	return estimate, ErrNotConverged
+}
+
+// dataPointOptimal reports whether points[k] is a geometric median: the unit
+// vectors from it toward the other points must sum to a vector no longer
+// than the number of points coinciding with it. buf is scratch space of the
+// points' dimension.
+func dataPointOptimal(points [][]float64, k int, buf []float64) bool {
+	center := points[k]
+	for j := range buf {
+		buf[j] = 0
+	}
+	coincident := 0.0
+	for _, p := range points {
+		d := euclideanDistance(p, center)
+		if d == 0 {
+			coincident++
+			continue
+		}
+		for j, v := range p {
+			buf[j] += (v - center[j]) / d
+		}
+	}
+	return math.Sqrt(dot(buf, buf)) <= coincident
+}
+
+// checkRows validates multivariate input given as rows: at least one row,
+// at least one column, equal lengths, and finite values.
+func checkRows(x [][]float64) error {
+	if len(x) == 0 || len(x[0]) == 0 {
+		return NewValidityError(SubjectX)
+	}
+	columns := len(x[0])
+	for i, row := range x {
+		if len(row) != columns {
+			return fmt.Errorf("row %d has %d columns, want %d", i, len(row), columns)
+		}
+		if err := checkValidity(row, SubjectX); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func euclideanDistance(a, b []float64) float64 {
+	sum := 0.0
+	for j := range a {
+		d := a[j] - b[j]
+		sum += d * d
+	}
+	return math.Sqrt(sum)
+}
+
+func dot(a, b []float64) float64 {
+	sum := 0.0
+	for j := range a {
+		sum += a[j] * b[j]
+	}
+	return sum
+}
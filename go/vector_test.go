@@ -0,0 +1,163 @@
+package pragmastat
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestCenterVector(t *testing.T) {
+	x := [][]float64{
+		{12, 95, 1.5},
+		{10, 120, 2.5},
+		{11, 101, 1.0},
+		{14, 99, 3.0},
+		{13, 480, 2.0},
+	}
+	got, err := CenterVector(x)
+	if err != nil {
+		t.Fatalf("CenterVector: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("CenterVector returned %d components, want 3", len(got))
+	}
+	for j := range got {
+		column := make([]float64, len(x))
+		for i := range x {
+			column[i] = x[i][j]
+		}
+		want, err := Center(column, false)
+		if err != nil {
+			t.Fatalf("Center(column %d): %v", j, err)
+		}
+		if got[j] != want {
+			t.Errorf("component %d = %v, want %v", j, got[j], want)
+		}
+	}
+	if !floatEquals(got[0], 12, 1e-9) {
+		t.Errorf("component 0 = %v, want 12", got[0])
+	}
+
+	// The input rows are left untouched.
+	if x[0][0] != 12 || x[4][1] != 480 {
+		t.Error("CenterVector mutated its input")
+	}
+}
+
+func TestCenterVectorErrors(t *testing.T) {
+	_, err := CenterVector(nil)
+	assertViolation(t, err, Validity, SubjectX)
+
+	_, err = CenterVector([][]float64{{}, {}})
+	assertViolation(t, err, Validity, SubjectX)
+
+	_, err = CenterVector([][]float64{{1, 2}, {3, math.NaN()}})
+	assertViolation(t, err, Validity, SubjectX)
+
+	_, err = CenterVector([][]float64{{1, 2, 3}, {4, 5}, {6, 7, 8}})
+	if err == nil {
+		t.Fatal("expected error for ragged input")
+	}
+	if _, ok := err.(*AssumptionError); ok {
+		t.Errorf("ragged input must be a plain error, got %v", err)
+	}
+}
+
+func TestGeometricMedianCollinear(t *testing.T) {
+	// On a line the geometric median is the univariate median along it.
+	points := [][]float64{{0, 0}, {1, 2}, {2, 4}, {10, 20}, {3, 6}}
+	got, err := GeometricMedian(points, 1e-10, 1000)
+	if err != nil {
+		t.Fatalf("GeometricMedian: %v", err)
+	}
+	if !floatEquals(got[0], 2, 1e-6) || !floatEquals(got[1], 4, 1e-6) {
+		t.Errorf("GeometricMedian = %v, want [2 4]", got)
+	}
+	// The result lies on the line y = 2x.
+	if !floatEquals(got[1], 2*got[0], 1e-9) {
+		t.Errorf("GeometricMedian = %v is off the line", got)
+	}
+}
+
+func TestGeometricMedianSymmetricCluster(t *testing.T) {
+	points := [][]float64{
+		{1, 1, 1}, {-1, -1, -1}, {1, -1, 1}, {-1, 1, -1},
+		{2, 0, 0}, {-2, 0, 0}, {0, 2, 0}, {0, -2, 0},
+	}
+	for _, p := range points {
+		p[0] += 5
+		p[1] -= 3
+		p[2] += 0.5
+	}
+	got, err := GeometricMedian(points, 1e-12, 1000)
+	if err != nil {
+		t.Fatalf("GeometricMedian: %v", err)
+	}
+	want := []float64{5, -3, 0.5}
+	for j := range want {
+		if !floatEquals(got[j], want[j], 1e-9) {
+			t.Errorf("GeometricMedian = %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestGeometricMedianRobustness(t *testing.T) {
+	// A single wild point barely moves the estimate, unlike the centroid.
+	points := [][]float64{{0, 0}, {1, 0}, {0, 1}, {1, 1}, {0.5, 0.5}, {1e6, 1e6}}
+	got, err := GeometricMedian(points, 1e-10, 1000)
+	if err != nil {
+		t.Fatalf("GeometricMedian: %v", err)
+	}
+	// The optimum is the data point (0.5, 0.5): the corners' pulls cancel and
+	// the outlier's unit pull is balanced by the point itself.
+	if got[0] != 0.5 || got[1] != 0.5 {
+		t.Errorf("GeometricMedian = %v, want exactly [0.5 0.5]", got)
+	}
+}
+
+func TestGeometricMedianDegenerate(t *testing.T) {
+	got, err := GeometricMedian([][]float64{{3, 4}}, 1e-9, 10)
+	if err != nil || got[0] != 3 || got[1] != 4 {
+		t.Errorf("single point: got %v, %v", got, err)
+	}
+
+	got, err = GeometricMedian([][]float64{{1, 1}, {1, 1}, {1, 1}}, 1e-9, 10)
+	if err != nil || got[0] != 1 || got[1] != 1 {
+		t.Errorf("identical points: got %v, %v", got, err)
+	}
+
+	// The centroid of this set is the data point (1, 0), which is also the
+	// optimum: the iteration must stop there rather than divide by zero.
+	got, err = GeometricMedian([][]float64{{0, 0}, {1, 0}, {2, 0}}, 1e-9, 10)
+	if err != nil || got[0] != 1 || got[1] != 0 {
+		t.Errorf("estimate at a data point: got %v, %v", got, err)
+	}
+}
+
+func TestGeometricMedianErrors(t *testing.T) {
+	_, err := GeometricMedian(nil, 1e-9, 10)
+	assertViolation(t, err, Validity, SubjectX)
+
+	_, err = GeometricMedian([][]float64{{1, math.Inf(1)}}, 1e-9, 10)
+	assertViolation(t, err, Validity, SubjectX)
+
+	if _, err = GeometricMedian([][]float64{{1, 2}, {3}}, 1e-9, 10); err == nil {
+		t.Error("expected error for inconsistent dimensions")
+	}
+	if _, err = GeometricMedian([][]float64{{1}, {2}}, 0, 10); err == nil {
+		t.Error("expected error for tol = 0")
+	}
+	if _, err = GeometricMedian([][]float64{{1}, {2}}, 1e-9, 0); err == nil {
+		t.Error("expected error for maxIter = 0")
+	}
+
+	points := [][]float64{{0, 0}, {4, 0}, {0, 3}, {10, 10}}
+	last, err := GeometricMedian(points, 1e-15, 1)
+	if !errors.Is(err, ErrNotConverged) {
+		t.Fatalf("expected ErrNotConverged, got %v", err)
+	}
+	if len(last) != 2 {
+		t.Errorf("expected the last iterate alongside ErrNotConverged, got %v", last)
+	}
+}
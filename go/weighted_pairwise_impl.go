@@ -0,0 +1,199 @@
+package pragmastat
+
+import (
+	"math"
+	"sort"
+)
+
+// weightedPairwiseMedian returns the weighted median of the pairwise values
+// of sorted a with non-negative weights w. The pairs are i < j with weight
+// w[i]·w[j]; if ordered is set they are all ordered pairs (i, j), including
+// i = j, each with weight w[i]·w[j]. The pair value is the absolute
+// difference, or the average (a[i] + a[j])/2 if average is set.
+//
+// The median is the smallest pair value m whose cumulative weight W(≤m)
+// reaches half the total W. When W(≤m) is exactly W/2, m is averaged with the
+// next larger pair value, so equal weights reproduce the unweighted median.
+// Weights are summed in float64 with compensation, so "exactly W/2" means
+// within relativeEpsilon·W. Zero-weight values take no part. Returns 0 when no
+// pair has positive weight.
+//
+// The pairs form an implicit matrix whose rows are sorted, so the median is
+// found by selection: a random active pair is the pivot, each row is split
+// around it by binary search, and the side holding the median stays active.
+// Time complexity: O(n log² n) expected
+// Space complexity: O(n)
+func weightedPairwiseMedian(a, w []float64, average, ordered bool) float64 {
+	values := make([]float64, 0, len(a))
+	weights := make([]float64, 0, len(a))
+	for i, wi := range w {
+		if wi > 0 {
+			values = append(values, a[i])
+			weights = append(weights, wi)
+		}
+	}
+	n := len(values)
+	start := 1 // first column of row i is i + start
+	if ordered {
+		start = 0
+	}
+	if n-start <= 0 {
+		return 0
+	}
+
+	// value is the pair value of cell (i, j), j >= i + start; rows are
+	// non-decreasing in j because values is sorted.
+	value := func(i, j int) float64 {
+		if average {
+			return 0.5*values[i] + 0.5*values[j]
+		}
+		return values[j] - values[i]
+	}
+
+	prefix := make([]float64, n+1)
+	var acc compensatedSum
+	for i, wi := range weights {
+		acc.add(wi)
+		prefix[i+1] = acc.value()
+	}
+	// rangeWeight returns the weight of cells (i, j), lo <= j < hi. With
+	// ordered pairs an off-diagonal cell stands for (i, j) and (j, i).
+	rangeWeight := func(i, lo, hi int) float64 {
+		if lo >= hi {
+			return 0
+		}
+		weight := (prefix[hi] - prefix[lo]) * weights[i]
+		if ordered {
+			weight *= 2
+			if lo == i {
+				weight -= weights[i] * weights[i]
+			}
+		}
+		return weight
+	}
+
+	lo := make([]int, n)
+	hi := make([]int, n)
+	var totalSum compensatedSum
+	for i := range lo {
+		lo[i], hi[i] = i+start, n
+		totalSum.add(rangeWeight(i, lo[i], hi[i]))
+	}
+	total := totalSum.value()
+	tolerance := relativeEpsilon * total
+	// reaches reports whether weight x is at least half the total.
+	reaches := func(x float64) bool {
+		return 2*x >= total-tolerance
+	}
+	// resolve returns m, or its average with the next larger pair value when
+	// the weight at or below m is half the total.
+	resolve := func(m, atOrBelow float64) float64 {
+		if math.Abs(2*atOrBelow-total) > tolerance {
+			return m
+		}
+		next := math.Inf(1)
+		for i := 0; i+start < n; i++ {
+			first := i + start
+			k := first + sort.Search(n-first, func(k int) bool { return value(i, first+k) > m })
+			if k < n && value(i, k) < next {
+				next = value(i, k)
+			}
+		}
+		if math.IsInf(next, 1) {
+			return m
+		}
+		return 0.5*m + 0.5*next
+	}
+
+	rng := NewRngFromSeed(deriveSeed(values))
+	var below compensatedSum // weight of the discarded cells smaller than the median
+	ltEnd := make([]int, n)
+	leEnd := make([]int, n)
+	for {
+		active := int64(0)
+		for i := range lo {
+			active += int64(hi[i] - lo[i])
+		}
+		if active <= int64(n) {
+			break
+		}
+
+		// A random active cell is the pivot; each iteration discards it, so
+		// the loop terminates.
+		t := rng.UniformInt64(0, active)
+		row := 0
+		for ; t >= int64(hi[row]-lo[row]); row++ {
+			t -= int64(hi[row] - lo[row])
+		}
+		pivot := value(row, lo[row]+int(t))
+
+		lt, le := below, compensatedSum{}
+		for i := range lo {
+			l, h := lo[i], hi[i]
+			ltEnd[i] = l + sort.Search(h-l, func(k int) bool { return value(i, l+k) >= pivot })
+			leEnd[i] = ltEnd[i] + sort.Search(h-ltEnd[i], func(k int) bool { return value(i, ltEnd[i]+k) > pivot })
+			lt.add(rangeWeight(i, l, ltEnd[i]))
+			le.add(rangeWeight(i, ltEnd[i], leEnd[i]))
+		}
+		le.addSum(lt)
+		switch {
+		case reaches(lt.value()):
+			copy(hi, ltEnd)
+		case !reaches(le.value()):
+			copy(lo, leEnd)
+			below = le
+		default:
+			return resolve(pivot, le.value())
+		}
+	}
+
+	// Few cells are left: sort them and accumulate their weights onto below.
+	type pair struct {
+		value  float64
+		weight float64
+	}
+	var pairs []pair
+	for i := range lo {
+		for j := lo[i]; j < hi[i]; j++ {
+			pairs = append(pairs, pair{value(i, j), rangeWeight(i, j, j+1)})
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].value < pairs[j].value })
+	for k, p := range pairs {
+		below.add(p.weight)
+		if k+1 < len(pairs) && pairs[k+1].value == p.value {
+			continue
+		}
+		if reaches(below.value()) {
+			return resolve(p.value, below.value())
+		}
+	}
+	return pairs[len(pairs)-1].value
+}
+
+// compensatedSum accumulates float64 terms with Neumaier's compensation, so
+// the rounding error of a sum stays of the order of one ulp of the result
+// rather than growing with the number of terms.
+type compensatedSum struct {
+	sum, c float64
+}
+
+func (s *compensatedSum) add(x float64) {
+	t := s.sum + x
+	if math.Abs(s.sum) >= math.Abs(x) {
+		s.c += (s.sum - t) + x
+	} else {
+		s.c += (x - t) + s.sum
+	}
+	s.sum = t
+}
+
+// addSum adds another compensated sum, keeping both compensation terms.
+func (s *compensatedSum) addSum(o compensatedSum) {
+	s.add(o.sum)
+	s.add(o.c)
+}
+
+func (s *compensatedSum) value() float64 {
+	return s.sum + s.c
+}
@@ -0,0 +1,110 @@
+package pragmastat
+
+import (
+	"math"
+	"math/big"
+	"sort"
+	"testing"
+)
+
+func TestWeightedPairwiseMedianMatchesBruteForce(t *testing.T) {
+	rng := NewRngFromString("weighted-pairwise")
+	for trial := 0; trial < 300; trial++ {
+		n := 1 + int(rng.UniformInt64(0, 24))
+		values := make([]float64, n)
+		weights := make([]float64, n)
+		for i := range values {
+			// Small integers give many tied pair values and exact half splits.
+			values[i] = float64(rng.UniformInt64(0, 8))
+			weights[i] = []float64{0, 0.1, 0.2, 0.3, 1, 2.5}[rng.UniformInt64(0, 6)]
+		}
+		sort.Float64s(values)
+		for _, mode := range []struct{ average, ordered bool }{{false, false}, {false, true}, {true, true}} {
+			got := weightedPairwiseMedian(values, weights, mode.average, mode.ordered)
+			want := bruteForceWeightedPairwiseMedian(values, weights, mode.average, mode.ordered)
+			if got != want {
+				t.Fatalf("values %v, weights %v, average %v, ordered %v: got %v, want %v",
+					values, weights, mode.average, mode.ordered, got, want)
+			}
+		}
+	}
+}
+
+func TestWeightedPairwiseMedianEqualWeights(t *testing.T) {
+	rng := NewRngFromString("weighted-pairwise-equal")
+	for _, n := range []int{2, 3, 10, 51, 400} {
+		x := NewAdditive(0, 1).Samples(rng, n)
+		sort.Float64s(x)
+		for i := range x[:n/4] {
+			x[i] = x[n/4] // ties
+		}
+		want, _ := spreadImpl(x, true)
+		if got := weightedPairwiseMedian(x, uniformWeights(n, 0.1), false, false); got != want {
+			t.Errorf("n = %d: weighted spread with equal weights = %v, want %v", n, got, want)
+		}
+	}
+}
+
+func TestWeightedPairwiseMedianLarge(t *testing.T) {
+	rng := NewRngFromString("weighted-pairwise-large")
+	n := 5000
+	x := NewExp(1).Samples(rng, n)
+	sort.Float64s(x)
+	w := NewUniform(0, 1).Samples(rng, n)
+	got := weightedPairwiseMedian(x, w, false, false)
+	// For Exp(1) the median of |X1 - X2| is ln 2; the weights are independent.
+	if math.Abs(got-math.Ln2) > 0.05 {
+		t.Errorf("weighted spread of Exp(1) = %v, want about %v", got, math.Ln2)
+	}
+}
+
+// bruteForceWeightedPairwiseMedian lists every pair with an exact rational
+// weight and applies the definition of weightedPairwiseMedian directly,
+// including its relativeEpsilon·W tolerance for the half-weight test (weights
+// such as 0.1 and 0.2 split decimal totals in half only up to rounding).
+func bruteForceWeightedPairwiseMedian(a, w []float64, average, ordered bool) float64 {
+	type pair struct {
+		value  float64
+		weight *big.Rat
+	}
+	var pairs []pair
+	for i := range a {
+		for j := range a {
+			if (!ordered && j <= i) || w[i] == 0 || w[j] == 0 {
+				continue
+			}
+			weight := new(big.Rat).Mul(new(big.Rat).SetFloat64(w[i]), new(big.Rat).SetFloat64(w[j]))
+			value := math.Abs(a[j] - a[i])
+			if average {
+				value = 0.5*a[i] + 0.5*a[j]
+			}
+			pairs = append(pairs, pair{value, weight})
+		}
+	}
+	if len(pairs) == 0 {
+		return 0
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].value < pairs[j].value })
+	total := new(big.Rat)
+	for _, p := range pairs {
+		total.Add(total, p.weight)
+	}
+	tolerance := new(big.Rat).Mul(total, new(big.Rat).SetFloat64(relativeEpsilon))
+	cum := new(big.Rat)
+	gap := new(big.Rat)
+	for k, p := range pairs {
+		cum.Add(cum, p.weight)
+		if k+1 < len(pairs) && pairs[k+1].value == p.value {
+			continue
+		}
+		// gap = 2·W(≤m) - W
+		gap.Sub(new(big.Rat).Add(cum, cum), total)
+		if new(big.Rat).Abs(gap).Cmp(tolerance) <= 0 && k+1 < len(pairs) {
+			return 0.5*p.value + 0.5*pairs[k+1].value
+		}
+		if gap.Sign() > 0 {
+			return p.value
+		}
+	}
+	return pairs[len(pairs)-1].value
+}
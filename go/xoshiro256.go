@@ -35,6 +35,22 @@ func newXoshiro256PlusPlus(seed uint64) *xoshiro256PlusPlus {
 	}
 }
 
+// newXoshiro256PlusPlusIndexed expands seed with SplitMix64 and XORs each state
+// word with the matching output of a second SplitMix64 seeded with index, so
+// streams for neighbouring indices share no structure.
+func newXoshiro256PlusPlusIndexed(seed, index uint64) *xoshiro256PlusPlus {
+	sm := newSplitMix64(seed)
+	im := newSplitMix64(index)
+	return &xoshiro256PlusPlus{
+		state: [4]uint64{
+			sm.next() ^ im.next(),
+			sm.next() ^ im.next(),
+			sm.next() ^ im.next(),
+			sm.next() ^ im.next(),
+		},
+	}
+}
+
 func (x *xoshiro256PlusPlus) nextU64() uint64 {
 	result := bits.RotateLeft64(x.state[0]+x.state[3], 23) + x.state[0]
 
@@ -51,6 +67,41 @@ func (x *xoshiro256PlusPlus) nextU64() uint64 {
 	return result
 }
 
+// ========================================================================
+// Jump Methods
+// ========================================================================
+
+// Jump polynomials from the reference implementation: jump advances the state
+// by 2^128 steps and longJump by 2^192 steps.
+var (
+	xoshiroJump     = [4]uint64{0x180ec6d33cfd0aba, 0xd5a61266f0c9392c, 0xa9582618e03fc9aa, 0x39abdc4529b1661c}
+	xoshiroLongJump = [4]uint64{0x76e15d3efefdcbbf, 0xc5004e441c522fb3, 0x77710069854ee241, 0x39109bb02acbe635}
+)
+
+func (x *xoshiro256PlusPlus) jump() {
+	x.applyJump(&xoshiroJump)
+}
+
+func (x *xoshiro256PlusPlus) longJump() {
+	x.applyJump(&xoshiroLongJump)
+}
+
+func (x *xoshiro256PlusPlus) applyJump(poly *[4]uint64) {
+	var s [4]uint64
+	for _, word := range poly {
+		for b := 0; b < 64; b++ {
+			if word&(uint64(1)<<b) != 0 {
+				s[0] ^= x.state[0]
+				s[1] ^= x.state[1]
+				s[2] ^= x.state[2]
+				s[3] ^= x.state[3]
+			}
+			x.nextU64()
+		}
+	}
+	x.state = s
+}
+
 // ========================================================================
 // Floating Point Methods
 // ========================================================================
@@ -60,6 +111,12 @@ func (x *xoshiro256PlusPlus) uniformFloat64() float64 {
 	return float64(x.nextU64()>>11) * (1.0 / float64(uint64(1)<<53))
 }
 
+func (x *xoshiro256PlusPlus) uniformFloat64Closed() float64 {
+	// The upper 53 bits span 0..2^53-1; dividing (rather than multiplying by
+	// the reciprocal) makes the maximum map to exactly 1
+	return float64(x.nextU64()>>11) / float64(uint64(1)<<53-1)
+}
+
 // Note: FP rounding in min + (max-min)*u can theoretically yield max
 // for extreme values of (max-min). Acceptable for statistical use.
 func (x *xoshiro256PlusPlus) uniformFloat64Range(min, max float64) float64 {
@@ -94,6 +151,32 @@ func (x *xoshiro256PlusPlus) uniformInt64(min, max int64) int64 {
 	return min + int64(x.nextU64()%rangeSize)
 }
 
+// uniformInt64Unbiased is uniformInt64 without modulo bias.
+func (x *xoshiro256PlusPlus) uniformInt64Unbiased(min, max int64) int64 {
+	if min >= max {
+		return min
+	}
+	return min + int64(x.uniformBelow(uint64(max)-uint64(min)))
+}
+
+// uniformBelow returns a uniform value in [0, s) for s > 0 using Lemire's
+// nearly-divisionless method: the high word of the 128-bit product of a
+// 64-bit output and s (a 64x64-bit multiply) is accepted unless the low word
+// falls in the biased zone, which has probability (2^64 mod s) / 2^64. It
+// consumes one or more outputs.
+// Reference: D. Lemire, "Fast Random Integer Generation in an Interval",
+// ACM TOMACS 29(1), 2019.
+func (x *xoshiro256PlusPlus) uniformBelow(s uint64) uint64 {
+	hi, lo := bits.Mul64(x.nextU64(), s)
+	if lo < s {
+		threshold := -s % s // 2^64 mod s
+		for lo < threshold {
+			hi, lo = bits.Mul64(x.nextU64(), s)
+		}
+	}
+	return hi
+}
+
 func (x *xoshiro256PlusPlus) uniformInt32(min, max int32) int32 {
 	if min >= max {
 		return min
@@ -184,9 +267,14 @@ const (
 	fnvPrime       = 0x00000100000001b3
 )
 
-// fnv1aHash computes FNV-1a 64-bit hash of a string
-func fnv1aHash(s string) uint64 {
-	hash := uint64(fnvOffsetBasis)
+// fnv1aHash computes FNV-1a 64-bit hash of a string or byte slice
+func fnv1aHash[S ~string | ~[]byte](s S) uint64 {
+	return fnv1aUpdate(fnvOffsetBasis, s)
+}
+
+// fnv1aUpdate continues an FNV-1a hash over further bytes, so that hashing
+// a and then b equals hashing their concatenation.
+func fnv1aUpdate[S ~string | ~[]byte](hash uint64, s S) uint64 {
 	for i := 0; i < len(s); i++ {
 		hash ^= uint64(s[i])
 		hash *= fnvPrime
@@ -213,6 +213,20 @@ struct PowerDistTestCase {
     output: Vec<f64>,
 }
 
+#[derive(Serialize)]
+struct StringSeedDistInput {
+    distribution: String,
+    seed: String,
+    params: Vec<f64>,
+    count: usize,
+}
+
+#[derive(Serialize)]
+struct StringSeedDistTestCase {
+    input: StringSeedDistInput,
+    output: Vec<f64>,
+}
+
 fn find_tests_dir() -> PathBuf {
     // Find repository root by looking for CITATION.cff
     let mut current = std::env::current_dir().expect("Cannot get current dir");
@@ -724,6 +738,51 @@ fn generate_power_distribution_tests(tests_dir: &PathBuf) {
     }
 }
 
+fn generate_string_seed_distribution_tests(tests_dir: &PathBuf) {
+    let dist_dir = tests_dir.join("distributions").join("string-seed");
+    fs::create_dir_all(&dist_dir).expect("Failed to create string-seed distribution test dir");
+
+    let test_configs: Vec<(&str, &str, Vec<f64>)> = vec![
+        ("uniform", "demo-dist-uniform", vec![0.0, 10.0]),
+        ("uniform", "pragmastat", vec![-1.0, 1.0]),
+        ("additive", "demo-dist-additive", vec![0.0, 1.0]),
+        ("additive", "pragmastat", vec![10.0, 2.0]),
+        ("multiplic", "demo-dist-multiplic", vec![0.0, 1.0]),
+        ("multiplic", "pragmastat", vec![1.0, 0.5]),
+        ("exp", "demo-dist-exp", vec![1.0]),
+        ("exp", "pragmastat", vec![2.5]),
+        ("power", "demo-dist-power", vec![1.0, 2.0]),
+        ("power", "pragmastat", vec![3.0, 1.5]),
+    ];
+    let count = 5;
+
+    for (distribution, seed, params) in test_configs {
+        let mut rng = Rng::from_string(seed);
+        let dist: Box<dyn Distribution> = match distribution {
+            "uniform" => Box::new(Uniform::new(params[0], params[1])),
+            "additive" => Box::new(Additive::new(params[0], params[1])),
+            "multiplic" => Box::new(Multiplic::new(params[0], params[1])),
+            "exp" => Box::new(Exp::new(params[0])),
+            "power" => Box::new(Power::new(params[0], params[1])),
+            _ => unreachable!(),
+        };
+        let values: Vec<f64> = (0..count).map(|_| dist.sample(&mut rng)).collect();
+
+        let test_case = StringSeedDistTestCase {
+            input: StringSeedDistInput {
+                distribution: distribution.to_string(),
+                seed: seed.to_string(),
+                params,
+                count,
+            },
+            output: values,
+        };
+
+        let filename = format!("{}-{}.json", distribution, string_seed_filename(seed));
+        write_json(&dist_dir.join(filename), &test_case);
+    }
+}
+
 fn main() {
     let tests_dir = find_tests_dir();
 
@@ -776,6 +835,7 @@ fn main() {
     generate_multiplic_distribution_tests(&tests_dir);
     generate_exp_distribution_tests(&tests_dir);
     generate_power_distribution_tests(&tests_dir);
+    generate_string_seed_distribution_tests(&tests_dir);
     println!();
 
     println!("Done! Test data generated successfully.");